@@ -67,9 +67,8 @@ func init() {
 	//+kubebuilder:scaffold:scheme
 	utilruntime.Must(gwv1alpha2.AddToScheme(scheme))
 	utilruntime.Must(gwv1beta1.AddToScheme(scheme))
-	utilruntime.Must(anv1alpha1.AddToScheme(scheme))
 	utilruntime.Must(discoveryv1.AddToScheme(scheme))
-	addOptionalCRDs(scheme)
+	// the types of the controller are registered once the group of ServiceExports and ServiceImports is configured
 }
 
 func addOptionalCRDs(scheme *runtime.Scheme) {
@@ -123,8 +122,15 @@ func main() {
 		"ClusterName", config.ClusterName,
 		"LogLevel", logLevel,
 		"DisableTaggingServiceAPI", config.DisableTaggingServiceAPI,
+		"DryRunMode", config.DryRunMode,
+		"DriftDetectionInterval", config.DriftDetectionInterval,
+		"DriftDetectionMode", config.DriftDetectionMode,
+		"MulticlusterAPIGroup", config.MulticlusterAPIGroup,
 	)
 
+	utilruntime.Must(anv1alpha1.AddToSchemeWithMulticlusterGroup(scheme, config.MulticlusterAPIGroup))
+	addOptionalCRDs(scheme)
+
 	cloud, err := aws.NewCloud(log.Named("cloud"), aws.CloudConfig{
 		VpcId:                     config.VpcID,
 		AccountId:                 config.AccountID,
@@ -174,22 +180,37 @@ func main() {
 		webhook.NewPodMutator(logger, scheme, readinessGateInjector).SetupWithManager(logger, mgr)
 	}
 
+	// dry-run mode does not modify resources, ServiceExports and ServiceImports are migrated once it is disabled
+	if !config.DryRunMode {
+		if err = mgr.Add(controllers.NewMulticlusterMigration(log.Named("multicluster-migration"), mgr)); err != nil {
+			setupLog.Fatalf("multicluster migration setup failed: %s", err)
+		}
+	}
+
 	finalizerManager := k8s.NewDefaultFinalizerManager(mgr.GetClient())
 
 	// parent logging scope for all controllers
 	ctrlLog := log.Named("controller")
 
-	err = controllers.RegisterPodController(ctrlLog.Named("pod"), mgr)
-	if err != nil {
-		setupLog.Fatalf("pod controller setup failed: %s", err)
+	// dry-run mode plans every reconcile already, there is nothing to repair
+	var driftDetector *controllers.DriftDetector
+	if config.DriftDetectionInterval > 0 && !config.DryRunMode {
+		driftDetector, err = controllers.NewDriftDetector(ctrlLog.Named("drift-detector"), cloud, mgr, metrics.Registry)
+		if err != nil {
+			setupLog.Fatalf("drift detector setup failed: %s", err)
+		}
+		if err = mgr.Add(driftDetector); err != nil {
+			setupLog.Fatalf("drift detector setup failed: %s", err)
+		}
 	}
 
-	err = controllers.RegisterServiceController(ctrlLog.Named("service"), cloud, finalizerManager, mgr)
-	if err != nil {
-		setupLog.Fatalf("service controller setup failed: %s", err)
+	if config.DryRunMode {
+		setupLog.Info("Dry-run mode is enabled, routes are annotated with planned changes and no VPC Lattice resource is modified")
+	} else {
+		registerDeployingControllers(setupLog, ctrlLog, cloud, finalizerManager, mgr, driftDetector)
 	}
 
-	err = controllers.RegisterGatewayClassController(ctrlLog.Named("gateway-class"), mgr)
+	err = controllers.RegisterGatewayClassController(ctrlLog.Named("gateway-class"), cloud, mgr)
 	if err != nil {
 		setupLog.Fatalf("gateway-class controller setup failed: %s", err)
 	}
@@ -199,40 +220,16 @@ func main() {
 		setupLog.Fatalf("gateway controller setup failed: %s", err)
 	}
 
-	err = controllers.RegisterAllRouteControllers(ctrlLog.Named("route"), cloud, finalizerManager, mgr)
+	err = controllers.RegisterAllRouteControllers(ctrlLog.Named("route"), cloud, finalizerManager, mgr, driftDetector)
 	if err != nil {
 		setupLog.Fatalf("route controller setup failed: %s", err)
 	}
 
-	err = controllers.RegisterServiceImportController(ctrlLog.Named("service-import"), mgr, finalizerManager)
-	if err != nil {
-		setupLog.Fatalf("serviceimport controller setup failed: %s", err)
-	}
-
-	err = controllers.RegisterServiceExportController(ctrlLog.Named("service-export"), cloud, finalizerManager, mgr)
-	if err != nil {
-		setupLog.Fatalf("serviceexport controller setup failed: %s", err)
-	}
-
-	err = controllers.RegisterAccessLogPolicyController(ctrlLog.Named("access-log-policy"), cloud, finalizerManager, mgr)
-	if err != nil {
-		setupLog.Fatalf("accesslogpolicy controller setup failed: %s", err)
-	}
-
-	err = controllers.RegisterIAMAuthPolicyController(ctrlLog.Named("iam-auth-policy"), mgr, cloud)
-	if err != nil {
-		setupLog.Fatalf("iam auth policy controller setup failed: %s", err)
-	}
-
 	err = controllers.RegisterTargetGroupPolicyController(ctrlLog.Named("target-group-policy"), mgr)
 	if err != nil {
 		setupLog.Fatalf("target group policy controller setup failed: %s", err)
 	}
 
-	err = controllers.RegisterVpcAssociationPolicyController(ctrlLog.Named("vpc-association-policy"), cloud, finalizerManager, mgr)
-	if err != nil {
-		setupLog.Fatalf("vpc association policy controller setup failed: %s", err)
-	}
 	//+kubebuilder:scaffold:builder
 
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
@@ -252,6 +249,51 @@ func main() {
 
 }
 
+// controllers modifying VPC Lattice resources outside of routes, they are not registered in dry-run mode
+func registerDeployingControllers(
+	setupLog gwlog.Logger,
+	ctrlLog gwlog.Logger,
+	cloud aws.Cloud,
+	finalizerManager k8s.FinalizerManager,
+	mgr ctrl.Manager,
+	driftDetector *controllers.DriftDetector,
+) {
+	err := controllers.RegisterPodController(ctrlLog.Named("pod"), mgr)
+	if err != nil {
+		setupLog.Fatalf("pod controller setup failed: %s", err)
+	}
+
+	err = controllers.RegisterServiceController(ctrlLog.Named("service"), cloud, finalizerManager, mgr)
+	if err != nil {
+		setupLog.Fatalf("service controller setup failed: %s", err)
+	}
+
+	err = controllers.RegisterServiceImportController(ctrlLog.Named("service-import"), cloud, mgr, finalizerManager)
+	if err != nil {
+		setupLog.Fatalf("serviceimport controller setup failed: %s", err)
+	}
+
+	err = controllers.RegisterServiceExportController(ctrlLog.Named("service-export"), cloud, finalizerManager, mgr, driftDetector)
+	if err != nil {
+		setupLog.Fatalf("serviceexport controller setup failed: %s", err)
+	}
+
+	err = controllers.RegisterAccessLogPolicyController(ctrlLog.Named("access-log-policy"), cloud, finalizerManager, mgr)
+	if err != nil {
+		setupLog.Fatalf("accesslogpolicy controller setup failed: %s", err)
+	}
+
+	err = controllers.RegisterIAMAuthPolicyController(ctrlLog.Named("iam-auth-policy"), mgr, cloud)
+	if err != nil {
+		setupLog.Fatalf("iam auth policy controller setup failed: %s", err)
+	}
+
+	err = controllers.RegisterVpcAssociationPolicyController(ctrlLog.Named("vpc-association-policy"), cloud, finalizerManager, mgr)
+	if err != nil {
+		setupLog.Fatalf("vpc association policy controller setup failed: %s", err)
+	}
+}
+
 func logLevel() zapcore.Level {
 	level := os.Getenv("LOG_LEVEL")
 	switch level {
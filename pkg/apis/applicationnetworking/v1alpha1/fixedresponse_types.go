@@ -0,0 +1,43 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	FixedResponseKind = "FixedResponse"
+)
+
+// +genclient
+// +kubebuilder:object:root=true
+
+// +kubebuilder:resource:categories=gateway-api,shortName=fr
+// +kubebuilder:storageversion
+// +kubebuilder:printcolumn:name="Status Code",type=integer,JSONPath=`.spec.statusCode`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+type FixedResponse struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec FixedResponseSpec `json:"spec"`
+}
+
+// +kubebuilder:object:root=true
+// FixedResponseList contains a list of FixedResponses.
+type FixedResponseList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []FixedResponse `json:"items"`
+}
+
+// FixedResponseSpec defines the response of the HTTPRoute rules referencing the FixedResponse
+// through an ExtensionRef filter. Those rules answer requests themselves instead of forwarding
+// them to their backendRefs.
+type FixedResponseSpec struct {
+	// StatusCode is the HTTP status code of the response.
+	//
+	// VPC Lattice fixed responses have no body and no headers, and only support the 404 and 500 status codes.
+	//
+	// +kubebuilder:validation:Enum=404;500
+	StatusCode int32 `json:"statusCode"`
+}
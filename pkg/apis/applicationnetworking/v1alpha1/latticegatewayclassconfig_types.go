@@ -0,0 +1,80 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	LatticeGatewayClassConfigKind = "LatticeGatewayClassConfig"
+)
+
+// +genclient
+// +genclient:nonNamespaced
+// +kubebuilder:object:root=true
+
+// +kubebuilder:resource:categories=gateway-api,scope=Cluster,shortName=lgcc
+// +kubebuilder:storageversion
+// +kubebuilder:printcolumn:name="Default Service Network",type=string,JSONPath=`.spec.defaultServiceNetwork`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+type LatticeGatewayClassConfig struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec LatticeGatewayClassConfigSpec `json:"spec"`
+}
+
+// +kubebuilder:object:root=true
+// LatticeGatewayClassConfigList contains a list of LatticeGatewayClassConfigs.
+type LatticeGatewayClassConfigList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []LatticeGatewayClassConfig `json:"items"`
+}
+
+// LatticeGatewayClassConfigSpec defines the defaults applied to the Gateways of the GatewayClasses referencing
+// the LatticeGatewayClassConfig through their parametersRef. Gateways of classes without a parametersRef keep
+// the controller-wide defaults.
+type LatticeGatewayClassConfigSpec struct {
+	// DefaultServiceNetwork is the name of a service network created and associated to the cluster VPC
+	// when a GatewayClass referencing this config is accepted, like the DEFAULT_SERVICE_NETWORK
+	// environment variable does for the whole controller.
+	//
+	// +optional
+	// +kubebuilder:validation:MaxLength=63
+	// +kubebuilder:validation:MinLength=3
+	DefaultServiceNetwork string `json:"defaultServiceNetwork,omitempty"`
+
+	// ServiceNetworkAuthType is the auth type of the service networks the controller creates for the
+	// Gateways of the class. Defaults to NONE. An IAMAuthPolicy targeting a Gateway still sets AWS_IAM.
+	//
+	// +optional
+	// +kubebuilder:validation:Enum=NONE;AWS_IAM
+	ServiceNetworkAuthType string `json:"serviceNetworkAuthType,omitempty"`
+
+	// Tags are added to the service networks and VPC associations the controller creates for the
+	// Gateways of the class. Keys with the application-networking.k8s.aws/ prefix are reserved for the
+	// controller.
+	//
+	// +optional
+	// +kubebuilder:validation:MaxProperties=40
+	Tags map[string]string `json:"tags,omitempty"`
+
+	// SecurityGroupIds are enforced on the VPC associations the controller creates for the service
+	// networks of the class. A VpcAssociationPolicy targeting a Gateway takes precedence.
+	//
+	// +optional
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=5
+	SecurityGroupIds []SecurityGroupId `json:"securityGroupIds,omitempty"`
+
+	// ServiceNetworkNameTemplate is a Go template naming the service network of each Gateway of the class,
+	// with the fields .Name and .Namespace of the Gateway and .ClassName. The service network is named
+	// after the Gateway when unset.
+	//
+	// Changing it renames the service network of existing Gateways, set it before creating them.
+	//
+	// +optional
+	// +kubebuilder:validation:MaxLength=255
+	// +kubebuilder:validation:MinLength=1
+	ServiceNetworkNameTemplate string `json:"serviceNetworkNameTemplate,omitempty"`
+}
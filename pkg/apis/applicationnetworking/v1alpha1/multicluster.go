@@ -0,0 +1,51 @@
+package v1alpha1
+
+import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// MulticlusterGroupName is the group of the upstream Kubernetes Multi-Cluster Services API,
+// ServiceExport and ServiceImport can be served by it instead of GroupName.
+const MulticlusterGroupName = "multicluster.x-k8s.io"
+
+// IsMulticlusterGroup tells whether ServiceExport and ServiceImport references of the group are handled.
+// References of either group resolve to the objects of the group the scheme registers them under.
+func IsMulticlusterGroup(group string) bool {
+	return group == GroupName || group == MulticlusterGroupName
+}
+
+// AddToSchemeWithMulticlusterGroup registers the types of AddToScheme, with ServiceExport and
+// ServiceImport under the given group. A type can only be registered under one group of a scheme.
+func AddToSchemeWithMulticlusterGroup(scheme *runtime.Scheme, group string) error {
+	if group == GroupName {
+		return AddToScheme(scheme)
+	}
+
+	scheme.AddKnownTypes(SchemeGroupVersion,
+		&AccessLogPolicy{},
+		&AccessLogPolicyList{},
+		&FixedResponse{},
+		&FixedResponseList{},
+		&IAMAuthPolicy{},
+		&IAMAuthPolicyList{},
+		&LatticeGatewayClassConfig{},
+		&LatticeGatewayClassConfigList{},
+		&TargetGroupPolicy{},
+		&TargetGroupPolicyList{},
+		&VpcAssociationPolicy{},
+		&VpcAssociationPolicyList{},
+	)
+	v1.AddToGroupVersion(scheme, SchemeGroupVersion)
+
+	multiclusterGroupVersion := schema.GroupVersion{Group: group, Version: SchemeGroupVersion.Version}
+	scheme.AddKnownTypes(multiclusterGroupVersion,
+		&ServiceExport{},
+		&ServiceExportList{},
+		&ServiceImport{},
+		&ServiceImportList{},
+	)
+	v1.AddToGroupVersion(scheme, multiclusterGroupVersion)
+	return nil
+}
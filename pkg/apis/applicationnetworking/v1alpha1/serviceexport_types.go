@@ -30,6 +30,9 @@ type ServiceExport struct {
 	apimachineryv1.TypeMeta `json:",inline"`
 	// +optional
 	apimachineryv1.ObjectMeta `json:"metadata,omitempty"`
+	// spec defines the ports of the Service exported.
+	// +optional
+	Spec ServiceExportSpec `json:"spec,omitempty"`
 	// status describes the current state of an exported service.
 	// Service configuration comes from the Service that had the same
 	// name and namespace as this ServiceExport.
@@ -38,6 +41,37 @@ type ServiceExport struct {
 	Status ServiceExportStatus `json:"status,omitempty"`
 }
 
+// ServiceExportSpec defines the ports of the Service exported.
+type ServiceExportSpec struct {
+	// ports of the Service to export, each to a VPC Lattice target group of its own.
+	//
+	// When empty, a single target group is created, registering the ports listed in the
+	// application-networking.k8s.aws/port annotation, or all the ports of the Service.
+	// +optional
+	// +listType=map
+	// +listMapKey=port
+	// +kubebuilder:validation:MaxItems=10
+	Ports []ExportedPort `json:"ports,omitempty"`
+}
+
+// ExportedPort is a port of the Service exported to a target group of its own.
+type ExportedPort struct {
+	// port is the Service port to export. The target group registers the endpoints of this port.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	Port int32 `json:"port"`
+	// protocol of the target group, HTTP, HTTPS or TCP.
+	// Defaults to the protocol of the applicable TargetGroupPolicy, or HTTP.
+	// +optional
+	// +kubebuilder:validation:Enum=HTTP;HTTPS;TCP
+	Protocol string `json:"protocol,omitempty"`
+	// protocolVersion of the target group, HTTP1, HTTP2 or GRPC. Not supported with TCP.
+	// Defaults to the protocol version of the applicable TargetGroupPolicy, or HTTP1.
+	// +optional
+	// +kubebuilder:validation:Enum=HTTP1;HTTP2;GRPC
+	ProtocolVersion string `json:"protocolVersion,omitempty"`
+}
+
 // ServiceExportStatus contains the current status of an export.
 type ServiceExportStatus struct {
 	// +optional
@@ -46,6 +80,23 @@ type ServiceExportStatus struct {
 	// +listType=map
 	// +listMapKey=type
 	Conditions []ServiceExportCondition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
+	// targetGroups are the VPC Lattice target groups created for the export.
+	// +optional
+	// +listType=atomic
+	TargetGroups []ExportedTargetGroup `json:"targetGroups,omitempty"`
+}
+
+// ExportedTargetGroup identifies a VPC Lattice target group created for an export.
+type ExportedTargetGroup struct {
+	// arn of the target group.
+	Arn string `json:"arn"`
+	// port of the target group.
+	Port int32 `json:"port"`
+	// protocol of the target group, HTTP, HTTPS or TCP.
+	Protocol string `json:"protocol"`
+	// protocolVersion of the target group, HTTP1, HTTP2 or GRPC. Empty for TCP.
+	// +optional
+	ProtocolVersion string `json:"protocolVersion,omitempty"`
 }
 
 // ServiceExportConditionType identifies a specific condition.
@@ -66,6 +117,31 @@ const (
 	ServiceExportConflict ServiceExportConditionType = "Conflict"
 )
 
+const (
+	// ServiceExportReasonTargetGroupCreated is used with the Valid condition when the target group of
+	// the export is created.
+	ServiceExportReasonTargetGroupCreated = "TargetGroupCreated"
+	// ServiceExportReasonServiceNotFound is used with the Valid condition when the exported Service does
+	// not exist.
+	ServiceExportReasonServiceNotFound = "ServiceNotFound"
+	// ServiceExportReasonFailedBuildModel is used with the Valid condition when the target group of the
+	// export cannot be built, e.g. from an invalid TargetGroupPolicy.
+	ServiceExportReasonFailedBuildModel = "FailedBuildModel"
+	// ServiceExportReasonFailedDeployModel is used with the Valid condition when the target group of the
+	// export cannot be created or updated.
+	ServiceExportReasonFailedDeployModel = "FailedDeployModel"
+
+	// ServiceExportReasonNoConflicts is used with the Conflict condition when the exports of other clusters
+	// are compatible.
+	ServiceExportReasonNoConflicts = "NoConflicts"
+	// ServiceExportReasonPortConflict is used with the Conflict condition when another cluster exports the
+	// service on another port.
+	ServiceExportReasonPortConflict = "PortConflict"
+	// ServiceExportReasonProtocolConflict is used with the Conflict condition when another cluster exports
+	// the service with another protocol on the same port.
+	ServiceExportReasonProtocolConflict = "ProtocolConflict"
+)
+
 // ServiceExportCondition contains details for the current condition of this
 // service export.
 //
@@ -112,13 +112,41 @@ type ServiceImportStatus struct {
 	// +listType=map
 	// +listMapKey=cluster
 	Clusters []ClusterStatus `json:"clusters,omitempty"`
+	// conditions describe whether the ServiceImport resolves to the target groups of
+	// exported services.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	// +kubebuilder:validation:MaxItems=8
+	Conditions []apimachineryv1.Condition `json:"conditions,omitempty"`
 }
 
+const (
+	// ServiceImportConditionReady is True when the ServiceImport resolves to the target group
+	// of at least one exported service.
+	ServiceImportConditionReady = "Ready"
+
+	// ServiceImportReasonResolved is used with the Ready condition when target groups of
+	// exported services are found.
+	ServiceImportReasonResolved = "Resolved"
+
+	// ServiceImportReasonNoMatchingExports is used with the Ready condition when no exported
+	// service matches the ServiceImport.
+	ServiceImportReasonNoMatchingExports = "NoMatchingExports"
+)
+
 // ClusterStatus contains service configuration mapped to a specific source cluster
 type ClusterStatus struct {
 	// cluster is the name of the exporting cluster. Must be a valid RFC-1123 DNS
 	// label.
 	Cluster string `json:"cluster"`
+	// vpcId is the VPC of the target groups exported by the cluster.
+	// +optional
+	VpcId string `json:"vpcId,omitempty"`
+	// ports are exported by the cluster, one per target group.
+	// +optional
+	// +listType=atomic
+	Ports []ServicePort `json:"ports,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -59,8 +59,12 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 	scheme.AddKnownTypes(SchemeGroupVersion,
 		&AccessLogPolicy{},
 		&AccessLogPolicyList{},
+		&FixedResponse{},
+		&FixedResponseList{},
 		&IAMAuthPolicy{},
 		&IAMAuthPolicyList{},
+		&LatticeGatewayClassConfig{},
+		&LatticeGatewayClassConfigList{},
 		&ServiceExport{},
 		&ServiceExportList{},
 		&ServiceImport{},
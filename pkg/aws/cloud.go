@@ -35,6 +35,7 @@ type Cloud interface {
 	Config() CloudConfig
 	Lattice() services.Lattice
 	Tagging() services.Tagging
+	ACM() services.ACM
 
 	// creates lattice tags with default values populated
 	DefaultTags() services.Tags
@@ -45,6 +46,9 @@ type Cloud interface {
 	// check if managedBy tag set for lattice resource
 	IsArnManaged(ctx context.Context, arn string) (bool, error)
 
+	// check if managedBy tag set in the given tags, for resources whose tags are not read from lattice
+	IsManagedByTags(tags services.Tags) bool
+
 	// check ownership and acquire if it is not owned by anyone.
 	TryOwn(ctx context.Context, arn string) (bool, error)
 	TryOwnFromTags(ctx context.Context, arn string, tags services.Tags) (bool, error)
@@ -91,7 +95,13 @@ func NewCloud(log gwlog.Logger, cfg CloudConfig, metricsRegisterer prometheus.Re
 		tagging = services.NewDefaultTagging(sess, cfg.Region)
 	}
 
-	cl := NewDefaultCloudWithTagging(lattice, tagging, cfg)
+	cl := &defaultCloud{
+		cfg:          cfg,
+		lattice:      lattice,
+		tagging:      tagging,
+		acm:          services.NewDefaultACM(sess, cfg.Region),
+		managedByTag: getManagedByTag(cfg),
+	}
 	return cl, nil
 }
 
@@ -113,10 +123,21 @@ func NewDefaultCloudWithTagging(lattice services.Lattice, tagging services.Taggi
 	}
 }
 
+// Used in testing and mocks
+func NewDefaultCloudWithACM(lattice services.Lattice, acm services.ACM, cfg CloudConfig) Cloud {
+	return &defaultCloud{
+		cfg:          cfg,
+		lattice:      lattice,
+		acm:          acm,
+		managedByTag: getManagedByTag(cfg),
+	}
+}
+
 type defaultCloud struct {
 	cfg          CloudConfig
 	lattice      services.Lattice
 	tagging      services.Tagging
+	acm          services.ACM
 	managedByTag string
 }
 
@@ -128,6 +149,10 @@ func (c *defaultCloud) Tagging() services.Tagging {
 	return c.tagging
 }
 
+func (c *defaultCloud) ACM() services.ACM {
+	return c.acm
+}
+
 func (c *defaultCloud) Config() CloudConfig {
 	return c.cfg
 }
@@ -169,6 +194,10 @@ func (c *defaultCloud) IsArnManaged(ctx context.Context, arn string) (bool, erro
 	return c.isOwner(c.getManagedByFromTags(tags)), nil
 }
 
+func (c *defaultCloud) IsManagedByTags(tags services.Tags) bool {
+	return c.isOwner(c.getManagedByFromTags(tags))
+}
+
 func (c *defaultCloud) TryOwn(ctx context.Context, arn string) (bool, error) {
 	// For resources that need backwards compatibility - not having managedBy is considered as owned by controller.
 	tags, err := c.getTags(ctx, arn)
@@ -35,6 +35,20 @@ func (m *MockCloud) EXPECT() *MockCloudMockRecorder {
 	return m.recorder
 }
 
+// ACM mocks base method.
+func (m *MockCloud) ACM() services.ACM {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ACM")
+	ret0, _ := ret[0].(services.ACM)
+	return ret0
+}
+
+// ACM indicates an expected call of ACM.
+func (mr *MockCloudMockRecorder) ACM() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ACM", reflect.TypeOf((*MockCloud)(nil).ACM))
+}
+
 // Config mocks base method.
 func (m *MockCloud) Config() CloudConfig {
 	m.ctrl.T.Helper()
@@ -92,6 +106,20 @@ func (mr *MockCloudMockRecorder) IsArnManaged(arg0, arg1 interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsArnManaged", reflect.TypeOf((*MockCloud)(nil).IsArnManaged), arg0, arg1)
 }
 
+// IsManagedByTags mocks base method.
+func (m *MockCloud) IsManagedByTags(arg0 map[string]*string) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsManagedByTags", arg0)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsManagedByTags indicates an expected call of IsManagedByTags.
+func (mr *MockCloudMockRecorder) IsManagedByTags(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsManagedByTags", reflect.TypeOf((*MockCloud)(nil).IsManagedByTags), arg0)
+}
+
 // Lattice mocks base method.
 func (m *MockCloud) Lattice() services.Lattice {
 	m.ctrl.T.Helper()
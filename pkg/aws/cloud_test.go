@@ -86,6 +86,17 @@ func TestIsArnManaged(t *testing.T) {
 	})
 }
 
+func TestIsManagedByTags(t *testing.T) {
+	cfg := CloudConfig{VpcId: "vpc-id", AccountId: "account-id"}
+	cl := NewDefaultCloud(nil, cfg)
+	other := NewDefaultCloud(nil, CloudConfig{VpcId: "other-vpc-id", AccountId: "account-id"})
+
+	assert.True(t, cl.IsManagedByTags(cl.DefaultTagsMergedWith(services.Tags{"key": aws.String("value")})))
+	assert.False(t, cl.IsManagedByTags(other.DefaultTags()))
+	assert.False(t, cl.IsManagedByTags(services.Tags{}))
+	assert.False(t, cl.IsManagedByTags(nil))
+}
+
 func Test_DefaultTagsMergedWith(t *testing.T) {
 	c := gomock.NewController(t)
 	defer c.Finish()
@@ -0,0 +1,53 @@
+package services
+
+import (
+	"errors"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/acm"
+	"github.com/aws/aws-sdk-go/service/acm/acmiface"
+)
+
+//go:generate mockgen -destination acm_mocks.go -package services github.com/aws/aws-application-networking-k8s/pkg/aws/services ACM
+
+type ACM interface {
+	acmiface.ACMAPI
+}
+
+type defaultACM struct {
+	acmiface.ACMAPI
+}
+
+func NewDefaultACM(sess *session.Session, region string) *defaultACM {
+	api := acm.New(sess, &aws.Config{Region: aws.String(region)})
+	return &defaultACM{ACMAPI: api}
+}
+
+func IsACMNotFoundError(err error) bool {
+	var aerr awserr.Error
+	return errors.As(err, &aerr) && aerr.Code() == acm.ErrCodeResourceNotFoundException
+}
+
+// certificates used by a lattice service cannot be deleted
+func IsACMInUseError(err error) bool {
+	var aerr awserr.Error
+	return errors.As(err, &aerr) && aerr.Code() == acm.ErrCodeResourceInUseException
+}
+
+func ToACMTags(tags Tags) []*acm.Tag {
+	acmTags := make([]*acm.Tag, 0, len(tags))
+	for k, v := range tags {
+		acmTags = append(acmTags, &acm.Tag{Key: aws.String(k), Value: v})
+	}
+	return acmTags
+}
+
+func FromACMTags(acmTags []*acm.Tag) Tags {
+	tags := make(Tags, len(acmTags))
+	for _, tag := range acmTags {
+		tags[aws.StringValue(tag.Key)] = tag.Value
+	}
+	return tags
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"time"
 
 	"strings"
 
@@ -28,6 +29,26 @@ const (
 	AWS_ACCOUNT_ID                  = "AWS_ACCOUNT_ID"
 	DEV_MODE                        = "DEV_MODE"
 	WEBHOOK_ENABLED                 = "WEBHOOK_ENABLED"
+	DRY_RUN                         = "DRY_RUN"
+	DRIFT_DETECTION_INTERVAL        = "DRIFT_DETECTION_INTERVAL"
+	DRIFT_DETECTION_MODE            = "DRIFT_DETECTION_MODE"
+	MERGE_ROUTES_BY_HOSTNAME        = "MERGE_ROUTES_BY_HOSTNAME"
+	SERVICE_IMPORT_NAMESPACES       = "SERVICE_IMPORT_NAMESPACES"
+	MULTICLUSTER_API_GROUP          = "MULTICLUSTER_API_GROUP"
+)
+
+const (
+	// drifted resources are only reported through events and metrics
+	DriftDetectionModeReport = "report"
+	// drifted resources are reported, then reconciled back to their desired state
+	DriftDetectionModeRepair = "repair"
+)
+
+const (
+	// ServiceExports and ServiceImports are served by the group of the controller CRDs
+	MulticlusterAPIGroupApplicationNetworking = "application-networking.k8s.aws"
+	// ServiceExports and ServiceImports are served by the upstream Multi-Cluster Services API group
+	MulticlusterAPIGroupUpstream = "multicluster.x-k8s.io"
 )
 
 var VpcID = ""
@@ -40,6 +61,20 @@ var WebhookEnabled = ""
 
 var DisableTaggingServiceAPI = false
 var ServiceNetworkOverrideMode = false
+var DryRunMode = false
+
+// routes of a gateway sharing a hostname are deployed as a single lattice service
+var MergeRoutesByHostname = false
+
+// drift detection is disabled when the interval is 0
+var DriftDetectionInterval time.Duration
+var DriftDetectionMode = DriftDetectionModeReport
+
+// ServiceImports are created in these namespaces for the newly seen exports of their services
+var ServiceImportNamespaces []string
+
+// group of the ServiceExports and ServiceImports handled by the controller
+var MulticlusterAPIGroup = MulticlusterAPIGroupApplicationNetworking
 
 func ConfigInit() error {
 	sess, _ := session.NewSession()
@@ -90,6 +125,20 @@ func configInit(sess *session.Session, metadata EC2Metadata) error {
 		DisableTaggingServiceAPI = true
 	}
 
+	DryRunMode = strings.ToLower(os.Getenv(DRY_RUN)) == "true"
+	MergeRoutesByHostname = strings.ToLower(os.Getenv(MERGE_ROUTES_BY_HOSTNAME)) == "true"
+	ServiceImportNamespaces = splitList(os.Getenv(SERVICE_IMPORT_NAMESPACES))
+
+	DriftDetectionInterval, DriftDetectionMode, err = driftDetectionConfig()
+	if err != nil {
+		return err
+	}
+
+	MulticlusterAPIGroup, err = multiclusterAPIGroupConfig()
+	if err != nil {
+		return err
+	}
+
 	ClusterName, err = getClusterName(sess)
 	if err != nil {
 		return fmt.Errorf("cannot get cluster name: %s", err)
@@ -98,6 +147,52 @@ func configInit(sess *session.Session, metadata EC2Metadata) error {
 	return nil
 }
 
+func driftDetectionConfig() (time.Duration, string, error) {
+	var interval time.Duration
+	if value := os.Getenv(DRIFT_DETECTION_INTERVAL); value != "" {
+		var err error
+		interval, err = time.ParseDuration(value)
+		if err != nil || interval < 0 {
+			return 0, "", fmt.Errorf("invalid %s %s, expected a duration such as 10m", DRIFT_DETECTION_INTERVAL, value)
+		}
+	}
+
+	mode := strings.ToLower(os.Getenv(DRIFT_DETECTION_MODE))
+	switch mode {
+	case "":
+		mode = DriftDetectionModeReport
+	case DriftDetectionModeReport, DriftDetectionModeRepair:
+	default:
+		return 0, "", fmt.Errorf("invalid %s %s, expected %s or %s", DRIFT_DETECTION_MODE, mode,
+			DriftDetectionModeReport, DriftDetectionModeRepair)
+	}
+	return interval, mode, nil
+}
+
+func multiclusterAPIGroupConfig() (string, error) {
+	group := strings.ToLower(os.Getenv(MULTICLUSTER_API_GROUP))
+	switch group {
+	case "":
+		return MulticlusterAPIGroupApplicationNetworking, nil
+	case MulticlusterAPIGroupApplicationNetworking, MulticlusterAPIGroupUpstream:
+		return group, nil
+	default:
+		return "", fmt.Errorf("invalid %s %s, expected %s or %s", MULTICLUSTER_API_GROUP, group,
+			MulticlusterAPIGroupApplicationNetworking, MulticlusterAPIGroupUpstream)
+	}
+}
+
+// comma separated values, empty values are skipped
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
 // try to find cluster name, search in env then in ec2 instance tags
 func getClusterName(sess *session.Session) (string, error) {
 	cn := os.Getenv(CLUSTER_NAME)
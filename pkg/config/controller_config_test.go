@@ -4,6 +4,7 @@ import (
 	"errors"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -71,3 +72,67 @@ func Test_config_init_with_all_env_var(t *testing.T) {
 	assert.Equal(t, DefaultServiceNetwork, testClusterLocalGateway)
 	assert.Equal(t, testClusterName, ClusterName)
 }
+
+func Test_drift_detection_config(t *testing.T) {
+	tests := []struct {
+		name         string
+		interval     string
+		mode         string
+		wantInterval time.Duration
+		wantMode     string
+		wantErr      bool
+	}{
+		{name: "disabled by default", wantMode: DriftDetectionModeReport},
+		{name: "report", interval: "10m", mode: "report", wantInterval: 10 * time.Minute, wantMode: DriftDetectionModeReport},
+		{name: "repair", interval: "1h", mode: "Repair", wantInterval: time.Hour, wantMode: DriftDetectionModeRepair},
+		{name: "invalid interval", interval: "10", wantErr: true},
+		{name: "negative interval", interval: "-1m", wantErr: true},
+		{name: "invalid mode", interval: "10m", mode: "fix", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(DRIFT_DETECTION_INTERVAL, tt.interval)
+			t.Setenv(DRIFT_DETECTION_MODE, tt.mode)
+			interval, mode, err := driftDetectionConfig()
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantInterval, interval)
+			assert.Equal(t, tt.wantMode, mode)
+		})
+	}
+}
+
+func Test_multicluster_api_group_config(t *testing.T) {
+	tests := []struct {
+		name      string
+		group     string
+		wantGroup string
+		wantErr   bool
+	}{
+		{name: "controller group by default", wantGroup: MulticlusterAPIGroupApplicationNetworking},
+		{name: "controller group", group: "application-networking.k8s.aws", wantGroup: MulticlusterAPIGroupApplicationNetworking},
+		{name: "upstream group", group: "multicluster.x-k8s.io", wantGroup: MulticlusterAPIGroupUpstream},
+		{name: "invalid group", group: "example.com", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(MULTICLUSTER_API_GROUP, tt.group)
+			group, err := multiclusterAPIGroupConfig()
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantGroup, group)
+		})
+	}
+}
+
+func Test_splitList(t *testing.T) {
+	assert.Nil(t, splitList(""))
+	assert.Equal(t, []string{"team-a"}, splitList("team-a"))
+	assert.Equal(t, []string{"team-a", "team-b"}, splitList(" team-a, ,team-b,"))
+}
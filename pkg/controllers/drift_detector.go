@@ -0,0 +1,287 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	anv1alpha1 "github.com/aws/aws-application-networking-k8s/pkg/apis/applicationnetworking/v1alpha1"
+	"github.com/aws/aws-application-networking-k8s/pkg/aws"
+	"github.com/aws/aws-application-networking-k8s/pkg/config"
+	"github.com/aws/aws-application-networking-k8s/pkg/deploy"
+	"github.com/aws/aws-application-networking-k8s/pkg/deploy/lattice"
+	"github.com/aws/aws-application-networking-k8s/pkg/gateway"
+	"github.com/aws/aws-application-networking-k8s/pkg/k8s"
+	"github.com/aws/aws-application-networking-k8s/pkg/model/core"
+	"github.com/aws/aws-application-networking-k8s/pkg/utils/gwlog"
+)
+
+const (
+	metricSubsystemDrift = "lattice_drift"
+
+	metricDriftedResources = "resources"
+	metricDriftRepairs     = "repairs_total"
+	metricDriftErrors      = "detection_errors_total"
+
+	labelKind         = "kind"
+	labelNamespace    = "namespace"
+	labelName         = "name"
+	labelResourceType = "resource_type"
+
+	serviceExportKind = "ServiceExport"
+)
+
+var driftRouteTypes = []core.RouteType{core.HttpRouteType, core.GrpcRouteType, core.TlsRouteType}
+
+var routeTypeToLister = map[core.RouteType]func(context.Context, client.Client) ([]core.Route, error){
+	core.HttpRouteType: core.ListHTTPRoutes,
+	core.GrpcRouteType: core.ListGRPCRoutes,
+	core.TlsRouteType:  core.ListTLSRoutes,
+}
+
+type driftMetrics struct {
+	driftedResources *prometheus.GaugeVec
+	repairsTotal     *prometheus.CounterVec
+	errorsTotal      *prometheus.CounterVec
+}
+
+func newDriftMetrics(registerer prometheus.Registerer) (*driftMetrics, error) {
+	driftedResources := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Subsystem: metricSubsystemDrift,
+		Name:      metricDriftedResources,
+		Help:      "Number of VPC Lattice resources of an object which differ from their desired state, as of the last drift detection",
+	}, []string{labelKind, labelNamespace, labelName, labelResourceType})
+	repairsTotal := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Subsystem: metricSubsystemDrift,
+		Name:      metricDriftRepairs,
+		Help:      "Total number of objects reconciled to repair their drifted VPC Lattice resources",
+	}, []string{labelKind})
+	errorsTotal := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Subsystem: metricSubsystemDrift,
+		Name:      metricDriftErrors,
+		Help:      "Total number of objects whose drift could not be detected",
+	}, []string{labelKind})
+
+	for _, c := range []prometheus.Collector{driftedResources, repairsTotal, errorsTotal} {
+		if err := registerer.Register(c); err != nil {
+			return nil, err
+		}
+	}
+	return &driftMetrics{
+		driftedResources: driftedResources,
+		repairsTotal:     repairsTotal,
+		errorsTotal:      errorsTotal,
+	}, nil
+}
+
+// DriftDetector periodically compares the VPC Lattice resources of the managed routes and
+// ServiceExports with the stacks their model builders produce, to catch changes made outside
+// the controller. Drift is reported through events and metrics. In repair mode the drifted
+// objects are also sent to their controller, which deploys them again.
+type DriftDetector struct {
+	log                   gwlog.Logger
+	client                client.Client
+	eventRecorder         record.EventRecorder
+	routeModelBuilder     gateway.LatticeServiceBuilder
+	svcExportModelBuilder gateway.SvcExportTargetGroupModelBuilder
+	stackPlanner          deploy.StackPlanner
+	metrics               *driftMetrics
+	interval              time.Duration
+	mode                  string
+	routeRepairs          map[core.RouteType]chan event.GenericEvent
+	svcExportRepairs      chan event.GenericEvent
+}
+
+func NewDriftDetector(
+	log gwlog.Logger,
+	cloud aws.Cloud,
+	mgr ctrl.Manager,
+	registerer prometheus.Registerer,
+) (*DriftDetector, error) {
+	mgrClient := mgr.GetClient()
+	metrics, err := newDriftMetrics(registerer)
+	if err != nil {
+		return nil, err
+	}
+
+	d := &DriftDetector{
+		log:                   log,
+		client:                mgrClient,
+		eventRecorder:         mgr.GetEventRecorderFor("drift-detector"),
+		routeModelBuilder:     gateway.NewLatticeServiceBuilder(log, mgrClient, gateway.NewBackendRefTargetGroupBuilder(log, mgrClient)),
+		svcExportModelBuilder: gateway.NewSvcExportTargetGroupBuilder(log, mgrClient),
+		stackPlanner:          deploy.NewLatticeServiceStackPlanner(log, cloud),
+		metrics:               metrics,
+		interval:              config.DriftDetectionInterval,
+		mode:                  config.DriftDetectionMode,
+		routeRepairs:          make(map[core.RouteType]chan event.GenericEvent),
+		svcExportRepairs:      make(chan event.GenericEvent),
+	}
+	for _, routeType := range driftRouteTypes {
+		d.routeRepairs[routeType] = make(chan event.GenericEvent)
+	}
+	return d, nil
+}
+
+// RouteRepairSource is watched by the route controller of the type to receive the routes to repair
+func (d *DriftDetector) RouteRepairSource(routeType core.RouteType) source.Source {
+	return &source.Channel{Source: d.routeRepairs[routeType]}
+}
+
+// ServiceExportRepairSource is watched by the ServiceExport controller to receive the ServiceExports to repair
+func (d *DriftDetector) ServiceExportRepairSource() source.Source {
+	return &source.Channel{Source: d.svcExportRepairs}
+}
+
+// Start implements manager.Runnable, detection runs every interval until the context is done
+func (d *DriftDetector) Start(ctx context.Context) error {
+	d.log.Infof("Starting drift detection every %s in %s mode", d.interval, d.mode)
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			d.detect(ctx)
+		}
+	}
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable, only the leader deploys repairs
+func (d *DriftDetector) NeedLeaderElection() bool {
+	return true
+}
+
+type objectDrift struct {
+	kind    string
+	object  client.Object
+	changes lattice.ChangeSet
+	repairs chan<- event.GenericEvent
+}
+
+func (d *DriftDetector) detect(ctx context.Context) {
+	d.log.Debugf("Detecting drift of VPC Lattice resources")
+	var drifts []objectDrift
+
+	for _, routeType := range driftRouteTypes {
+		routes, err := routeTypeToLister[routeType](ctx, d.client)
+		if err != nil {
+			if !meta.IsNoMatchError(err) {
+				d.log.Errorf("Failed to list %s routes for drift detection due to %s", routeType, err)
+			}
+			continue
+		}
+		for _, route := range routes {
+			if !d.isRouteManaged(routeType, route) {
+				continue
+			}
+			kind := route.GroupKind().Kind
+			changes, err := d.detectRouteDrift(ctx, route)
+			if err != nil {
+				d.log.Infof("Failed to detect drift of %s %s-%s due to %s", kind, route.Name(), route.Namespace(), err)
+				d.metrics.errorsTotal.WithLabelValues(kind).Inc()
+				continue
+			}
+			if len(changes) > 0 {
+				drifts = append(drifts, objectDrift{kind: kind, object: route.K8sObject(), changes: changes,
+					repairs: d.routeRepairs[routeType]})
+			}
+		}
+	}
+
+	svcExports := &anv1alpha1.ServiceExportList{}
+	if err := d.client.List(ctx, svcExports); err != nil {
+		if !meta.IsNoMatchError(err) {
+			d.log.Errorf("Failed to list ServiceExports for drift detection due to %s", err)
+		}
+	} else {
+		for i := range svcExports.Items {
+			svcExport := &svcExports.Items[i]
+			if !d.isServiceExportManaged(svcExport) {
+				continue
+			}
+			changes, err := d.detectServiceExportDrift(ctx, svcExport)
+			if err != nil {
+				d.log.Infof("Failed to detect drift of ServiceExport %s-%s due to %s", svcExport.Name, svcExport.Namespace, err)
+				d.metrics.errorsTotal.WithLabelValues(serviceExportKind).Inc()
+				continue
+			}
+			if len(changes) > 0 {
+				drifts = append(drifts, objectDrift{kind: serviceExportKind, object: svcExport, changes: changes,
+					repairs: d.svcExportRepairs})
+			}
+		}
+	}
+
+	// objects whose drift was repaired since the last detection no longer have a series
+	d.metrics.driftedResources.Reset()
+	for _, drift := range drifts {
+		d.handleDrift(ctx, drift)
+	}
+	d.log.Debugf("Detected drift of %d objects", len(drifts))
+}
+
+// routes without the finalizer have no resources deployed, detached routes have their resources and
+// finalizer removed by the route controller
+func (d *DriftDetector) isRouteManaged(routeType core.RouteType, route core.Route) bool {
+	return route.DeletionTimestamp().IsZero() &&
+		controllerutil.ContainsFinalizer(route.K8sObject(), routeTypeToFinalizer[routeType]) &&
+		!core.IsRouteDetachedFromAllParents(route)
+}
+
+func (d *DriftDetector) isServiceExportManaged(svcExport *anv1alpha1.ServiceExport) bool {
+	return svcExport.DeletionTimestamp.IsZero() &&
+		svcExport.Annotations["application-networking.k8s.aws/federation"] == "amazon-vpc-lattice" &&
+		controllerutil.ContainsFinalizer(svcExport, serviceExportFinalizer)
+}
+
+func (d *DriftDetector) detectRouteDrift(ctx context.Context, route core.Route) (lattice.ChangeSet, error) {
+	stack, err := d.routeModelBuilder.Build(ctx, route)
+	var partialErr *gateway.PartiallyInvalidRouteError
+	if err != nil && !errors.As(err, &partialErr) {
+		return nil, fmt.Errorf("failed to build model due to %w", err)
+	}
+	return d.stackPlanner.Plan(ctx, stack)
+}
+
+func (d *DriftDetector) detectServiceExportDrift(ctx context.Context, svcExport *anv1alpha1.ServiceExport) (lattice.ChangeSet, error) {
+	stack, err := d.svcExportModelBuilder.Build(ctx, svcExport)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build model due to %w", err)
+	}
+	return d.stackPlanner.Plan(ctx, stack)
+}
+
+func (d *DriftDetector) handleDrift(ctx context.Context, drift objectDrift) {
+	obj := drift.object
+	d.log.Infof("Detected drift of %s %s-%s: %s", drift.kind, obj.GetName(), obj.GetNamespace(), drift.changes)
+	d.eventRecorder.Event(obj, corev1.EventTypeWarning, k8s.DriftEventReasonDriftDetected,
+		fmt.Sprintf("VPC Lattice resources differ from the desired state: %s", drift.changes.Summary()))
+
+	for _, change := range drift.changes {
+		d.metrics.driftedResources.WithLabelValues(drift.kind, obj.GetNamespace(), obj.GetName(), change.ResourceType).Inc()
+	}
+
+	if d.mode != config.DriftDetectionModeRepair {
+		return
+	}
+	d.eventRecorder.Event(obj, corev1.EventTypeNormal, k8s.DriftEventReasonRepairDrift,
+		"Reconciling VPC Lattice resources back to the desired state")
+	d.metrics.repairsTotal.WithLabelValues(drift.kind).Inc()
+	select {
+	case drift.repairs <- event.GenericEvent{Object: obj}:
+	case <-ctx.Done():
+	}
+}
@@ -0,0 +1,164 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	gwv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	gwv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	mock_client "github.com/aws/aws-application-networking-k8s/mocks/controller-runtime/client"
+	anv1alpha1 "github.com/aws/aws-application-networking-k8s/pkg/apis/applicationnetworking/v1alpha1"
+	"github.com/aws/aws-application-networking-k8s/pkg/config"
+	"github.com/aws/aws-application-networking-k8s/pkg/deploy/lattice"
+	"github.com/aws/aws-application-networking-k8s/pkg/gateway"
+	"github.com/aws/aws-application-networking-k8s/pkg/k8s"
+	"github.com/aws/aws-application-networking-k8s/pkg/model/core"
+	"github.com/aws/aws-application-networking-k8s/pkg/utils/gwlog"
+)
+
+// plans the changes configured for the stack id, stacks are named after the object they are built for
+type driftTestPlanner struct {
+	changes map[core.StackID]lattice.ChangeSet
+}
+
+func (p *driftTestPlanner) Plan(ctx context.Context, stack core.Stack) (lattice.ChangeSet, error) {
+	return p.changes[stack.StackID()], nil
+}
+
+func newDriftTestDetector(t *testing.T, c *gomock.Controller, mode string, eventRecorder *mock_client.MockEventRecorder) (*DriftDetector, *driftTestPlanner) {
+	ctx := context.TODO()
+	k8sScheme := runtime.NewScheme()
+	clientgoscheme.AddToScheme(k8sScheme)
+	gwv1beta1.AddToScheme(k8sScheme)
+	gwv1alpha2.AddToScheme(k8sScheme)
+	anv1alpha1.AddToScheme(k8sScheme)
+	k8sClient := testclient.NewClientBuilder().WithScheme(k8sScheme).Build()
+
+	managedRoute := &gwv1beta1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "managed-route",
+			Namespace:  "ns1",
+			Finalizers: []string{routeTypeToFinalizer[core.HttpRouteType]},
+		},
+		Spec: gwv1beta1.HTTPRouteSpec{
+			CommonRouteSpec: gwv1beta1.CommonRouteSpec{
+				ParentRefs: []gwv1beta1.ParentReference{{Name: "my-gateway"}},
+			},
+		},
+	}
+	// no finalizer, nothing was deployed for this route
+	unmanagedRoute := managedRoute.DeepCopy()
+	unmanagedRoute.Name = "unmanaged-route"
+	unmanagedRoute.Finalizers = nil
+	svcExport := &anv1alpha1.ServiceExport{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "export",
+			Namespace:   "ns1",
+			Finalizers:  []string{serviceExportFinalizer},
+			Annotations: map[string]string{"application-networking.k8s.aws/federation": "amazon-vpc-lattice"},
+		},
+	}
+	for _, obj := range []client.Object{managedRoute, unmanagedRoute, svcExport} {
+		assert.NoError(t, k8sClient.Create(ctx, obj))
+	}
+
+	routeModelBuilder := gateway.NewMockLatticeServiceBuilder(c)
+	routeModelBuilder.EXPECT().Build(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, route core.Route) (core.Stack, error) {
+			assert.Equal(t, "managed-route", route.Name())
+			return core.NewDefaultStack(core.StackID{Name: route.Name(), Namespace: route.Namespace()}), nil
+		})
+	svcExportModelBuilder := gateway.NewMockSvcExportTargetGroupModelBuilder(c)
+	svcExportModelBuilder.EXPECT().Build(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, svcExport *anv1alpha1.ServiceExport) (core.Stack, error) {
+			return core.NewDefaultStack(core.StackID{Name: svcExport.Name, Namespace: svcExport.Namespace}), nil
+		})
+
+	metrics, err := newDriftMetrics(prometheus.NewRegistry())
+	assert.NoError(t, err)
+	planner := &driftTestPlanner{changes: map[core.StackID]lattice.ChangeSet{}}
+	d := &DriftDetector{
+		log:                   gwlog.FallbackLogger,
+		client:                k8sClient,
+		eventRecorder:         eventRecorder,
+		routeModelBuilder:     routeModelBuilder,
+		svcExportModelBuilder: svcExportModelBuilder,
+		stackPlanner:          planner,
+		metrics:               metrics,
+		mode:                  mode,
+		routeRepairs:          make(map[core.RouteType]chan event.GenericEvent),
+		svcExportRepairs:      make(chan event.GenericEvent, 10),
+	}
+	for _, routeType := range driftRouteTypes {
+		d.routeRepairs[routeType] = make(chan event.GenericEvent, 10)
+	}
+	return d, planner
+}
+
+func TestDriftDetector_Report(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	eventRecorder := mock_client.NewMockEventRecorder(c)
+	eventRecorder.EXPECT().Event(gomock.Any(), corev1.EventTypeWarning, k8s.DriftEventReasonDriftDetected,
+		"VPC Lattice resources differ from the desired state: 0 to create, 1 to update, 1 to delete")
+	d, planner := newDriftTestDetector(t, c, config.DriftDetectionModeReport, eventRecorder)
+	planner.changes[core.StackID{Name: "managed-route", Namespace: "ns1"}] = lattice.ChangeSet{
+		{Action: lattice.ChangeActionUpdate, ResourceType: lattice.ChangeResourceListener, Name: "listener", Detail: "default action"},
+		{Action: lattice.ChangeActionDelete, ResourceType: lattice.ChangeResourceRule, Name: "rule"},
+	}
+
+	d.detect(context.TODO())
+
+	assert.Equal(t, 1.0, testutil.ToFloat64(d.metrics.driftedResources.WithLabelValues(
+		"HTTPRoute", "ns1", "managed-route", lattice.ChangeResourceListener)))
+	assert.Equal(t, 1.0, testutil.ToFloat64(d.metrics.driftedResources.WithLabelValues(
+		"HTTPRoute", "ns1", "managed-route", lattice.ChangeResourceRule)))
+	assert.Equal(t, 2, testutil.CollectAndCount(d.metrics.driftedResources))
+	assert.Equal(t, 0, testutil.CollectAndCount(d.metrics.repairsTotal))
+	assert.Len(t, d.routeRepairs[core.HttpRouteType], 0)
+}
+
+func TestDriftDetector_Repair(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	eventRecorder := mock_client.NewMockEventRecorder(c)
+	eventRecorder.EXPECT().Event(gomock.Any(), corev1.EventTypeWarning, k8s.DriftEventReasonDriftDetected, gomock.Any())
+	eventRecorder.EXPECT().Event(gomock.Any(), corev1.EventTypeNormal, k8s.DriftEventReasonRepairDrift, gomock.Any())
+	d, planner := newDriftTestDetector(t, c, config.DriftDetectionModeRepair, eventRecorder)
+	planner.changes[core.StackID{Name: "export", Namespace: "ns1"}] = lattice.ChangeSet{
+		{Action: lattice.ChangeActionUpdate, ResourceType: lattice.ChangeResourceTargetGroup, Name: "tg", Detail: "health check"},
+	}
+
+	d.detect(context.TODO())
+
+	assert.Equal(t, 1.0, testutil.ToFloat64(d.metrics.driftedResources.WithLabelValues(
+		serviceExportKind, "ns1", "export", lattice.ChangeResourceTargetGroup)))
+	assert.Equal(t, 1.0, testutil.ToFloat64(d.metrics.repairsTotal.WithLabelValues(serviceExportKind)))
+	assert.Len(t, d.routeRepairs[core.HttpRouteType], 0)
+	assert.Len(t, d.svcExportRepairs, 1)
+	repair := <-d.svcExportRepairs
+	assert.Equal(t, "export", repair.Object.GetName())
+
+	// drift is gone on the next detection
+	planner.changes = map[core.StackID]lattice.ChangeSet{}
+	d.routeModelBuilder.(*gateway.MockLatticeServiceBuilder).EXPECT().Build(gomock.Any(), gomock.Any()).Return(
+		core.NewDefaultStack(core.StackID{Name: "managed-route", Namespace: "ns1"}), nil)
+	d.svcExportModelBuilder.(*gateway.MockSvcExportTargetGroupModelBuilder).EXPECT().Build(gomock.Any(), gomock.Any()).Return(
+		core.NewDefaultStack(core.StackID{Name: "export", Namespace: "ns1"}), nil)
+	d.detect(context.TODO())
+	assert.Equal(t, 0, testutil.CollectAndCount(d.metrics.driftedResources))
+}
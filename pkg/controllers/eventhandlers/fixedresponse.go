@@ -0,0 +1,52 @@
+package eventhandlers
+
+import (
+	"context"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	anv1alpha1 "github.com/aws/aws-application-networking-k8s/pkg/apis/applicationnetworking/v1alpha1"
+	"github.com/aws/aws-application-networking-k8s/pkg/k8s"
+	"github.com/aws/aws-application-networking-k8s/pkg/model/core"
+	"github.com/aws/aws-application-networking-k8s/pkg/utils/gwlog"
+)
+
+type fixedResponseEventHandler struct {
+	log    gwlog.Logger
+	client client.Client
+	mapper *resourceMapper
+}
+
+func NewFixedResponseEventHandler(log gwlog.Logger, client client.Client) *fixedResponseEventHandler {
+	return &fixedResponseEventHandler{
+		log:    log,
+		client: client,
+		mapper: &resourceMapper{log: log, client: client},
+	}
+}
+
+// Enqueues the routes with an ExtensionRef filter referencing the FixedResponse
+func (h *fixedResponseEventHandler) MapToRoute(routeType core.RouteType) handler.EventHandler {
+	return handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []reconcile.Request {
+		return h.mapToRoute(ctx, obj, routeType)
+	})
+}
+
+func (h *fixedResponseEventHandler) mapToRoute(ctx context.Context, obj client.Object, routeType core.RouteType) []reconcile.Request {
+	fixedResponse, ok := obj.(*anv1alpha1.FixedResponse)
+	if !ok {
+		return nil
+	}
+	routes := h.mapper.FixedResponseToRoutes(ctx, fixedResponse, routeType)
+
+	var requests []reconcile.Request
+	for _, route := range routes {
+		routeName := k8s.NamespacedName(route.K8sObject())
+		requests = append(requests, reconcile.Request{NamespacedName: routeName})
+		h.log.Infow("FixedResponse change triggered Route update",
+			"fixedResponse", obj.GetNamespace()+"/"+obj.GetName(), "routeName", routeName, "routeType", routeType)
+	}
+	return requests
+}
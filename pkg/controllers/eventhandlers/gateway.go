@@ -19,6 +19,7 @@ import (
 	gateway_api "sigs.k8s.io/gateway-api/apis/v1beta1"
 
 	"github.com/aws/aws-application-networking-k8s/pkg/config"
+	"github.com/aws/aws-application-networking-k8s/pkg/gateway"
 )
 
 type enqueueRequestsForGatewayEvent struct {
@@ -51,7 +52,9 @@ func (h *enqueueRequestsForGatewayEvent) Update(ctx context.Context, e event.Upd
 
 	h.log.Infof("Received Update event for Gateway %s-%s", gwNew.GetName(), gwNew.GetNamespace())
 
-	if !equality.Semantic.DeepEqual(gwOld.Spec, gwNew.Spec) {
+	// routes of HTTPS listeners use the certificates the gateway controller records on the gateway
+	certArnsChanged := gwOld.Annotations[gateway.CertificateArnsAnnotation] != gwNew.Annotations[gateway.CertificateArnsAnnotation]
+	if !equality.Semantic.DeepEqual(gwOld.Spec, gwNew.Spec) || certArnsChanged {
 		// initialize transition time
 		gwNew.Status.Conditions[0].LastTransitionTime = ZeroTransitionTime
 		h.enqueueImpactedRoutes(ctx, queue)
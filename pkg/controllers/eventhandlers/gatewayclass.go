@@ -3,6 +3,7 @@ package eventhandlers
 import (
 	"context"
 
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/util/workqueue"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -32,7 +33,15 @@ func (h *enqueueRequestsForGatewayClassEvent) Create(ctx context.Context, e even
 	h.enqueueImpactedGateway(ctx, queue, gwClassNew)
 }
 
+// the gateways of a class are reconciled again when its parametersRef or its acceptance changes
 func (h *enqueueRequestsForGatewayClassEvent) Update(ctx context.Context, e event.UpdateEvent, queue workqueue.RateLimitingInterface) {
+	gwClassOld := e.ObjectOld.(*gateway_api.GatewayClass)
+	gwClassNew := e.ObjectNew.(*gateway_api.GatewayClass)
+	if equality.Semantic.DeepEqual(gwClassOld.Spec, gwClassNew.Spec) &&
+		equality.Semantic.DeepEqual(gwClassOld.Status, gwClassNew.Status) {
+		return
+	}
+	h.enqueueImpactedGateway(ctx, queue, gwClassNew)
 }
 
 func (h *enqueueRequestsForGatewayClassEvent) Delete(ctx context.Context, e event.DeleteEvent, queue workqueue.RateLimitingInterface) {
@@ -0,0 +1,44 @@
+package eventhandlers
+
+import (
+	"context"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	anv1alpha1 "github.com/aws/aws-application-networking-k8s/pkg/apis/applicationnetworking/v1alpha1"
+	"github.com/aws/aws-application-networking-k8s/pkg/k8s"
+	"github.com/aws/aws-application-networking-k8s/pkg/utils/gwlog"
+)
+
+type latticeGatewayClassConfigEventHandler struct {
+	log    gwlog.Logger
+	client client.Client
+	mapper *resourceMapper
+}
+
+func NewLatticeGatewayClassConfigEventHandler(log gwlog.Logger, client client.Client) *latticeGatewayClassConfigEventHandler {
+	return &latticeGatewayClassConfigEventHandler{
+		log:    log,
+		client: client,
+		mapper: &resourceMapper{log: log, client: client},
+	}
+}
+
+// Enqueues the GatewayClasses whose parametersRef references the LatticeGatewayClassConfig
+func (h *latticeGatewayClassConfigEventHandler) MapToGatewayClass() handler.EventHandler {
+	return handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []reconcile.Request {
+		classConfig, ok := obj.(*anv1alpha1.LatticeGatewayClassConfig)
+		if !ok {
+			return nil
+		}
+		var requests []reconcile.Request
+		for _, gwClass := range h.mapper.LatticeGatewayClassConfigToGatewayClasses(ctx, classConfig) {
+			requests = append(requests, reconcile.Request{NamespacedName: k8s.NamespacedName(gwClass)})
+			h.log.Infow("LatticeGatewayClassConfig change triggered GatewayClass update",
+				"classConfig", classConfig.Name, "gatewayClass", gwClass.Name)
+		}
+		return requests
+	})
+}
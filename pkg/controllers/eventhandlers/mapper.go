@@ -12,6 +12,7 @@ import (
 	gateway_api "sigs.k8s.io/gateway-api/apis/v1beta1"
 
 	anv1alpha1 "github.com/aws/aws-application-networking-k8s/pkg/apis/applicationnetworking/v1alpha1"
+	"github.com/aws/aws-application-networking-k8s/pkg/gateway"
 	k8sutils "github.com/aws/aws-application-networking-k8s/pkg/k8s"
 	"github.com/aws/aws-application-networking-k8s/pkg/k8s/policyhelper"
 	"github.com/aws/aws-application-networking-k8s/pkg/model/core"
@@ -24,6 +25,12 @@ type resourceMapper struct {
 	client client.Client
 }
 
+var routeTypeToKind = map[core.RouteType]string{
+	core.HttpRouteType: "HTTPRoute",
+	core.GrpcRouteType: "GRPCRoute",
+	core.TlsRouteType:  "TLSRoute",
+}
+
 const (
 	serviceKind       = "Service"
 	serviceImportKind = "ServiceImport"
@@ -194,10 +201,13 @@ func (r *resourceMapper) isBackendRefUsedByRoute(route core.Route, obj client.Ob
 	for _, rule := range route.Spec().Rules() {
 		for _, backendRef := range rule.BackendRefs() {
 			var isGroupEqual bool
-			if group == corev1.GroupName || (group == anv1alpha1.GroupName && kind == serviceImportKind) {
+			if group == corev1.GroupName {
 				// from spec: "When [Group] unspecified or empty string, core API group is inferred."
-				// we deviate from spec slightly that for ServiceImport we have not historically required a Group
 				isGroupEqual = backendRef.Group() == nil || string(*backendRef.Group()) == group
+			} else if kind == serviceImportKind {
+				// we deviate from spec slightly that for ServiceImport we have not historically required a Group,
+				// and accept both groups ServiceImports can be served by
+				isGroupEqual = backendRef.Group() == nil || anv1alpha1.IsMulticlusterGroup(string(*backendRef.Group()))
 			} else {
 				// otherwise, make sure the group matches
 				isGroupEqual = backendRef.Group() != nil && string(*backendRef.Group()) == group
@@ -218,3 +228,135 @@ func (r *resourceMapper) isBackendRefUsedByRoute(route core.Route, obj client.Ob
 	}
 	return false
 }
+
+func (r *resourceMapper) ReferenceGrantToRoutes(ctx context.Context, grant *gateway_api.ReferenceGrant, routeType core.RouteType) []core.Route {
+	if grant == nil {
+		return nil
+	}
+	var routes []core.Route
+	for _, from := range grant.Spec.From {
+		if string(from.Group) != gateway_api.GroupName || string(from.Kind) != routeTypeToKind[routeType] {
+			continue
+		}
+		for _, route := range r.routesInNamespace(ctx, routeType, string(from.Namespace)) {
+			if r.hasBackendRefInNamespace(route, grant.Namespace) {
+				routes = append(routes, route)
+			}
+		}
+	}
+	return routes
+}
+
+// FixedResponses are only referenced by ExtensionRef filters of HTTPRoutes in the same namespace
+func (r *resourceMapper) FixedResponseToRoutes(ctx context.Context, fixedResponse *anv1alpha1.FixedResponse, routeType core.RouteType) []core.Route {
+	if fixedResponse == nil || routeType != core.HttpRouteType {
+		return nil
+	}
+	var routes []core.Route
+	for _, route := range r.routesInNamespace(ctx, routeType, fixedResponse.Namespace) {
+		if isFixedResponseUsedByRoute(route, fixedResponse) {
+			routes = append(routes, route)
+		}
+	}
+	return routes
+}
+
+func isFixedResponseUsedByRoute(route core.Route, fixedResponse *anv1alpha1.FixedResponse) bool {
+	for _, rule := range route.Spec().Rules() {
+		httpRule, ok := rule.(*core.HTTPRouteRule)
+		if !ok {
+			continue
+		}
+		for _, filter := range httpRule.Filters() {
+			ref := filter.ExtensionRef
+			if ref != nil && string(ref.Group) == anv1alpha1.GroupName &&
+				string(ref.Kind) == anv1alpha1.FixedResponseKind && string(ref.Name) == fixedResponse.Name {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Secrets are referenced by the certificateRefs of HTTPS gateway listeners
+func (r *resourceMapper) SecretToGateways(ctx context.Context, secret client.Object) []*gateway_api.Gateway {
+	if secret == nil {
+		return nil
+	}
+	gwList := &gateway_api.GatewayList{}
+	if err := r.client.List(ctx, gwList); err != nil {
+		r.log.Errorf("Failed to list gateways, %s", err)
+		return nil
+	}
+	secretName := k8sutils.NamespacedName(secret)
+	var gws []*gateway_api.Gateway
+	for i := range gwList.Items {
+		gw := &gwList.Items[i]
+		for _, listener := range gw.Spec.Listeners {
+			if ref, ok := gateway.ListenerCertificateSecret(gw, listener); ok && ref == secretName {
+				gws = append(gws, gw)
+				break
+			}
+		}
+	}
+	return gws
+}
+
+func (r *resourceMapper) routesInNamespace(ctx context.Context, routeType core.RouteType, namespace string) []core.Route {
+	var routes []core.Route
+	inNamespace := client.InNamespace(namespace)
+	switch routeType {
+	case core.HttpRouteType:
+		routeList := &gateway_api.HTTPRouteList{}
+		r.client.List(ctx, routeList, inNamespace)
+		for _, k8sRoute := range routeList.Items {
+			routes = append(routes, core.NewHTTPRoute(k8sRoute))
+		}
+	case core.GrpcRouteType:
+		routeList := &gateway_api_v1alpha2.GRPCRouteList{}
+		r.client.List(ctx, routeList, inNamespace)
+		for _, k8sRoute := range routeList.Items {
+			routes = append(routes, core.NewGRPCRoute(k8sRoute))
+		}
+	case core.TlsRouteType:
+		routeList := &gateway_api_v1alpha2.TLSRouteList{}
+		r.client.List(ctx, routeList, inNamespace)
+		for _, k8sRoute := range routeList.Items {
+			routes = append(routes, core.NewTLSRoute(k8sRoute))
+		}
+	}
+	return routes
+}
+
+func (r *resourceMapper) hasBackendRefInNamespace(route core.Route, namespace string) bool {
+	for _, rule := range route.Spec().Rules() {
+		for _, backendRef := range rule.BackendRefs() {
+			if backendRef.Namespace() != nil && string(*backendRef.Namespace()) == namespace {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// LatticeGatewayClassConfigs are referenced by the parametersRef of GatewayClasses
+func (r *resourceMapper) LatticeGatewayClassConfigToGatewayClasses(ctx context.Context,
+	classConfig *anv1alpha1.LatticeGatewayClassConfig) []*gateway_api.GatewayClass {
+	if classConfig == nil {
+		return nil
+	}
+	gwClassList := &gateway_api.GatewayClassList{}
+	if err := r.client.List(ctx, gwClassList); err != nil {
+		r.log.Errorf("Failed to list gateway classes, %s", err)
+		return nil
+	}
+	var gwClasses []*gateway_api.GatewayClass
+	for i := range gwClassList.Items {
+		ref := gwClassList.Items[i].Spec.ParametersRef
+		if ref != nil && string(ref.Group) == anv1alpha1.GroupName &&
+			string(ref.Kind) == anv1alpha1.LatticeGatewayClassConfigKind && ref.Name == classConfig.Name {
+			gwClasses = append(gwClasses, &gwClassList.Items[i])
+		}
+	}
+	return gwClasses
+}
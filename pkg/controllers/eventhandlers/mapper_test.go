@@ -11,6 +11,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/ptr"
+	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
 	gwv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
 	gwv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 
@@ -119,6 +120,57 @@ func TestServiceToRoutes(t *testing.T) {
 	}
 }
 
+func TestReferenceGrantToRoutes(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	routes := []gwv1beta1.HTTPRoute{
+		createHTTPRoute("backend-in-grant-namespace", "ns1", gwv1beta1.BackendObjectReference{
+			Kind:      (*gwv1beta1.Kind)(ptr.To("Service")),
+			Namespace: (*gwv1beta1.Namespace)(ptr.To("ns2")),
+			Name:      "test-service",
+		}),
+		createHTTPRoute("backend-in-route-namespace", "ns1", gwv1beta1.BackendObjectReference{
+			Kind: (*gwv1beta1.Kind)(ptr.To("Service")),
+			Name: "test-service",
+		}),
+		createHTTPRoute("backend-in-other-namespace", "ns1", gwv1beta1.BackendObjectReference{
+			Kind:      (*gwv1beta1.Kind)(ptr.To("Service")),
+			Namespace: (*gwv1beta1.Namespace)(ptr.To("ns3")),
+			Name:      "test-service",
+		}),
+	}
+
+	mockClient := mock_client.NewMockClient(c)
+	mockClient.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, routeList *gwv1beta1.HTTPRouteList, _ ...interface{}) error {
+			routeList.Items = append(routeList.Items, routes...)
+			return nil
+		},
+	)
+
+	mapper := &resourceMapper{log: gwlog.FallbackLogger, client: mockClient}
+	grant := &gwv1beta1.ReferenceGrant{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-grant",
+			Namespace: "ns2",
+		},
+		Spec: gwv1beta1.ReferenceGrantSpec{
+			From: []gwv1beta1.ReferenceGrantFrom{
+				{Group: gwv1beta1.GroupName, Kind: "HTTPRoute", Namespace: "ns1"},
+				{Group: gwv1beta1.GroupName, Kind: "GRPCRoute", Namespace: "ns1"},
+			},
+			To: []gwv1beta1.ReferenceGrantTo{
+				{Group: "", Kind: "Service"},
+			},
+		},
+	}
+	res := mapper.ReferenceGrantToRoutes(context.Background(), grant, core.HttpRouteType)
+
+	assert.Len(t, res, 1)
+	assert.Equal(t, "backend-in-grant-namespace", res[0].Name())
+}
+
 func TestTargetGroupPolicyToService(t *testing.T) {
 	c := gomock.NewController(t)
 	defer c.Finish()
@@ -301,3 +353,96 @@ func TestVpcAssociationPolicyToGateway(t *testing.T) {
 		})
 	}
 }
+
+func TestFixedResponseToRoutes(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	withFilter := func(route gwv1beta1.HTTPRoute, group, kind, name string) gwv1beta1.HTTPRoute {
+		route.Spec.Rules[0].Filters = []gwv1beta1.HTTPRouteFilter{{
+			Type: gwv1.HTTPRouteFilterExtensionRef,
+			ExtensionRef: &gwv1.LocalObjectReference{
+				Group: gwv1beta1.Group(group),
+				Kind:  gwv1beta1.Kind(kind),
+				Name:  gwv1beta1.ObjectName(name),
+			},
+		}}
+		return route
+	}
+	backendRef := gwv1beta1.BackendObjectReference{Name: "test-service"}
+	routes := []gwv1beta1.HTTPRoute{
+		withFilter(createHTTPRoute("references-fixed-response", "ns1", backendRef),
+			anv1alpha1.GroupName, anv1alpha1.FixedResponseKind, "maintenance"),
+		withFilter(createHTTPRoute("references-other-fixed-response", "ns1", backendRef),
+			anv1alpha1.GroupName, anv1alpha1.FixedResponseKind, "other"),
+		withFilter(createHTTPRoute("references-other-kind", "ns1", backendRef),
+			"example.com", "Custom", "maintenance"),
+		createHTTPRoute("no-filter", "ns1", backendRef),
+	}
+
+	mockClient := mock_client.NewMockClient(c)
+	mockClient.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, routeList *gwv1beta1.HTTPRouteList, _ ...interface{}) error {
+			routeList.Items = append(routeList.Items, routes...)
+			return nil
+		},
+	)
+
+	mapper := &resourceMapper{log: gwlog.FallbackLogger, client: mockClient}
+	fixedResponse := &anv1alpha1.FixedResponse{
+		ObjectMeta: metav1.ObjectMeta{Name: "maintenance", Namespace: "ns1"},
+	}
+	res := mapper.FixedResponseToRoutes(context.Background(), fixedResponse, core.HttpRouteType)
+
+	assert.Len(t, res, 1)
+	assert.Equal(t, "references-fixed-response", res[0].Name())
+	assert.Empty(t, mapper.FixedResponseToRoutes(context.Background(), fixedResponse, core.GrpcRouteType))
+}
+
+func TestSecretToGateways(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	terminate := gwv1.TLSModeTerminate
+	httpsGateway := func(name string, certificateRefs ...gwv1beta1.SecretObjectReference) gwv1beta1.Gateway {
+		return gwv1beta1.Gateway{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "ns1"},
+			Spec: gwv1beta1.GatewaySpec{
+				Listeners: []gwv1beta1.Listener{
+					{Name: "http", Protocol: gwv1.HTTPProtocolType},
+					{
+						Name:     "https",
+						Protocol: gwv1.HTTPSProtocolType,
+						TLS:      &gwv1beta1.GatewayTLSConfig{Mode: &terminate, CertificateRefs: certificateRefs},
+					},
+				},
+			},
+		}
+	}
+	otherNamespace := gwv1beta1.Namespace("ns2")
+	gws := []gwv1beta1.Gateway{
+		httpsGateway("references-secret", gwv1beta1.SecretObjectReference{Name: "cert"}),
+		httpsGateway("references-other-secret", gwv1beta1.SecretObjectReference{Name: "other"}),
+		httpsGateway("references-secret-in-other-namespace",
+			gwv1beta1.SecretObjectReference{Name: "cert", Namespace: &otherNamespace}),
+		httpsGateway("no-certificate-refs"),
+	}
+
+	mockClient := mock_client.NewMockClient(c)
+	mockClient.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, gwList *gwv1beta1.GatewayList, _ ...interface{}) error {
+			gwList.Items = append(gwList.Items, gws...)
+			return nil
+		},
+	)
+
+	mapper := &resourceMapper{log: gwlog.FallbackLogger, client: mockClient}
+	// Secrets are only watched by their metadata
+	secret := &metav1.PartialObjectMetadata{
+		ObjectMeta: metav1.ObjectMeta{Name: "cert", Namespace: "ns1"},
+	}
+	res := mapper.SecretToGateways(context.Background(), secret)
+
+	assert.Len(t, res, 1)
+	assert.Equal(t, "references-secret", res[0].Name)
+}
@@ -0,0 +1,48 @@
+package eventhandlers
+
+import (
+	"context"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/aws/aws-application-networking-k8s/pkg/k8s"
+	"github.com/aws/aws-application-networking-k8s/pkg/model/core"
+	"github.com/aws/aws-application-networking-k8s/pkg/utils/gwlog"
+)
+
+type namespaceEventHandler struct {
+	log    gwlog.Logger
+	client client.Client
+	mapper *resourceMapper
+}
+
+func NewNamespaceEventHandler(log gwlog.Logger, client client.Client) *namespaceEventHandler {
+	return &namespaceEventHandler{
+		log:    log,
+		client: client,
+		mapper: &resourceMapper{log: log, client: client},
+	}
+}
+
+// Enqueues the routes of a namespace, so listener allowedRoutes namespace selectors
+// are evaluated again when the namespace labels change
+func (h *namespaceEventHandler) MapToRoute(routeType core.RouteType) handler.EventHandler {
+	return handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []reconcile.Request {
+		return h.mapToRoute(ctx, obj, routeType)
+	})
+}
+
+func (h *namespaceEventHandler) mapToRoute(ctx context.Context, obj client.Object, routeType core.RouteType) []reconcile.Request {
+	routes := h.mapper.routesInNamespace(ctx, routeType, obj.GetName())
+
+	var requests []reconcile.Request
+	for _, route := range routes {
+		routeName := k8s.NamespacedName(route.K8sObject())
+		requests = append(requests, reconcile.Request{NamespacedName: routeName})
+		h.log.Debugw("Namespace change triggered Route update",
+			"namespace", obj.GetName(), "routeName", routeName, "routeType", routeType)
+	}
+	return requests
+}
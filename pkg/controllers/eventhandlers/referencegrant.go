@@ -0,0 +1,53 @@
+package eventhandlers
+
+import (
+	"context"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	gateway_api "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/aws/aws-application-networking-k8s/pkg/k8s"
+	"github.com/aws/aws-application-networking-k8s/pkg/model/core"
+	"github.com/aws/aws-application-networking-k8s/pkg/utils/gwlog"
+)
+
+type referenceGrantEventHandler struct {
+	log    gwlog.Logger
+	client client.Client
+	mapper *resourceMapper
+}
+
+func NewReferenceGrantEventHandler(log gwlog.Logger, client client.Client) *referenceGrantEventHandler {
+	return &referenceGrantEventHandler{
+		log:    log,
+		client: client,
+		mapper: &resourceMapper{log: log, client: client},
+	}
+}
+
+// Enqueues the routes a ReferenceGrant may allow or disallow, which are the routes of the grant
+// "from" namespaces with a backendRef in the grant namespace
+func (h *referenceGrantEventHandler) MapToRoute(routeType core.RouteType) handler.EventHandler {
+	return handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []reconcile.Request {
+		return h.mapToRoute(ctx, obj, routeType)
+	})
+}
+
+func (h *referenceGrantEventHandler) mapToRoute(ctx context.Context, obj client.Object, routeType core.RouteType) []reconcile.Request {
+	grant, ok := obj.(*gateway_api.ReferenceGrant)
+	if !ok {
+		return nil
+	}
+	routes := h.mapper.ReferenceGrantToRoutes(ctx, grant, routeType)
+
+	var requests []reconcile.Request
+	for _, route := range routes {
+		routeName := k8s.NamespacedName(route.K8sObject())
+		requests = append(requests, reconcile.Request{NamespacedName: routeName})
+		h.log.Infow("ReferenceGrant change triggered Route update",
+			"referenceGrant", obj.GetNamespace()+"/"+obj.GetName(), "routeName", routeName, "routeType", routeType)
+	}
+	return requests
+}
@@ -0,0 +1,40 @@
+package eventhandlers
+
+import (
+	"context"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/aws/aws-application-networking-k8s/pkg/k8s"
+	"github.com/aws/aws-application-networking-k8s/pkg/utils/gwlog"
+)
+
+type secretEventHandler struct {
+	log    gwlog.Logger
+	client client.Client
+	mapper *resourceMapper
+}
+
+func NewSecretEventHandler(log gwlog.Logger, client client.Client) *secretEventHandler {
+	return &secretEventHandler{
+		log:    log,
+		client: client,
+		mapper: &resourceMapper{log: log, client: client},
+	}
+}
+
+// Enqueues the gateways with a listener certificateRef referencing the Secret, which is only watched by its metadata
+func (h *secretEventHandler) MapToGateway() handler.EventHandler {
+	return handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []reconcile.Request {
+		var requests []reconcile.Request
+		for _, gw := range h.mapper.SecretToGateways(ctx, obj) {
+			gwName := k8s.NamespacedName(gw)
+			requests = append(requests, reconcile.Request{NamespacedName: gwName})
+			h.log.Infow("Secret change triggered Gateway update",
+				"secret", obj.GetNamespace()+"/"+obj.GetName(), "gatewayName", gwName)
+		}
+		return requests
+	})
+}
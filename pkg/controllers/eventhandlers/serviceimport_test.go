@@ -27,6 +27,18 @@ func TestServiceImportEventHandler_MapToRoute(t *testing.T) {
 			Namespace: (*gwv1beta1.Namespace)(ptr.To("ns1")),
 			Name:      "test-service",
 		}),
+		createHTTPRoute("valid-upstream-route", "ns1", gwv1beta1.BackendObjectReference{
+			Group:     (*gwv1beta1.Group)(ptr.To("multicluster.x-k8s.io")),
+			Kind:      (*gwv1beta1.Kind)(ptr.To("ServiceImport")),
+			Namespace: (*gwv1beta1.Namespace)(ptr.To("ns1")),
+			Name:      "test-service",
+		}),
+		createHTTPRoute("invalid-group-route", "ns1", gwv1beta1.BackendObjectReference{
+			Group:     (*gwv1beta1.Group)(ptr.To("example.com")),
+			Kind:      (*gwv1beta1.Kind)(ptr.To("ServiceImport")),
+			Namespace: (*gwv1beta1.Namespace)(ptr.To("ns1")),
+			Name:      "test-service",
+		}),
 	}
 	mockClient := mock_client.NewMockClient(c)
 	h := NewServiceImportEventHandler(gwlog.FallbackLogger, mockClient)
@@ -43,6 +55,7 @@ func TestServiceImportEventHandler_MapToRoute(t *testing.T) {
 			Namespace: "ns1",
 		},
 	}, core.HttpRouteType)
-	assert.Len(t, reqs, 1)
+	assert.Len(t, reqs, 2)
 	assert.Equal(t, "valid-route", reqs[0].Name)
+	assert.Equal(t, "valid-upstream-route", reqs[1].Name)
 }
@@ -0,0 +1,132 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"golang.org/x/exp/maps"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gwv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/aws/aws-application-networking-k8s/pkg/gateway"
+	"github.com/aws/aws-application-networking-k8s/pkg/k8s"
+	model "github.com/aws/aws-application-networking-k8s/pkg/model/lattice"
+)
+
+// reconcileCertificates imports the certificates of the Secrets referenced by the given gateway listeners
+// into ACM, re-importing the ones whose Secret changed, and deletes the certificates no listener references
+// anymore. The certificate arns are recorded on the gateway, where the route model builder finds them.
+func (r *gatewayReconciler) reconcileCertificates(ctx context.Context, gw *gwv1beta1.Gateway, listeners []gwv1beta1.Listener) error {
+	certArns := gateway.GatewayCertificateArns(gw)
+	newCertArns := make(map[string]string)
+	var errs []error
+
+	for _, listener := range listeners {
+		secretName, ok := gateway.ListenerCertificateSecret(gw, listener)
+		if !ok {
+			continue
+		}
+		key := secretName.String()
+		if _, ok := newCertArns[key]; ok {
+			continue
+		}
+
+		cert, invalidReason, err := r.getListenerCertificate(ctx, gw, secretName)
+		if err != nil {
+			return err
+		}
+		if invalidReason != "" {
+			// fixing the Secret triggers a reconcile, routes of the listener cannot be deployed until then
+			r.eventRecorder.Event(gw, corev1.EventTypeWarning, k8s.GatewayEventReasonInvalidCertificateRef,
+				fmt.Sprintf("Invalid certificateRef of listener %s: %s", listener.Name, invalidReason))
+			continue
+		}
+
+		certArn, err := r.certManager.Upsert(ctx, certArns[key], cert)
+		if err != nil {
+			errs = append(errs, err)
+			if certArn, ok := certArns[key]; ok {
+				newCertArns[key] = certArn
+			}
+			continue
+		}
+		newCertArns[key] = certArn
+	}
+
+	for key, certArn := range certArns {
+		if _, ok := newCertArns[key]; ok {
+			continue
+		}
+		if err := r.certManager.Delete(ctx, certArn); err != nil {
+			// lattice services of the routes still use it until they are updated, retry on the next reconcile
+			errs = append(errs, err)
+			newCertArns[key] = certArn
+		}
+	}
+
+	if err := r.updateCertificateArns(ctx, gw, newCertArns); err != nil {
+		return err
+	}
+	return errors.Join(errs...)
+}
+
+// returns the reason a certificateRef is invalid rather than an error, reconciling again would not fix it
+func (r *gatewayReconciler) getListenerCertificate(ctx context.Context, gw *gwv1beta1.Gateway, secretName types.NamespacedName) (
+	*model.Certificate, string, error,
+) {
+	permitted, err := k8s.IsReferencePermitted(ctx, r.client,
+		k8s.ObjectRef{Group: gwv1.GroupName, Kind: "Gateway", Namespace: gw.Namespace, Name: gw.Name},
+		k8s.ObjectRef{Group: corev1.GroupName, Kind: "Secret", Namespace: secretName.Namespace, Name: secretName.Name})
+	if err != nil {
+		return nil, "", err
+	}
+	if !permitted {
+		return nil, fmt.Sprintf("secret %s is not permitted by any ReferenceGrant", secretName), nil
+	}
+
+	secret := &corev1.Secret{}
+	if err := r.apiReader.Get(ctx, secretName, secret); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, fmt.Sprintf("secret %s not found", secretName), nil
+		}
+		return nil, "", err
+	}
+	if secret.Type != corev1.SecretTypeTLS {
+		return nil, fmt.Sprintf("secret %s is not of type %s", secretName, corev1.SecretTypeTLS), nil
+	}
+	cert, err := model.NewCertificateFromSecret(gw.Name, gw.Namespace, secret)
+	if err != nil {
+		return nil, fmt.Sprintf("secret %s is invalid: %s", secretName, err), nil
+	}
+	return cert, "", nil
+}
+
+func (r *gatewayReconciler) updateCertificateArns(ctx context.Context, gw *gwv1beta1.Gateway, certArns map[string]string) error {
+	if maps.Equal(gateway.GatewayCertificateArns(gw), certArns) {
+		return nil
+	}
+
+	gwOld := gw.DeepCopy()
+	if len(certArns) == 0 {
+		delete(gw.Annotations, gateway.CertificateArnsAnnotation)
+	} else {
+		value, err := json.Marshal(certArns)
+		if err != nil {
+			return err
+		}
+		if gw.Annotations == nil {
+			gw.Annotations = make(map[string]string)
+		}
+		gw.Annotations[gateway.CertificateArnsAnnotation] = string(value)
+	}
+	if err := r.client.Patch(ctx, gw, client.MergeFrom(gwOld)); err != nil {
+		return fmt.Errorf("failed to record certificate arns on gateway %s/%s: %w", gw.Namespace, gw.Name, err)
+	}
+	return nil
+}
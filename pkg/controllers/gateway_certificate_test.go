@@ -0,0 +1,179 @@
+package controllers
+
+import (
+	"context"
+	"encoding/pem"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gwv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	mock_client "github.com/aws/aws-application-networking-k8s/mocks/controller-runtime/client"
+	deploy "github.com/aws/aws-application-networking-k8s/pkg/deploy/lattice"
+	"github.com/aws/aws-application-networking-k8s/pkg/gateway"
+	"github.com/aws/aws-application-networking-k8s/pkg/k8s"
+	"github.com/aws/aws-application-networking-k8s/pkg/model/lattice"
+	"github.com/aws/aws-application-networking-k8s/pkg/utils/gwlog"
+)
+
+func TestGatewayReconciler_ReconcileCertificates(t *testing.T) {
+	terminate := gwv1.TLSModeTerminate
+	otherNamespace := gwv1beta1.Namespace("other")
+	httpsListener := func(ref gwv1beta1.SecretObjectReference) gwv1beta1.Listener {
+		return gwv1beta1.Listener{
+			Name:     "https",
+			Protocol: gwv1.HTTPSProtocolType,
+			TLS:      &gwv1beta1.GatewayTLSConfig{Mode: &terminate, CertificateRefs: []gwv1beta1.SecretObjectReference{ref}},
+		}
+	}
+	crt := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: []byte("leaf")})
+	chain := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: []byte("intermediate")})
+	secrets := []*corev1.Secret{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "cert", Namespace: "default"},
+			Type:       corev1.SecretTypeTLS,
+			Data:       map[string][]byte{corev1.TLSCertKey: append(crt, chain...), corev1.TLSPrivateKeyKey: []byte("key")},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "opaque", Namespace: "default"},
+			Type:       corev1.SecretTypeOpaque,
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "cert", Namespace: "other"},
+			Type:       corev1.SecretTypeTLS,
+			Data:       map[string][]byte{corev1.TLSCertKey: crt, corev1.TLSPrivateKeyKey: []byte("key")},
+		},
+	}
+
+	tests := []struct {
+		name              string
+		listeners         []gwv1beta1.Listener
+		certArns          string
+		deleted           bool
+		setup             func(certManager *deploy.MockCertificateManager, eventRecorder *mock_client.MockEventRecorder)
+		expectedCertArns  map[string]string
+		expectedErrString string
+	}{
+		{
+			name:      "certificate of the listener secret is imported",
+			listeners: []gwv1beta1.Listener{httpsListener(gwv1beta1.SecretObjectReference{Name: "cert"})},
+			setup: func(certManager *deploy.MockCertificateManager, _ *mock_client.MockEventRecorder) {
+				certManager.EXPECT().Upsert(gomock.Any(), "", gomock.Any()).DoAndReturn(
+					func(ctx context.Context, certArn string, cert *lattice.Certificate) (string, error) {
+						assert.Equal(t, "cert", cert.Spec.SecretName)
+						assert.Equal(t, "gw", cert.Spec.GatewayName)
+						assert.Equal(t, crt, cert.Certificate)
+						assert.Equal(t, chain, cert.CertificateChain)
+						assert.Equal(t, []byte("key"), cert.PrivateKey)
+						return "arn-1", nil
+					})
+			},
+			expectedCertArns: map[string]string{"default/cert": "arn-1"},
+		},
+		{
+			name:      "certificate no listener references is deleted",
+			listeners: []gwv1beta1.Listener{httpsListener(gwv1beta1.SecretObjectReference{Name: "cert"})},
+			certArns:  `{"default/cert":"arn-1","default/old":"arn-old"}`,
+			setup: func(certManager *deploy.MockCertificateManager, _ *mock_client.MockEventRecorder) {
+				certManager.EXPECT().Upsert(gomock.Any(), "arn-1", gomock.Any()).Return("arn-1", nil)
+				certManager.EXPECT().Delete(gomock.Any(), "arn-old").Return(nil)
+			},
+			expectedCertArns: map[string]string{"default/cert": "arn-1"},
+		},
+		{
+			name:     "certificate still in use is kept",
+			certArns: `{"default/old":"arn-old"}`,
+			setup: func(certManager *deploy.MockCertificateManager, _ *mock_client.MockEventRecorder) {
+				certManager.EXPECT().Delete(gomock.Any(), "arn-old").Return(errors.New("in use"))
+			},
+			expectedCertArns:  map[string]string{"default/old": "arn-old"},
+			expectedErrString: "in use",
+		},
+		{
+			name:      "secret of another type is not imported",
+			listeners: []gwv1beta1.Listener{httpsListener(gwv1beta1.SecretObjectReference{Name: "opaque"})},
+			setup: func(_ *deploy.MockCertificateManager, eventRecorder *mock_client.MockEventRecorder) {
+				eventRecorder.EXPECT().Event(gomock.Any(), corev1.EventTypeWarning, k8s.GatewayEventReasonInvalidCertificateRef,
+					"Invalid certificateRef of listener https: secret default/opaque is not of type kubernetes.io/tls")
+			},
+			expectedCertArns: map[string]string{},
+		},
+		{
+			name:      "secret in another namespace needs a ReferenceGrant",
+			listeners: []gwv1beta1.Listener{httpsListener(gwv1beta1.SecretObjectReference{Name: "cert", Namespace: &otherNamespace})},
+			setup: func(_ *deploy.MockCertificateManager, eventRecorder *mock_client.MockEventRecorder) {
+				eventRecorder.EXPECT().Event(gomock.Any(), corev1.EventTypeWarning, k8s.GatewayEventReasonInvalidCertificateRef,
+					"Invalid certificateRef of listener https: secret other/cert is not permitted by any ReferenceGrant")
+			},
+			expectedCertArns: map[string]string{},
+		},
+		{
+			name:      "certificates of a deleted gateway are deleted",
+			listeners: []gwv1beta1.Listener{httpsListener(gwv1beta1.SecretObjectReference{Name: "cert"})},
+			certArns:  `{"default/cert":"arn-1"}`,
+			deleted:   true,
+			setup: func(certManager *deploy.MockCertificateManager, _ *mock_client.MockEventRecorder) {
+				certManager.EXPECT().Delete(gomock.Any(), "arn-1").Return(nil)
+			},
+			expectedCertArns: map[string]string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+			ctx := context.TODO()
+
+			k8sScheme := runtime.NewScheme()
+			clientgoscheme.AddToScheme(k8sScheme)
+			gwv1beta1.AddToScheme(k8sScheme)
+			k8sClient := testclient.NewClientBuilder().WithScheme(k8sScheme).Build()
+			for _, secret := range secrets {
+				assert.NoError(t, k8sClient.Create(ctx, secret.DeepCopy()))
+			}
+			gw := &gwv1beta1.Gateway{
+				ObjectMeta: metav1.ObjectMeta{Name: "gw", Namespace: "default"},
+				Spec:       gwv1beta1.GatewaySpec{Listeners: tt.listeners},
+			}
+			if tt.certArns != "" {
+				gw.Annotations = map[string]string{gateway.CertificateArnsAnnotation: tt.certArns}
+			}
+			assert.NoError(t, k8sClient.Create(ctx, gw))
+
+			certManager := deploy.NewMockCertificateManager(c)
+			eventRecorder := mock_client.NewMockEventRecorder(c)
+			tt.setup(certManager, eventRecorder)
+			r := &gatewayReconciler{
+				log:           gwlog.FallbackLogger,
+				client:        k8sClient,
+				apiReader:     k8sClient,
+				eventRecorder: eventRecorder,
+				certManager:   certManager,
+			}
+
+			listeners := gw.Spec.Listeners
+			if tt.deleted {
+				listeners = nil
+			}
+			err := r.reconcileCertificates(ctx, gw, listeners)
+			if tt.expectedErrString != "" {
+				assert.ErrorContains(t, err, tt.expectedErrString)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			updated := &gwv1beta1.Gateway{}
+			assert.NoError(t, k8sClient.Get(ctx, k8s.NamespacedName(gw), updated))
+			assert.Equal(t, tt.expectedCertArns, gateway.GatewayCertificateArns(updated))
+		})
+	}
+}
@@ -22,6 +22,7 @@ import (
 	anv1alpha1 "github.com/aws/aws-application-networking-k8s/pkg/apis/applicationnetworking/v1alpha1"
 	"github.com/aws/aws-application-networking-k8s/pkg/aws/services"
 	"github.com/aws/aws-application-networking-k8s/pkg/controllers/eventhandlers"
+	"github.com/aws/aws-application-networking-k8s/pkg/gateway"
 
 	"github.com/aws/aws-application-networking-k8s/pkg/aws"
 	"github.com/aws/aws-application-networking-k8s/pkg/config"
@@ -60,6 +61,9 @@ type gatewayReconciler struct {
 	finalizerManager k8s.FinalizerManager
 	eventRecorder    record.EventRecorder
 	cloud            aws.Cloud
+	apiReader        client.Reader // reads Secrets, only their metadata is cached
+	certManager      deploy.CertificateManager
+	snManager        deploy.ServiceNetworkManager
 }
 
 func RegisterGatewayController(
@@ -79,12 +83,14 @@ func RegisterGatewayController(
 		finalizerManager: finalizerManager,
 		eventRecorder:    evtRec,
 		cloud:            cloud,
+		apiReader:        mgr.GetAPIReader(),
+		certManager:      deploy.NewCertificateManager(log, cloud),
+		snManager:        deploy.NewDefaultServiceNetworkManager(log, cloud),
 	}
 
-	if config.DefaultServiceNetwork != "" {
+	if config.DefaultServiceNetwork != "" && !config.DryRunMode {
 		// Attempt creation of default service network, move gracefully even if it fails.
-		snManager := deploy.NewDefaultServiceNetworkManager(log, cloud)
-		_, err := snManager.CreateOrUpdate(context.Background(), &model.ServiceNetwork{
+		_, err := r.snManager.CreateOrUpdate(context.Background(), &model.ServiceNetwork{
 			Spec: model.ServiceNetworkSpec{
 				Name: config.DefaultServiceNetwork,
 			},
@@ -97,9 +103,14 @@ func RegisterGatewayController(
 
 	gwClassEventHandler := eventhandlers.NewEnqueueRequestsForGatewayClassEvent(log, mgrClient)
 	vpcAssociationPolicyEventHandler := eventhandlers.NewVpcAssociationPolicyEventHandler(log, mgrClient)
+	secretEventHandler := eventhandlers.NewSecretEventHandler(log, mgrClient)
+	// the annotation opts the gateway in to a managed service network, the others are written by the controller
 	builder := ctrl.NewControllerManagedBy(mgr).
-		For(&gwv1beta1.Gateway{}, pkg_builder.WithPredicates(predicate.GenerationChangedPredicate{}))
+		For(&gwv1beta1.Gateway{}, pkg_builder.WithPredicates(
+			predicate.Or(predicate.GenerationChangedPredicate{}, k8s.AnnotationsChangedPredicate(ManageServiceNetworkAnnotation))))
 	builder.Watches(&gwv1beta1.GatewayClass{}, gwClassEventHandler)
+	// caching whole Secrets would keep every Secret of the cluster in memory
+	builder.Watches(&corev1.Secret{}, secretEventHandler.MapToGateway(), pkg_builder.OnlyMetadata)
 
 	//Watch VpcAssociationPolicy CRD if it is installed
 	ok, err := k8s.IsGVKSupported(mgr, anv1alpha1.GroupVersion.String(), anv1alpha1.VpcAssociationPolicyKind)
@@ -117,6 +128,7 @@ func RegisterGatewayController(
 //+kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=gateways,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=gateways/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=gateways/finalizers,verbs=update
+//+kubebuilder:rbac:groups=core,resources=secrets,verbs=get;list;watch
 
 func (r *gatewayReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	r.log.Infow("reconcile", "name", req.Name)
@@ -158,40 +170,53 @@ func (r *gatewayReconciler) reconcile(ctx context.Context, req ctrl.Request) err
 		return nil
 	}
 
+	// the service network a gateway maps to depends on the config of its class, the gateway is reconciled
+	// again once the GatewayClass accepts a fixed config
+	classConfig, err := gateway.GetGatewayClassConfig(ctx, r.client, gwClass)
+	if err != nil {
+		var invalidErr *gateway.InvalidGatewayClassConfigError
+		if errors.As(err, &invalidErr) {
+			r.log.Infow("GatewayClass has an invalid parametersRef", "name", req.Name, "gwclass", gwClass.Name,
+				"reason", invalidErr.Message)
+			return nil
+		}
+		return err
+	}
+	snName, err := gateway.ServiceNetworkNameOfGateway(gw, classConfig)
+	if err != nil {
+		return err
+	}
+
 	if !gw.DeletionTimestamp.IsZero() {
-		return r.reconcileDelete(ctx, gw)
+		return r.reconcileDelete(ctx, gw, snName, classConfig)
 	} else {
-		return r.reconcileUpsert(ctx, gw)
+		return r.reconcileUpsert(ctx, gw, snName, classConfig)
 	}
 }
 
-func (r *gatewayReconciler) reconcileDelete(ctx context.Context, gw *gwv1beta1.Gateway) error {
+func (r *gatewayReconciler) reconcileDelete(ctx context.Context, gw *gwv1beta1.Gateway, snName string,
+	classConfig *anv1alpha1.LatticeGatewayClassConfig) error {
 	routes, err := core.ListAllRoutes(ctx, r.client)
 	if err != nil {
 		return err
 	}
 
+	// routes are associated to the service networks of all their parents
 	for _, route := range routes {
-		if len(route.Spec().ParentRefs()) <= 0 {
-			continue
-		}
-		gwNamespace := route.Namespace()
-		if route.Spec().ParentRefs()[0].Namespace != nil {
-			gwNamespace = string(*route.Spec().ParentRefs()[0].Namespace)
-		}
-		gwName := types.NamespacedName{
-			Namespace: gwNamespace,
-			Name:      string(route.Spec().ParentRefs()[0].Name),
+		for _, parentRef := range route.Spec().ParentRefs() {
+			if gateway.ParentRefGatewayName(route, parentRef) == k8s.NamespacedName(gw) {
+				return fmt.Errorf("cannot delete gateway %s/%s - found referencing route %s/%s",
+					gw.Namespace, gw.Name, route.Namespace(), route.Name())
+			}
 		}
+	}
 
-		httpGw := &gwv1beta1.Gateway{}
-		if err := r.client.Get(ctx, gwName, httpGw); err != nil {
-			continue
+	if !config.DryRunMode {
+		if err := r.reconcileCertificates(ctx, gw, nil); err != nil {
+			return err
 		}
-
-		if httpGw.Name == gw.Name && httpGw.Namespace == gw.Namespace {
-			return fmt.Errorf("cannot delete gateway %s/%s - found referencing route %s/%s",
-				gw.Namespace, gw.Name, route.Namespace(), route.Name())
+		if err := r.deleteServiceNetwork(ctx, gw, snName, classConfig); err != nil {
+			return err
 		}
 	}
 
@@ -203,7 +228,8 @@ func (r *gatewayReconciler) reconcileDelete(ctx context.Context, gw *gwv1beta1.G
 	return nil
 }
 
-func (r *gatewayReconciler) reconcileUpsert(ctx context.Context, gw *gwv1beta1.Gateway) error {
+func (r *gatewayReconciler) reconcileUpsert(ctx context.Context, gw *gwv1beta1.Gateway, snName string,
+	classConfig *anv1alpha1.LatticeGatewayClassConfig) error {
 	if err := r.finalizerManager.AddFinalizers(ctx, gw, gatewayFinalizer); err != nil {
 		r.eventRecorder.Event(gw, corev1.EventTypeWarning,
 			k8s.GatewayEventReasonFailedAddFinalizer, fmt.Sprintf("failed add finalizer: %s", err))
@@ -223,7 +249,18 @@ func (r *gatewayReconciler) reconcileUpsert(ctx context.Context, gw *gwv1beta1.G
 		return err
 	}
 
-	snInfo, err := r.cloud.Lattice().FindServiceNetwork(ctx, gw.Name)
+	if !config.DryRunMode {
+		if err = r.reconcileCertificates(ctx, gw, gw.Spec.Listeners); err != nil {
+			return err
+		}
+	}
+
+	snInfo, err := r.cloud.Lattice().FindServiceNetwork(ctx, snName)
+	if services.IsNotFoundError(err) && isServiceNetworkManaged(gw, snName, classConfig) && !config.DryRunMode {
+		snInfo, err = r.createServiceNetwork(ctx, gw, snName, classConfig)
+	} else if err == nil && isServiceNetworkManaged(gw, snName, classConfig) {
+		r.warnServiceNetworkClaimed(gw, snInfo)
+	}
 	if err != nil {
 		if services.IsNotFoundError(err) {
 			if err = r.updateGatewayProgrammedStatus(ctx, gw, gwv1.GatewayReasonPending, "VPC Lattice Service Network not found"); err != nil {
@@ -408,19 +445,20 @@ func UpdateGWListenerStatus(ctx context.Context, k8sClient client.Client, gw *gw
 						continue
 					}
 
+					// only count routes the listener accepts
+					allowed, err := listenerAllowsRoute(ctx, k8sClient, gw, listener, route)
+					if err != nil {
+						return err
+					}
+					if !allowed || !listenerHostnameMatchesRoute(listener, route) {
+						continue
+					}
+
 					listenerStatus.AttachedRoutes++
 				}
 			}
 
-			if listener.Protocol == gwv1.HTTPSProtocolType {
-				listenerStatus.SupportedKinds = append(listenerStatus.SupportedKinds, gwv1beta1.RouteGroupKind{
-					Kind: "GRPCRoute",
-				})
-			}
-
-			listenerStatus.SupportedKinds = append(listenerStatus.SupportedKinds, gwv1beta1.RouteGroupKind{
-				Kind: "HTTPRoute",
-			})
+			listenerStatus.SupportedKinds = supportedKinds
 			listenerStatus.Conditions = append(listenerStatus.Conditions, condition)
 		}
 
@@ -450,31 +488,32 @@ func UpdateGWListenerStatus(ctx context.Context, k8sClient client.Client, gw *gw
 	}
 }
 
+// checks every kind in the listener allowedRoutes can be served by the listener protocol,
+// returns the supported kinds, which default to the protocol kinds when allowedRoutes has none
 func listenerRouteGroupKindSupported(listener gwv1beta1.Listener) (bool, []gwv1beta1.RouteGroupKind) {
+	protocolKinds := listenerDefaultRouteKinds(listener)
+	if listener.AllowedRoutes == nil || len(listener.AllowedRoutes.Kinds) == 0 {
+		return true, protocolKinds
+	}
+
 	validRoute := true
 	supportedKinds := make([]gwv1beta1.RouteGroupKind, 0)
-
 	for _, routeGroupKind := range listener.AllowedRoutes.Kinds {
-		if routeGroupKind.Kind == "HTTPRoute" {
+		supported := false
+		for _, protocolKind := range protocolKinds {
+			if routeGroupKind.Kind == protocolKind.Kind &&
+				(routeGroupKind.Group == nil || *routeGroupKind.Group == "" || *routeGroupKind.Group == gwv1.GroupName) {
+				supported = true
+			}
+		}
+		if supported {
 			supportedKinds = append(supportedKinds, gwv1beta1.RouteGroupKind{
-				Kind: "HTTPRoute",
+				Kind: routeGroupKind.Kind,
 			})
-		} else if routeGroupKind.Kind == "GRPCRoute" {
-			if listener.Protocol == gwv1.HTTPSProtocolType {
-				supportedKinds = append(supportedKinds, gwv1beta1.RouteGroupKind{
-					Kind: "GRPCRoute",
-				})
-			} else {
-				validRoute = false
-			}
 		} else {
 			validRoute = false
 		}
 	}
 
-	if validRoute {
-		return true, supportedKinds
-	} else {
-		return false, supportedKinds
-	}
+	return validRoute, supportedKinds
 }
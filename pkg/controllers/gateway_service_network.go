@@ -0,0 +1,127 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/vpclattice"
+	corev1 "k8s.io/api/core/v1"
+	gwv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	anv1alpha1 "github.com/aws/aws-application-networking-k8s/pkg/apis/applicationnetworking/v1alpha1"
+	"github.com/aws/aws-application-networking-k8s/pkg/aws/services"
+	"github.com/aws/aws-application-networking-k8s/pkg/config"
+	"github.com/aws/aws-application-networking-k8s/pkg/k8s"
+	model "github.com/aws/aws-application-networking-k8s/pkg/model/lattice"
+	"github.com/aws/aws-application-networking-k8s/pkg/utils"
+)
+
+// ManageServiceNetworkAnnotation set to "true" opts a gateway in to a service network created with the
+// gateway and deleted with it. Without it, the service network of the gateway must exist.
+const ManageServiceNetworkAnnotation = k8s.AnnotationPrefix + "manage-service-network"
+
+// In service network override mode every gateway maps to the default service network, which is not owned by any
+// of them. A gateway mapping to the default service network of the controller or of its class does not own it either.
+func isServiceNetworkManaged(gw *gwv1beta1.Gateway, snName string, classConfig *anv1alpha1.LatticeGatewayClassConfig) bool {
+	if config.ServiceNetworkOverrideMode || snName == config.DefaultServiceNetwork {
+		return false
+	}
+	if classConfig != nil && snName == classConfig.Spec.DefaultServiceNetwork {
+		return false
+	}
+	return gw.Annotations[ManageServiceNetworkAnnotation] == "true"
+}
+
+// newServiceNetworkSpec applies the defaults of the class config, if any, to a service network created by the controller
+func newServiceNetworkSpec(snName string, classConfig *anv1alpha1.LatticeGatewayClassConfig) model.ServiceNetworkSpec {
+	spec := model.ServiceNetworkSpec{Name: snName}
+	if classConfig == nil {
+		return spec
+	}
+	spec.AuthType = classConfig.Spec.ServiceNetworkAuthType
+	spec.Tags = classConfig.Spec.Tags
+	if len(classConfig.Spec.SecurityGroupIds) > 0 {
+		spec.SecurityGroupIds = utils.SliceMap(classConfig.Spec.SecurityGroupIds, func(sg anv1alpha1.SecurityGroupId) *string {
+			return aws.String(string(sg))
+		})
+	}
+	return spec
+}
+
+// createServiceNetwork creates the missing service network of a gateway managing it, tagged as managed by the
+// controller and associated to the cluster VPC. An existing service network is never updated, its VPC association
+// is then configured with a VpcAssociationPolicy. The service network is also tagged with the gateway claiming it,
+// gateways of different namespaces can have the same name.
+func (r *gatewayReconciler) createServiceNetwork(ctx context.Context, gw *gwv1beta1.Gateway, snName string,
+	classConfig *anv1alpha1.LatticeGatewayClassConfig) (*services.ServiceNetworkInfo, error) {
+	spec := newServiceNetworkSpec(snName, classConfig)
+	tags := map[string]string{}
+	for k, v := range spec.Tags {
+		tags[k] = v
+	}
+	tags[model.K8SGatewayNameKey] = gw.Name
+	tags[model.K8SGatewayNamespaceKey] = gw.Namespace
+	spec.Tags = tags
+	status, err := r.snManager.CreateOrUpdate(ctx, &model.ServiceNetwork{Spec: spec})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create service network of gateway %s/%s: %w", gw.Namespace, gw.Name, err)
+	}
+	r.eventRecorder.Event(gw, corev1.EventTypeNormal, k8s.GatewayEventReasonServiceNetworkCreated,
+		fmt.Sprintf("Created VPC Lattice service network %s", status.ServiceNetworkARN))
+	return &services.ServiceNetworkInfo{
+		SvcNetwork: vpclattice.ServiceNetworkSummary{
+			Arn:  aws.String(status.ServiceNetworkARN),
+			Id:   aws.String(status.ServiceNetworkID),
+			Name: aws.String(snName),
+		},
+	}, nil
+}
+
+// isServiceNetworkClaimedByOther tells whether the service network was created for another gateway. Service networks
+// created before gateways were recorded in their tags are not claimed by any.
+func isServiceNetworkClaimedByOther(gw *gwv1beta1.Gateway, tags services.Tags) bool {
+	name, namespace := tags[model.K8SGatewayNameKey], tags[model.K8SGatewayNamespaceKey]
+	if name == nil || namespace == nil {
+		return false
+	}
+	return aws.StringValue(name) != gw.Name || aws.StringValue(namespace) != gw.Namespace
+}
+
+// warnServiceNetworkClaimed reports a gateway managing a service network created for another gateway, it uses
+// the service network but never deletes it
+func (r *gatewayReconciler) warnServiceNetworkClaimed(gw *gwv1beta1.Gateway, snInfo *services.ServiceNetworkInfo) bool {
+	if !isServiceNetworkClaimedByOther(gw, snInfo.Tags) {
+		return false
+	}
+	r.eventRecorder.Event(gw, corev1.EventTypeWarning, k8s.GatewayEventReasonServiceNetworkClaimed,
+		fmt.Sprintf("VPC Lattice service network %s is managed by gateway %s/%s", aws.StringValue(snInfo.SvcNetwork.Name),
+			aws.StringValue(snInfo.Tags[model.K8SGatewayNamespaceKey]), aws.StringValue(snInfo.Tags[model.K8SGatewayNameKey])))
+	return true
+}
+
+// deleteServiceNetwork deletes the service network of a gateway managing it. The service network manager only
+// deletes it when it carries the managed-by tag of this controller, service networks created by someone else
+// are left untouched. So are the ones created for another gateway of the same name.
+func (r *gatewayReconciler) deleteServiceNetwork(ctx context.Context, gw *gwv1beta1.Gateway, snName string,
+	classConfig *anv1alpha1.LatticeGatewayClassConfig) error {
+	if !isServiceNetworkManaged(gw, snName, classConfig) {
+		return nil
+	}
+	snInfo, err := r.cloud.Lattice().FindServiceNetwork(ctx, snName)
+	if err != nil {
+		if services.IsNotFoundError(err) {
+			return nil
+		}
+		return err
+	}
+	if r.warnServiceNetworkClaimed(gw, snInfo) {
+		return nil
+	}
+	if err := r.snManager.Delete(ctx, snName); err != nil {
+		r.eventRecorder.Event(gw, corev1.EventTypeWarning, k8s.GatewayEventReasonFailedDeleteServiceNetwork,
+			fmt.Sprintf("Failed to delete VPC Lattice service network %s: %s", snName, err))
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,147 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/vpclattice"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gwv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	mock_client "github.com/aws/aws-application-networking-k8s/mocks/controller-runtime/client"
+	anv1alpha1 "github.com/aws/aws-application-networking-k8s/pkg/apis/applicationnetworking/v1alpha1"
+	pkg_aws "github.com/aws/aws-application-networking-k8s/pkg/aws"
+	"github.com/aws/aws-application-networking-k8s/pkg/aws/services"
+	"github.com/aws/aws-application-networking-k8s/pkg/config"
+	deploy "github.com/aws/aws-application-networking-k8s/pkg/deploy/lattice"
+	"github.com/aws/aws-application-networking-k8s/pkg/k8s"
+	model "github.com/aws/aws-application-networking-k8s/pkg/model/lattice"
+	"github.com/aws/aws-application-networking-k8s/pkg/utils/gwlog"
+)
+
+func TestIsServiceNetworkManaged(t *testing.T) {
+	defaultServiceNetwork := config.DefaultServiceNetwork
+	defer func() {
+		config.DefaultServiceNetwork = defaultServiceNetwork
+		config.ServiceNetworkOverrideMode = false
+	}()
+	config.DefaultServiceNetwork = "default-sn"
+
+	newGateway := func(name string, annotations map[string]string) *gwv1beta1.Gateway {
+		return &gwv1beta1.Gateway{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Annotations: annotations}}
+	}
+	managed := map[string]string{ManageServiceNetworkAnnotation: "true"}
+	classConfig := &anv1alpha1.LatticeGatewayClassConfig{
+		Spec: anv1alpha1.LatticeGatewayClassConfigSpec{DefaultServiceNetwork: "class-sn"},
+	}
+
+	assert.False(t, isServiceNetworkManaged(newGateway("gw", nil), "gw", nil))
+	assert.False(t, isServiceNetworkManaged(newGateway("gw", map[string]string{ManageServiceNetworkAnnotation: "false"}), "gw", nil))
+	assert.True(t, isServiceNetworkManaged(newGateway("gw", managed), "gw", nil))
+	assert.False(t, isServiceNetworkManaged(newGateway("default-sn", managed), "default-sn", nil))
+	assert.True(t, isServiceNetworkManaged(newGateway("gw", managed), "team-gw", classConfig))
+	assert.False(t, isServiceNetworkManaged(newGateway("gw", managed), "class-sn", classConfig))
+
+	config.ServiceNetworkOverrideMode = true
+	assert.False(t, isServiceNetworkManaged(newGateway("gw", managed), "gw", nil))
+}
+
+func TestGatewayReconciler_ServiceNetworkLifecycle(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+	ctx := context.TODO()
+
+	snManager := deploy.NewMockServiceNetworkManager(c)
+	eventRecorder := mock_client.NewMockEventRecorder(c)
+	mockCloud := pkg_aws.NewMockCloud(c)
+	mockLattice := services.NewMockLattice(c)
+	mockCloud.EXPECT().Lattice().Return(mockLattice).AnyTimes()
+	r := &gatewayReconciler{
+		log:           gwlog.FallbackLogger,
+		eventRecorder: eventRecorder,
+		snManager:     snManager,
+		cloud:         mockCloud,
+	}
+	gw := &gwv1beta1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "gw",
+			Namespace:   "default",
+			Annotations: map[string]string{ManageServiceNetworkAnnotation: "true"},
+		},
+	}
+
+	gwTags := map[string]string{model.K8SGatewayNameKey: "gw", model.K8SGatewayNamespaceKey: "default"}
+	snManager.EXPECT().CreateOrUpdate(ctx, &model.ServiceNetwork{Spec: model.ServiceNetworkSpec{Name: "gw", Tags: gwTags}}).
+		Return(model.ServiceNetworkStatus{ServiceNetworkARN: "sn-arn", ServiceNetworkID: "sn-id"}, nil)
+	eventRecorder.EXPECT().Event(gw, corev1.EventTypeNormal, k8s.GatewayEventReasonServiceNetworkCreated,
+		"Created VPC Lattice service network sn-arn")
+	snInfo, err := r.createServiceNetwork(ctx, gw, "gw", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "sn-arn", aws.StringValue(snInfo.SvcNetwork.Arn))
+	assert.Equal(t, "sn-id", aws.StringValue(snInfo.SvcNetwork.Id))
+
+	snInfo = &services.ServiceNetworkInfo{
+		SvcNetwork: vpclattice.ServiceNetworkSummary{Name: aws.String("gw")},
+		Tags:       aws.StringMap(gwTags),
+	}
+	mockLattice.EXPECT().FindServiceNetwork(ctx, "gw").Return(snInfo, nil).Times(2)
+	snManager.EXPECT().Delete(ctx, "gw").Return(errors.New("in use"))
+	eventRecorder.EXPECT().Event(gw, corev1.EventTypeWarning, k8s.GatewayEventReasonFailedDeleteServiceNetwork,
+		"Failed to delete VPC Lattice service network gw: in use")
+	assert.Error(t, r.deleteServiceNetwork(ctx, gw, "gw", nil))
+
+	snManager.EXPECT().Delete(ctx, "gw").Return(nil)
+	assert.NoError(t, r.deleteServiceNetwork(ctx, gw, "gw", nil))
+
+	// nor is the one created for a gateway of the same name in another namespace
+	gw.Namespace = "other"
+	mockLattice.EXPECT().FindServiceNetwork(ctx, "gw").Return(snInfo, nil)
+	eventRecorder.EXPECT().Event(gw, corev1.EventTypeWarning, k8s.GatewayEventReasonServiceNetworkClaimed,
+		"VPC Lattice service network gw is managed by gateway default/gw")
+	assert.NoError(t, r.deleteServiceNetwork(ctx, gw, "gw", nil))
+
+	mockLattice.EXPECT().FindServiceNetwork(ctx, "gw").Return(nil, services.NewNotFoundError("Service network", "gw"))
+	assert.NoError(t, r.deleteServiceNetwork(ctx, gw, "gw", nil))
+
+	// the service network of a gateway not managing it is never deleted
+	gw.Annotations = nil
+	assert.NoError(t, r.deleteServiceNetwork(ctx, gw, "gw", nil))
+}
+
+func TestIsServiceNetworkClaimedByOther(t *testing.T) {
+	gw := &gwv1beta1.Gateway{ObjectMeta: metav1.ObjectMeta{Name: "gw", Namespace: "default"}}
+
+	assert.False(t, isServiceNetworkClaimedByOther(gw, nil))
+	assert.False(t, isServiceNetworkClaimedByOther(gw, services.Tags{
+		model.K8SGatewayNameKey: aws.String("gw"), model.K8SGatewayNamespaceKey: aws.String("default"),
+	}))
+	assert.True(t, isServiceNetworkClaimedByOther(gw, services.Tags{
+		model.K8SGatewayNameKey: aws.String("gw"), model.K8SGatewayNamespaceKey: aws.String("other"),
+	}))
+	assert.True(t, isServiceNetworkClaimedByOther(gw, services.Tags{
+		model.K8SGatewayNameKey: aws.String("other-gw"), model.K8SGatewayNamespaceKey: aws.String("default"),
+	}))
+}
+
+func TestNewServiceNetworkSpec(t *testing.T) {
+	assert.Equal(t, model.ServiceNetworkSpec{Name: "sn"}, newServiceNetworkSpec("sn", nil))
+
+	classConfig := &anv1alpha1.LatticeGatewayClassConfig{
+		Spec: anv1alpha1.LatticeGatewayClassConfigSpec{
+			ServiceNetworkAuthType: "AWS_IAM",
+			Tags:                   map[string]string{"team": "a"},
+			SecurityGroupIds:       []anv1alpha1.SecurityGroupId{"sg-1"},
+		},
+	}
+	assert.Equal(t, model.ServiceNetworkSpec{
+		Name:             "sn",
+		AuthType:         "AWS_IAM",
+		Tags:             map[string]string{"team": "a"},
+		SecurityGroupIds: []*string{aws.String("sg-1")},
+	}, newServiceNetworkSpec("sn", classConfig))
+}
@@ -18,11 +18,19 @@ package controllers
 
 import (
 	"context"
-	"time"
+	"errors"
+	"fmt"
 
+	anv1alpha1 "github.com/aws/aws-application-networking-k8s/pkg/apis/applicationnetworking/v1alpha1"
+	"github.com/aws/aws-application-networking-k8s/pkg/aws"
 	"github.com/aws/aws-application-networking-k8s/pkg/config"
+	"github.com/aws/aws-application-networking-k8s/pkg/controllers/eventhandlers"
+	deploy "github.com/aws/aws-application-networking-k8s/pkg/deploy/lattice"
+	"github.com/aws/aws-application-networking-k8s/pkg/gateway"
+	"github.com/aws/aws-application-networking-k8s/pkg/k8s"
+	model "github.com/aws/aws-application-networking-k8s/pkg/model/lattice"
+	"github.com/aws/aws-application-networking-k8s/pkg/utils"
 	"github.com/aws/aws-application-networking-k8s/pkg/utils/gwlog"
-	"github.com/pkg/errors"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -36,24 +44,38 @@ type gatewayClassReconciler struct {
 	log                      gwlog.Logger
 	client                   client.Client
 	scheme                   *runtime.Scheme
+	snManager                deploy.ServiceNetworkManager
 	latticeControllerEnabled bool
 }
 
-func RegisterGatewayClassController(log gwlog.Logger, mgr ctrl.Manager) error {
+func RegisterGatewayClassController(log gwlog.Logger, cloud aws.Cloud, mgr ctrl.Manager) error {
 	r := &gatewayClassReconciler{
 		log:                      log,
 		client:                   mgr.GetClient(),
 		scheme:                   mgr.GetScheme(),
+		snManager:                deploy.NewDefaultServiceNetworkManager(log, cloud),
 		latticeControllerEnabled: false,
 	}
-	return ctrl.NewControllerManagedBy(mgr).
-		For(&gwv1beta1.GatewayClass{}).
-		Complete(r)
+	builder := ctrl.NewControllerManagedBy(mgr).
+		For(&gwv1beta1.GatewayClass{})
+
+	ok, err := k8s.IsGVKSupported(mgr, anv1alpha1.GroupVersion.String(), anv1alpha1.LatticeGatewayClassConfigKind)
+	if err != nil {
+		return err
+	}
+	if ok {
+		classConfigEventHandler := eventhandlers.NewLatticeGatewayClassConfigEventHandler(log, mgr.GetClient())
+		builder.Watches(&anv1alpha1.LatticeGatewayClassConfig{}, classConfigEventHandler.MapToGatewayClass())
+	} else {
+		log.Infof("LatticeGatewayClassConfig CRD is not installed, skipping watch")
+	}
+	return builder.Complete(r)
 }
 
 //+kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=gatewayclasses,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=gatewayclasses/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=gatewayclasses/finalizers,verbs=update
+//+kubebuilder:rbac:groups=application-networking.k8s.aws,resources=latticegatewayclassconfigs,verbs=get;list;watch
 
 func (r *gatewayClassReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	r.log.Infow("reconcile", "name", req.Name)
@@ -74,18 +96,50 @@ func (r *gatewayClassReconciler) Reconcile(ctx context.Context, req ctrl.Request
 	}
 	r.latticeControllerEnabled = true
 
-	// Update Status
-	gwClassOld := gwClass.DeepCopy()
-	gwClass.Status.Conditions[0].LastTransitionTime = metav1.NewTime(time.Now())
-	gwClass.Status.Conditions[0].ObservedGeneration = gwClass.Generation
-	gwClass.Status.Conditions[0].Status = "True"
-	gwClass.Status.Conditions[0].Message = string(gwv1.GatewayClassReasonAccepted)
-	gwClass.Status.Conditions[0].Reason = string(gwv1.GatewayClassReasonAccepted)
+	accepted := metav1.Condition{
+		Type:               string(gwv1.GatewayClassConditionStatusAccepted),
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: gwClass.Generation,
+		Reason:             string(gwv1.GatewayClassReasonAccepted),
+		Message:            string(gwv1.GatewayClassReasonAccepted),
+	}
+	classConfig, err := gateway.GetGatewayClassConfig(ctx, r.client, gwClass)
+	if err != nil {
+		var invalidErr *gateway.InvalidGatewayClassConfigError
+		if !errors.As(err, &invalidErr) {
+			return ctrl.Result{}, err
+		}
+		// fixing the config triggers a reconcile
+		accepted.Status = metav1.ConditionFalse
+		accepted.Reason = string(gwv1.GatewayClassReasonInvalidParameters)
+		accepted.Message = invalidErr.Message
+	}
 
+	gwClassOld := gwClass.DeepCopy()
+	gwClass.Status.Conditions = utils.GetNewConditions(gwClass.Status.Conditions, accepted)
 	if err := r.client.Status().Patch(ctx, gwClass, client.MergeFrom(gwClassOld)); err != nil {
-		return ctrl.Result{}, errors.Wrapf(err, "failed to update gatewayclass status")
+		return ctrl.Result{}, fmt.Errorf("failed to update gatewayclass status: %w", err)
+	}
+
+	if classConfig != nil && classConfig.Spec.DefaultServiceNetwork != "" && !config.DryRunMode {
+		if err := r.createDefaultServiceNetwork(ctx, classConfig); err != nil {
+			return ctrl.Result{}, err
+		}
 	}
 
 	r.log.Infow("reconciled", "name", gwClass.Name, "status", gwClass.Status)
 	return ctrl.Result{}, nil
 }
+
+// createDefaultServiceNetwork creates the default service network of a class config and associates it to the
+// cluster VPC, like the controller does for the DEFAULT_SERVICE_NETWORK. It is never deleted by the controller.
+func (r *gatewayClassReconciler) createDefaultServiceNetwork(ctx context.Context, classConfig *anv1alpha1.LatticeGatewayClassConfig) error {
+	_, err := r.snManager.CreateOrUpdate(ctx, &model.ServiceNetwork{
+		Spec: newServiceNetworkSpec(classConfig.Spec.DefaultServiceNetwork, classConfig),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create default service network %s of %s %s: %w",
+			classConfig.Spec.DefaultServiceNetwork, anv1alpha1.LatticeGatewayClassConfigKind, classConfig.Name, err)
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gwv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	anv1alpha1 "github.com/aws/aws-application-networking-k8s/pkg/apis/applicationnetworking/v1alpha1"
+	"github.com/aws/aws-application-networking-k8s/pkg/config"
+	deploy "github.com/aws/aws-application-networking-k8s/pkg/deploy/lattice"
+	model "github.com/aws/aws-application-networking-k8s/pkg/model/lattice"
+	"github.com/aws/aws-application-networking-k8s/pkg/utils/gwlog"
+)
+
+func TestGatewayClassReconciler_ParametersRef(t *testing.T) {
+	classConfig := &anv1alpha1.LatticeGatewayClassConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "team-config"},
+		Spec: anv1alpha1.LatticeGatewayClassConfigSpec{
+			DefaultServiceNetwork:  "team-sn",
+			ServiceNetworkAuthType: "AWS_IAM",
+		},
+	}
+
+	tests := []struct {
+		name            string
+		parametersRef   *gwv1beta1.ParametersReference
+		setup           func(snManager *deploy.MockServiceNetworkManager)
+		expectedStatus  metav1.ConditionStatus
+		expectedReason  gwv1.GatewayClassConditionReason
+		expectedMessage string
+	}{
+		{
+			name:            "class without parametersRef is accepted",
+			setup:           func(snManager *deploy.MockServiceNetworkManager) {},
+			expectedStatus:  metav1.ConditionTrue,
+			expectedReason:  gwv1.GatewayClassReasonAccepted,
+			expectedMessage: string(gwv1.GatewayClassReasonAccepted),
+		},
+		{
+			name: "default service network of the config is created",
+			parametersRef: &gwv1beta1.ParametersReference{
+				Group: anv1alpha1.GroupName,
+				Kind:  anv1alpha1.LatticeGatewayClassConfigKind,
+				Name:  "team-config",
+			},
+			setup: func(snManager *deploy.MockServiceNetworkManager) {
+				snManager.EXPECT().CreateOrUpdate(gomock.Any(), &model.ServiceNetwork{
+					Spec: model.ServiceNetworkSpec{Name: "team-sn", AuthType: "AWS_IAM"},
+				}).Return(model.ServiceNetworkStatus{ServiceNetworkARN: "sn-arn", ServiceNetworkID: "sn-id"}, nil)
+			},
+			expectedStatus:  metav1.ConditionTrue,
+			expectedReason:  gwv1.GatewayClassReasonAccepted,
+			expectedMessage: string(gwv1.GatewayClassReasonAccepted),
+		},
+		{
+			name: "class referencing a missing config is not accepted",
+			parametersRef: &gwv1beta1.ParametersReference{
+				Group: anv1alpha1.GroupName,
+				Kind:  anv1alpha1.LatticeGatewayClassConfigKind,
+				Name:  "missing",
+			},
+			setup:           func(snManager *deploy.MockServiceNetworkManager) {},
+			expectedStatus:  metav1.ConditionFalse,
+			expectedReason:  gwv1.GatewayClassReasonInvalidParameters,
+			expectedMessage: "LatticeGatewayClassConfig missing not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+			ctx := context.TODO()
+
+			k8sScheme := runtime.NewScheme()
+			clientgoscheme.AddToScheme(k8sScheme)
+			gwv1beta1.AddToScheme(k8sScheme)
+			anv1alpha1.AddToScheme(k8sScheme)
+			gwClass := &gwv1beta1.GatewayClass{
+				ObjectMeta: metav1.ObjectMeta{Name: "team-class"},
+				Spec: gwv1beta1.GatewayClassSpec{
+					ControllerName: config.LatticeGatewayControllerName,
+					ParametersRef:  tt.parametersRef,
+				},
+			}
+			k8sClient := testclient.NewClientBuilder().WithScheme(k8sScheme).
+				WithObjects(gwClass, classConfig.DeepCopy()).
+				WithStatusSubresource(gwClass).
+				Build()
+
+			snManager := deploy.NewMockServiceNetworkManager(c)
+			tt.setup(snManager)
+			r := &gatewayClassReconciler{
+				log:       gwlog.FallbackLogger,
+				client:    k8sClient,
+				snManager: snManager,
+			}
+			_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: "team-class"}})
+			assert.NoError(t, err)
+
+			updated := &gwv1beta1.GatewayClass{}
+			assert.NoError(t, k8sClient.Get(ctx, types.NamespacedName{Name: "team-class"}, updated))
+			assert.Len(t, updated.Status.Conditions, 1)
+			cond := updated.Status.Conditions[0]
+			assert.Equal(t, string(gwv1.GatewayClassConditionStatusAccepted), cond.Type)
+			assert.Equal(t, tt.expectedStatus, cond.Status)
+			assert.Equal(t, string(tt.expectedReason), cond.Reason)
+			assert.Equal(t, tt.expectedMessage, cond.Message)
+		})
+	}
+}
@@ -7,11 +7,13 @@ import (
 	pkg_aws "github.com/aws/aws-application-networking-k8s/pkg/aws"
 	"github.com/aws/aws-application-networking-k8s/pkg/aws/services"
 	deploy "github.com/aws/aws-application-networking-k8s/pkg/deploy/lattice"
+	"github.com/aws/aws-application-networking-k8s/pkg/gateway"
 	"github.com/aws/aws-application-networking-k8s/pkg/k8s"
 	policy "github.com/aws/aws-application-networking-k8s/pkg/k8s/policyhelper"
 	model "github.com/aws/aws-application-networking-k8s/pkg/model/lattice"
 	"github.com/aws/aws-application-networking-k8s/pkg/utils/gwlog"
 
+	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -113,8 +115,11 @@ func (c *IAMAuthPolicyController) Reconcile(ctx context.Context, req ctrl.Reques
 func (c *IAMAuthPolicyController) reconcileDelete(ctx context.Context, k8sPolicy *anv1alpha1.IAMAuthPolicy) (ctrl.Result, error) {
 	err := c.ph.ValidateTargetRef(ctx, k8sPolicy)
 	if err == nil {
-		modelPolicy := model.NewIAMAuthPolicy(k8sPolicy)
-		_, err := c.pm.Delete(ctx, modelPolicy)
+		modelPolicy, err := c.newModelPolicy(ctx, k8sPolicy)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		_, err = c.pm.Delete(ctx, modelPolicy)
 		if err != nil {
 			return ctrl.Result{}, services.IgnoreNotFound(err)
 		}
@@ -135,7 +140,10 @@ func (c *IAMAuthPolicyController) reconcileUpsert(ctx context.Context, k8sPolicy
 	if reason != policy.ReasonAccepted {
 		return ctrl.Result{}, nil
 	}
-	modelPolicy := model.NewIAMAuthPolicy(k8sPolicy)
+	modelPolicy, err := c.newModelPolicy(ctx, k8sPolicy)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
 	c.addFinalizer(k8sPolicy)
 	err = c.client.Update(ctx, k8sPolicy)
 	if err != nil {
@@ -153,6 +161,22 @@ func (c *IAMAuthPolicyController) reconcileUpsert(ctx context.Context, k8sPolicy
 	return ctrl.Result{}, nil
 }
 
+// the service network of a targeted gateway is not necessarily named after it
+func (c *IAMAuthPolicyController) newModelPolicy(ctx context.Context, k8sPolicy *anv1alpha1.IAMAuthPolicy) (model.IAMAuthPolicy, error) {
+	modelPolicy := model.NewIAMAuthPolicy(k8sPolicy)
+	if modelPolicy.Type == model.ServiceNetworkType {
+		snName, err := gateway.ServiceNetworkName(ctx, c.client, types.NamespacedName{
+			Namespace: k8sPolicy.Namespace,
+			Name:      string(k8sPolicy.Spec.TargetRef.Name),
+		})
+		if err != nil {
+			return model.IAMAuthPolicy{}, err
+		}
+		modelPolicy.Name = snName
+	}
+	return modelPolicy, nil
+}
+
 func (c *IAMAuthPolicyController) removeFinalizer(k8sPolicy *anv1alpha1.IAMAuthPolicy) {
 	if controllerutil.ContainsFinalizer(k8sPolicy, IAMAuthPolicyFinalizer) {
 		controllerutil.RemoveFinalizer(k8sPolicy, IAMAuthPolicyFinalizer)
@@ -0,0 +1,184 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	anv1alpha1 "github.com/aws/aws-application-networking-k8s/pkg/apis/applicationnetworking/v1alpha1"
+	"github.com/aws/aws-application-networking-k8s/pkg/config"
+	"github.com/aws/aws-application-networking-k8s/pkg/k8s"
+	"github.com/aws/aws-application-networking-k8s/pkg/utils/gwlog"
+)
+
+const (
+	// exports declaring spec.ports are not migrated, the migration is retried until they are removed or changed
+	multiclusterMigrationRetryInterval = 5 * time.Minute
+)
+
+// MulticlusterMigration copies the ServiceExports and ServiceImports of the application-networking.k8s.aws group to
+// the multicluster.x-k8s.io group when the controller is configured with the latter. Target groups are identified by
+// the service name and namespace, the copies keep using the target groups of the original exports. The controller
+// finalizers are removed from the originals, which can then be deleted.
+//
+// It only runs on the leader, and is retried until every object is migrated. Target groups of exports not migrated
+// yet are not deleted meanwhile.
+type MulticlusterMigration struct {
+	log              gwlog.Logger
+	client           client.Client
+	finalizerManager k8s.FinalizerManager
+	eventRecorder    record.EventRecorder
+	interval         time.Duration
+}
+
+func NewMulticlusterMigration(log gwlog.Logger, mgr ctrl.Manager) *MulticlusterMigration {
+	return &MulticlusterMigration{
+		log:              log,
+		client:           mgr.GetClient(),
+		finalizerManager: k8s.NewDefaultFinalizerManager(mgr.GetClient()),
+		eventRecorder:    mgr.GetEventRecorderFor("multicluster-migration"),
+		interval:         multiclusterMigrationRetryInterval,
+	}
+}
+
+func (m *MulticlusterMigration) Start(ctx context.Context) error {
+	if config.MulticlusterAPIGroup != config.MulticlusterAPIGroupUpstream {
+		return nil
+	}
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+	for {
+		done, err := m.migrateAll(ctx)
+		if err != nil {
+			m.log.Errorf("Failed to migrate to group %s, retrying in %s: %s", anv1alpha1.MulticlusterGroupName, m.interval, err)
+		} else if done {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// NeedLeaderElection makes the migration run only on the leader, as it creates objects and removes finalizers
+func (m *MulticlusterMigration) NeedLeaderElection() bool {
+	return true
+}
+
+// migrateAll tells whether every object is migrated. ServiceExports declaring spec.ports are left as they are,
+// the upstream ServiceExport has no spec and their target groups would be replaced.
+func (m *MulticlusterMigration) migrateAll(ctx context.Context) (bool, error) {
+	svcExports, err := m.listLegacy(ctx, "ServiceExport")
+	if err != nil {
+		return false, err
+	}
+	svcImports, err := m.listLegacy(ctx, "ServiceImport")
+	if err != nil {
+		return false, err
+	}
+
+	done := true
+	for i := range svcExports {
+		svcExport := &anv1alpha1.ServiceExport{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(svcExports[i].Object, svcExport); err != nil {
+			return false, err
+		}
+		if len(svcExport.Spec.Ports) > 0 && svcExport.DeletionTimestamp.IsZero() {
+			m.eventRecorder.Event(&svcExports[i], corev1.EventTypeWarning, k8s.ServiceExportEventReasonMigrationBlocked,
+				fmt.Sprintf("Not copied to group %s, which does not support spec.ports. Use the port annotation instead",
+					anv1alpha1.MulticlusterGroupName))
+			done = false
+			continue
+		}
+		err := m.migrate(ctx, &svcExports[i], serviceExportFinalizer, func(objMeta metav1.ObjectMeta) (client.Object, error) {
+			return &anv1alpha1.ServiceExport{ObjectMeta: objMeta}, nil
+		})
+		if err != nil {
+			return false, err
+		}
+	}
+	for i := range svcImports {
+		err := m.migrate(ctx, &svcImports[i], serviceImportFinalizer, func(objMeta metav1.ObjectMeta) (client.Object, error) {
+			svcImport := &anv1alpha1.ServiceImport{}
+			if err := runtime.DefaultUnstructuredConverter.FromUnstructured(svcImports[i].Object, svcImport); err != nil {
+				return nil, err
+			}
+			migrated := &anv1alpha1.ServiceImport{ObjectMeta: objMeta, Spec: svcImport.Spec}
+			// both are required by the upstream CRD
+			if migrated.Spec.Type == "" {
+				migrated.Spec.Type = anv1alpha1.ClusterSetIP
+			}
+			if migrated.Spec.Ports == nil {
+				migrated.Spec.Ports = []anv1alpha1.ServicePort{}
+			}
+			return migrated, nil
+		})
+		if err != nil {
+			return false, err
+		}
+	}
+	return done, nil
+}
+
+// the CRDs of the upstream group have a status subresource, the ones of the controller do not
+func hasMulticlusterStatusSubresource() bool {
+	return config.MulticlusterAPIGroup == config.MulticlusterAPIGroupUpstream
+}
+
+// listLegacy lists the objects of the application-networking.k8s.aws group, none when its CRD is not installed
+func (m *MulticlusterMigration) listLegacy(ctx context.Context, kind string) ([]unstructured.Unstructured, error) {
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(anv1alpha1.SchemeGroupVersion.WithKind(kind + "List"))
+	if err := m.client.List(ctx, list); err != nil {
+		if meta.IsNoMatchError(err) || apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to list %s %ss due to %w", anv1alpha1.GroupName, kind, err)
+	}
+	return list.Items, nil
+}
+
+func (m *MulticlusterMigration) migrate(ctx context.Context, legacy *unstructured.Unstructured, finalizer string,
+	newObj func(metav1.ObjectMeta) (client.Object, error)) error {
+	name := legacy.GetNamespace() + "/" + legacy.GetName()
+
+	// an object being deleted is not copied, its target groups are deleted once it is gone
+	if legacy.GetDeletionTimestamp().IsZero() {
+		annotations := legacy.GetAnnotations()
+		delete(annotations, corev1.LastAppliedConfigAnnotation)
+		obj, err := newObj(metav1.ObjectMeta{
+			Namespace:   legacy.GetNamespace(),
+			Name:        legacy.GetName(),
+			Labels:      legacy.GetLabels(),
+			Annotations: annotations,
+		})
+		if err != nil {
+			return err
+		}
+		if err := m.client.Create(ctx, obj); err != nil {
+			if !apierrors.IsAlreadyExists(err) {
+				return fmt.Errorf("failed to copy %s %s due to %w", legacy.GetKind(), name, err)
+			}
+			m.log.Infof("%s %s already exists in group %s", legacy.GetKind(), name, anv1alpha1.MulticlusterGroupName)
+		} else {
+			m.log.Infof("Copied %s %s to group %s", legacy.GetKind(), name, anv1alpha1.MulticlusterGroupName)
+		}
+	}
+
+	if err := m.finalizerManager.RemoveFinalizers(ctx, legacy, finalizer); err != nil {
+		return fmt.Errorf("failed to remove finalizer of %s %s due to %w", legacy.GetKind(), name, err)
+	}
+	return nil
+}
@@ -0,0 +1,142 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	anv1alpha1 "github.com/aws/aws-application-networking-k8s/pkg/apis/applicationnetworking/v1alpha1"
+	"github.com/aws/aws-application-networking-k8s/pkg/config"
+	"github.com/aws/aws-application-networking-k8s/pkg/k8s"
+	"github.com/aws/aws-application-networking-k8s/pkg/utils/gwlog"
+)
+
+func newLegacyMulticlusterObject(kind, name string, spec map[string]interface{}, finalizer string) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{}}
+	obj.SetGroupVersionKind(anv1alpha1.SchemeGroupVersion.WithKind(kind))
+	obj.SetNamespace("default")
+	obj.SetName(name)
+	obj.SetAnnotations(map[string]string{"application-networking.k8s.aws/federation": "amazon-vpc-lattice"})
+	obj.SetFinalizers([]string{finalizer})
+	if spec != nil {
+		obj.Object["spec"] = spec
+	}
+	return obj
+}
+
+func newMulticlusterMigrationClient(objs ...client.Object) client.Client {
+	k8sScheme := runtime.NewScheme()
+	clientgoscheme.AddToScheme(k8sScheme)
+	anv1alpha1.AddToSchemeWithMulticlusterGroup(k8sScheme, anv1alpha1.MulticlusterGroupName)
+	// the objects of the controller group are only read as unstructured
+	for _, kind := range []string{"ServiceExport", "ServiceImport"} {
+		k8sScheme.AddKnownTypeWithName(anv1alpha1.SchemeGroupVersion.WithKind(kind), &unstructured.Unstructured{})
+		k8sScheme.AddKnownTypeWithName(anv1alpha1.SchemeGroupVersion.WithKind(kind+"List"), &unstructured.UnstructuredList{})
+	}
+	return testclient.NewClientBuilder().WithScheme(k8sScheme).WithObjects(objs...).Build()
+}
+
+func newTestMulticlusterMigration(k8sClient client.Client) *MulticlusterMigration {
+	return &MulticlusterMigration{
+		log:              gwlog.FallbackLogger,
+		client:           k8sClient,
+		finalizerManager: k8s.NewDefaultFinalizerManager(k8sClient),
+		eventRecorder:    record.NewFakeRecorder(10),
+		interval:         time.Millisecond,
+	}
+}
+
+func TestMulticlusterMigration(t *testing.T) {
+	multiclusterAPIGroup := config.MulticlusterAPIGroup
+	defer func() { config.MulticlusterAPIGroup = multiclusterAPIGroup }()
+	config.MulticlusterAPIGroup = config.MulticlusterAPIGroupUpstream
+	ctx := context.TODO()
+
+	k8sClient := newMulticlusterMigrationClient(
+		newLegacyMulticlusterObject("ServiceExport", "svc", nil, serviceExportFinalizer),
+		newLegacyMulticlusterObject("ServiceImport", "svc", map[string]interface{}{}, serviceImportFinalizer),
+		newLegacyMulticlusterObject("ServiceImport", "grpc-svc", map[string]interface{}{
+			"type":  "ClusterSetIP",
+			"ports": []interface{}{map[string]interface{}{"port": int64(50051), "protocol": "TCP"}},
+		}, serviceImportFinalizer),
+		// already migrated
+		&anv1alpha1.ServiceImport{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "grpc-svc"},
+			Spec:       anv1alpha1.ServiceImportSpec{Type: anv1alpha1.Headless},
+		},
+	)
+	// returns once every object is migrated
+	assert.NoError(t, newTestMulticlusterMigration(k8sClient).Start(ctx))
+
+	svcExport := &anv1alpha1.ServiceExport{}
+	assert.NoError(t, k8sClient.Get(ctx, types.NamespacedName{Namespace: "default", Name: "svc"}, svcExport))
+	assert.Equal(t, "amazon-vpc-lattice", svcExport.Annotations["application-networking.k8s.aws/federation"])
+	assert.Empty(t, svcExport.Finalizers)
+
+	svcImport := &anv1alpha1.ServiceImport{}
+	assert.NoError(t, k8sClient.Get(ctx, types.NamespacedName{Namespace: "default", Name: "svc"}, svcImport))
+	assert.Equal(t, anv1alpha1.ClusterSetIP, svcImport.Spec.Type)
+	assert.NoError(t, k8sClient.Get(ctx, types.NamespacedName{Namespace: "default", Name: "grpc-svc"}, svcImport))
+	assert.Equal(t, anv1alpha1.Headless, svcImport.Spec.Type)
+
+	// the originals are left without the controller finalizer
+	for _, kind := range []string{"ServiceExport", "ServiceImport"} {
+		legacy := &unstructured.Unstructured{}
+		legacy.SetGroupVersionKind(anv1alpha1.SchemeGroupVersion.WithKind(kind))
+		assert.NoError(t, k8sClient.Get(ctx, types.NamespacedName{Namespace: "default", Name: "svc"}, legacy))
+		assert.Empty(t, legacy.GetFinalizers())
+	}
+}
+
+func TestMulticlusterMigration_ExportedPorts(t *testing.T) {
+	multiclusterAPIGroup := config.MulticlusterAPIGroup
+	defer func() { config.MulticlusterAPIGroup = multiclusterAPIGroup }()
+	config.MulticlusterAPIGroup = config.MulticlusterAPIGroupUpstream
+	ctx := context.TODO()
+
+	k8sClient := newMulticlusterMigrationClient(
+		newLegacyMulticlusterObject("ServiceExport", "svc", nil, serviceExportFinalizer),
+		newLegacyMulticlusterObject("ServiceExport", "ports-svc", map[string]interface{}{
+			"ports": []interface{}{map[string]interface{}{"port": int64(80)}},
+		}, serviceExportFinalizer),
+	)
+	m := newTestMulticlusterMigration(k8sClient)
+	done, err := m.migrateAll(ctx)
+	assert.NoError(t, err)
+	assert.False(t, done)
+
+	// the other exports are migrated
+	assert.NoError(t, k8sClient.Get(ctx, types.NamespacedName{Namespace: "default", Name: "svc"}, &anv1alpha1.ServiceExport{}))
+
+	// the blocked one is reported on the object and keeps its finalizer
+	assert.Error(t, k8sClient.Get(ctx, types.NamespacedName{Namespace: "default", Name: "ports-svc"}, &anv1alpha1.ServiceExport{}))
+	legacy := &unstructured.Unstructured{}
+	legacy.SetGroupVersionKind(anv1alpha1.SchemeGroupVersion.WithKind("ServiceExport"))
+	assert.NoError(t, k8sClient.Get(ctx, types.NamespacedName{Namespace: "default", Name: "ports-svc"}, legacy))
+	assert.Equal(t, []string{serviceExportFinalizer}, legacy.GetFinalizers())
+	event := <-m.eventRecorder.(*record.FakeRecorder).Events
+	assert.Contains(t, event, k8s.ServiceExportEventReasonMigrationBlocked)
+
+	// the migration is retried until it is stopped
+	m.interval = time.Hour
+	ctx, cancel := context.WithCancel(ctx)
+	cancel()
+	assert.NoError(t, m.Start(ctx))
+	assert.Len(t, m.eventRecorder.(*record.FakeRecorder).Events, 1)
+}
+
+func TestMulticlusterMigration_ControllerGroup(t *testing.T) {
+	ctx := context.TODO()
+	// nothing is read with the controller group, a nil client would panic otherwise
+	assert.NoError(t, newTestMulticlusterMigration(nil).Start(ctx))
+}
@@ -0,0 +1,136 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gwv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/aws/aws-application-networking-k8s/pkg/model/core"
+)
+
+// route kinds a listener accepts when its allowedRoutes.kinds is empty, derived from the listener protocol
+func listenerDefaultRouteKinds(listener gwv1beta1.Listener) []gwv1beta1.RouteGroupKind {
+	switch listener.Protocol {
+	case gwv1.HTTPProtocolType:
+		return []gwv1beta1.RouteGroupKind{{Kind: "HTTPRoute"}}
+	case gwv1.HTTPSProtocolType:
+		return []gwv1beta1.RouteGroupKind{{Kind: "GRPCRoute"}, {Kind: "HTTPRoute"}}
+	case gwv1.TLSProtocolType:
+		return []gwv1beta1.RouteGroupKind{{Kind: "TLSRoute"}}
+	default:
+		return []gwv1beta1.RouteGroupKind{}
+	}
+}
+
+// checks the route GroupKind is one of the kinds allowed by the listener
+func listenerAllowsRouteKind(listener gwv1beta1.Listener, route core.Route) bool {
+	kinds := listenerDefaultRouteKinds(listener)
+	if listener.AllowedRoutes != nil && len(listener.AllowedRoutes.Kinds) > 0 {
+		kinds = listener.AllowedRoutes.Kinds
+	}
+
+	routeGk := route.GroupKind()
+	for _, kind := range kinds {
+		group := gwv1.GroupName
+		if kind.Group != nil && *kind.Group != "" {
+			group = string(*kind.Group)
+		}
+		if group == routeGk.Group && string(kind.Kind) == routeGk.Kind {
+			return true
+		}
+	}
+	return false
+}
+
+// checks the route namespace is allowed by the listener allowedRoutes.namespaces, defaults to Same
+func listenerAllowsRouteNamespace(ctx context.Context, k8sClient client.Client,
+	gw *gwv1beta1.Gateway, listener gwv1beta1.Listener, route core.Route) (bool, error) {
+	from := gwv1.NamespacesFromSame
+	var selector *metav1.LabelSelector
+	if listener.AllowedRoutes != nil && listener.AllowedRoutes.Namespaces != nil {
+		if listener.AllowedRoutes.Namespaces.From != nil {
+			from = *listener.AllowedRoutes.Namespaces.From
+		}
+		selector = listener.AllowedRoutes.Namespaces.Selector
+	}
+
+	switch from {
+	case gwv1.NamespacesFromAll:
+		return true, nil
+	case gwv1.NamespacesFromSame:
+		return route.Namespace() == gw.Namespace, nil
+	case gwv1.NamespacesFromSelector:
+		if selector == nil {
+			return false, nil
+		}
+		labelSelector, err := metav1.LabelSelectorAsSelector(selector)
+		if err != nil {
+			return false, fmt.Errorf("invalid namespace selector on listener %s of gateway %s-%s: %w",
+				listener.Name, gw.Name, gw.Namespace, err)
+		}
+		ns := &corev1.Namespace{}
+		if err := k8sClient.Get(ctx, types.NamespacedName{Name: route.Namespace()}, ns); err != nil {
+			return false, fmt.Errorf("failed to get namespace %s: %w", route.Namespace(), err)
+		}
+		return labelSelector.Matches(labels.Set(ns.Labels)), nil
+	default:
+		return false, nil
+	}
+}
+
+// checks listener allowedRoutes, both namespaces and kinds, permit the route to attach
+func listenerAllowsRoute(ctx context.Context, k8sClient client.Client,
+	gw *gwv1beta1.Gateway, listener gwv1beta1.Listener, route core.Route) (bool, error) {
+	if !listenerAllowsRouteKind(listener, route) {
+		return false, nil
+	}
+	return listenerAllowsRouteNamespace(ctx, k8sClient, gw, listener, route)
+}
+
+// checks at least one of the route hostnames intersects with the listener hostname.
+// A listener without hostname, or a route without hostnames, matches any hostname.
+func listenerHostnameMatchesRoute(listener gwv1beta1.Listener, route core.Route) bool {
+	if listener.Hostname == nil || *listener.Hostname == "" || len(route.Spec().Hostnames()) == 0 {
+		return true
+	}
+	for _, hostname := range route.Spec().Hostnames() {
+		if hostnamesIntersect(string(*listener.Hostname), string(hostname)) {
+			return true
+		}
+	}
+	return false
+}
+
+// Two hostnames intersect when they are equal, or when one is a wildcard (*.example.com)
+// and the other one has at least one more label under the wildcard suffix.
+func hostnamesIntersect(a, b string) bool {
+	a = strings.ToLower(a)
+	b = strings.ToLower(b)
+	if a == b {
+		return true
+	}
+	if strings.HasPrefix(a, "*.") && wildcardMatches(a, b) {
+		return true
+	}
+	if strings.HasPrefix(b, "*.") && wildcardMatches(b, a) {
+		return true
+	}
+	return false
+}
+
+func wildcardMatches(wildcard, hostname string) bool {
+	suffix := strings.TrimPrefix(wildcard, "*")
+	if strings.HasPrefix(hostname, "*.") {
+		// both are wildcards, the more specific one must be under the other one
+		return strings.HasSuffix(strings.TrimPrefix(hostname, "*"), suffix)
+	}
+	return strings.HasSuffix(hostname, suffix) && len(hostname) > len(suffix)
+}
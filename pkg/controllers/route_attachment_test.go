@@ -0,0 +1,146 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gwv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	gwv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/aws/aws-application-networking-k8s/pkg/model/core"
+)
+
+func Test_HostnamesIntersect(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{"foo.example.com", "foo.example.com", true},
+		{"foo.example.com", "FOO.example.com", true},
+		{"foo.example.com", "bar.example.com", false},
+		{"*.example.com", "foo.example.com", true},
+		{"foo.example.com", "*.example.com", true},
+		{"*.example.com", "a.b.example.com", true},
+		{"*.example.com", "example.com", false},
+		{"*.example.com", "foo.example.org", false},
+		{"*.example.com", "*.foo.example.com", true},
+		{"*.foo.example.com", "*.example.com", true},
+		{"*.foo.example.com", "*.bar.example.com", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.a+" "+tt.b, func(t *testing.T) {
+			assert.Equal(t, tt.want, hostnamesIntersect(tt.a, tt.b))
+		})
+	}
+}
+
+func Test_ListenerHostnameMatchesRoute(t *testing.T) {
+	route := core.NewHTTPRoute(gwv1beta1.HTTPRoute{
+		Spec: gwv1beta1.HTTPRouteSpec{
+			Hostnames: []gwv1beta1.Hostname{"foo.example.com", "bar.example.org"},
+		},
+	})
+	noHostnameRoute := core.NewHTTPRoute(gwv1beta1.HTTPRoute{})
+
+	tests := []struct {
+		name     string
+		hostname *gwv1beta1.Hostname
+		route    core.Route
+		want     bool
+	}{
+		{"listener without hostname", nil, route, true},
+		{"route without hostnames", (*gwv1beta1.Hostname)(aws.String("foo.example.com")), noHostnameRoute, true},
+		{"second hostname matches", (*gwv1beta1.Hostname)(aws.String("*.example.org")), route, true},
+		{"no hostname matches", (*gwv1beta1.Hostname)(aws.String("*.example.net")), route, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			listener := gwv1beta1.Listener{Name: "http", Protocol: gwv1.HTTPProtocolType, Port: 80, Hostname: tt.hostname}
+			assert.Equal(t, tt.want, listenerHostnameMatchesRoute(listener, tt.route))
+		})
+	}
+}
+
+func Test_ListenerAllowsRoute(t *testing.T) {
+	ctx := context.TODO()
+	k8sScheme := runtime.NewScheme()
+	clientgoscheme.AddToScheme(k8sScheme)
+	k8sClient := testclient.NewClientBuilder().WithScheme(k8sScheme).Build()
+	k8sClient.Create(ctx, &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{Name: "team-a", Labels: map[string]string{"shared-gateway": "true"}},
+	})
+	k8sClient.Create(ctx, &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{Name: "team-b"},
+	})
+
+	gw := &gwv1beta1.Gateway{ObjectMeta: metav1.ObjectMeta{Name: "gw", Namespace: "infra"}}
+	httpRoute := func(ns string) core.Route {
+		return core.NewHTTPRoute(gwv1beta1.HTTPRoute{ObjectMeta: metav1.ObjectMeta{Name: "route", Namespace: ns}})
+	}
+	grpcRoute := core.NewGRPCRoute(gwv1alpha2.GRPCRoute{ObjectMeta: metav1.ObjectMeta{Name: "route", Namespace: "infra"}})
+	tlsRoute := core.NewTLSRoute(gwv1alpha2.TLSRoute{ObjectMeta: metav1.ObjectMeta{Name: "route", Namespace: "infra"}})
+	from := func(f gwv1.FromNamespaces) *gwv1beta1.AllowedRoutes {
+		return &gwv1beta1.AllowedRoutes{Namespaces: &gwv1beta1.RouteNamespaces{From: &f}}
+	}
+	selector := from(gwv1.NamespacesFromSelector)
+	selector.Namespaces.Selector = &metav1.LabelSelector{MatchLabels: map[string]string{"shared-gateway": "true"}}
+
+	tests := []struct {
+		name          string
+		protocol      gwv1.ProtocolType
+		allowedRoutes *gwv1beta1.AllowedRoutes
+		route         core.Route
+		want          bool
+	}{
+		{"defaults to same namespace", gwv1.HTTPProtocolType, nil, httpRoute("infra"), true},
+		{"other namespace not allowed by default", gwv1.HTTPProtocolType, nil, httpRoute("team-a"), false},
+		{"all namespaces", gwv1.HTTPProtocolType, from(gwv1.NamespacesFromAll), httpRoute("team-b"), true},
+		{"selector matches namespace labels", gwv1.HTTPProtocolType, selector, httpRoute("team-a"), true},
+		{"selector does not match namespace labels", gwv1.HTTPProtocolType, selector, httpRoute("team-b"), false},
+		{"selector without selector", gwv1.HTTPProtocolType, from(gwv1.NamespacesFromSelector), httpRoute("team-a"), false},
+		{"grpc not allowed on http by default", gwv1.HTTPProtocolType, nil, grpcRoute, false},
+		{"grpc allowed on https by default", gwv1.HTTPSProtocolType, nil, grpcRoute, true},
+		{"tls allowed on tls by default", gwv1.TLSProtocolType, nil, tlsRoute, true},
+		{"kind not in allowed kinds", gwv1.HTTPSProtocolType, &gwv1beta1.AllowedRoutes{
+			Kinds: []gwv1beta1.RouteGroupKind{{Kind: "HTTPRoute"}},
+		}, grpcRoute, false},
+		{"group not matching", gwv1.HTTPProtocolType, &gwv1beta1.AllowedRoutes{
+			Kinds: []gwv1beta1.RouteGroupKind{{Group: (*gwv1beta1.Group)(aws.String("example.com")), Kind: "HTTPRoute"}},
+		}, httpRoute("infra"), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			listener := gwv1beta1.Listener{Name: "l", Protocol: tt.protocol, Port: 80, AllowedRoutes: tt.allowedRoutes}
+			allowed, err := listenerAllowsRoute(ctx, k8sClient, gw, listener, tt.route)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, allowed)
+		})
+	}
+}
+
+func Test_ListenerRouteGroupKindSupported(t *testing.T) {
+	https := gwv1beta1.Listener{Name: "https", Protocol: gwv1.HTTPSProtocolType, Port: 443}
+	valid, kinds := listenerRouteGroupKindSupported(https)
+	assert.True(t, valid)
+	assert.Equal(t, []gwv1beta1.RouteGroupKind{{Kind: "GRPCRoute"}, {Kind: "HTTPRoute"}}, kinds)
+
+	tls := gwv1beta1.Listener{Name: "tls", Protocol: gwv1.TLSProtocolType, Port: 443,
+		AllowedRoutes: &gwv1beta1.AllowedRoutes{Kinds: []gwv1beta1.RouteGroupKind{{Kind: "TLSRoute"}}}}
+	valid, kinds = listenerRouteGroupKindSupported(tls)
+	assert.True(t, valid)
+	assert.Equal(t, []gwv1beta1.RouteGroupKind{{Kind: "TLSRoute"}}, kinds)
+
+	http := gwv1beta1.Listener{Name: "http", Protocol: gwv1.HTTPProtocolType, Port: 80,
+		AllowedRoutes: &gwv1beta1.AllowedRoutes{Kinds: []gwv1beta1.RouteGroupKind{{Kind: "HTTPRoute"}, {Kind: "GRPCRoute"}}}}
+	valid, kinds = listenerRouteGroupKindSupported(http)
+	assert.False(t, valid)
+	assert.Equal(t, []gwv1beta1.RouteGroupKind{{Kind: "HTTPRoute"}}, kinds)
+}
@@ -19,6 +19,7 @@ package controllers
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
@@ -31,7 +32,10 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/external-dns/endpoint"
 	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
 	gwv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
@@ -45,6 +49,7 @@ import (
 	"github.com/aws/aws-application-networking-k8s/pkg/config"
 	"github.com/aws/aws-application-networking-k8s/pkg/controllers/eventhandlers"
 	"github.com/aws/aws-application-networking-k8s/pkg/deploy"
+	"github.com/aws/aws-application-networking-k8s/pkg/deploy/externaldns"
 	"github.com/aws/aws-application-networking-k8s/pkg/deploy/lattice"
 	"github.com/aws/aws-application-networking-k8s/pkg/gateway"
 	"github.com/aws/aws-application-networking-k8s/pkg/k8s"
@@ -70,12 +75,15 @@ type routeReconciler struct {
 	eventRecorder    record.EventRecorder
 	modelBuilder     gateway.LatticeServiceBuilder
 	stackDeployer    deploy.StackDeployer
+	stackPlanner     deploy.StackPlanner
 	stackMarshaller  deploy.StackMarshaller
 	cloud            aws.Cloud
 }
 
 const (
 	LatticeAssignedDomainName = "application-networking.k8s.aws/lattice-assigned-domain-name"
+	// changes deploying the route would make, set in dry-run mode instead of deploying
+	DryRunPlanAnnotation = "application-networking.k8s.aws/dry-run-plan"
 )
 
 func RegisterAllRouteControllers(
@@ -83,10 +91,14 @@ func RegisterAllRouteControllers(
 	cloud aws.Cloud,
 	finalizerManager k8s.FinalizerManager,
 	mgr ctrl.Manager,
+	driftDetector *DriftDetector,
 ) error {
 	mgrClient := mgr.GetClient()
 	gwEventHandler := eventhandlers.NewEnqueueRequestGatewayEvent(log, mgrClient)
 	svcEventHandler := eventhandlers.NewServiceEventHandler(log, mgrClient)
+	nsEventHandler := eventhandlers.NewNamespaceEventHandler(log, mgrClient)
+	refGrantEventHandler := eventhandlers.NewReferenceGrantEventHandler(log, mgrClient)
+	fixedResponseEventHandler := eventhandlers.NewFixedResponseEventHandler(log, mgrClient)
 
 	routeInfos := []struct {
 		routeType      core.RouteType
@@ -107,19 +119,47 @@ func RegisterAllRouteControllers(
 			finalizerManager: finalizerManager,
 			eventRecorder:    mgr.GetEventRecorderFor(string(routeInfo.routeType) + "route"),
 			modelBuilder:     gateway.NewLatticeServiceBuilder(log, mgrClient, brTgBuilder),
-			stackDeployer:    deploy.NewLatticeServiceStackDeploy(log, cloud, mgrClient),
+			stackPlanner:     deploy.NewLatticeServiceStackPlanner(log, cloud),
 			stackMarshaller:  deploy.NewDefaultStackMarshaller(),
 			cloud:            cloud,
 		}
 
+		// the stack deployer starts the target group garbage collector, which must not run in dry-run mode
+		if !config.DryRunMode {
+			reconciler.stackDeployer = deploy.NewLatticeServiceStackDeploy(log, cloud, mgrClient)
+		}
+
 		svcImportEventHandler := eventhandlers.NewServiceImportEventHandler(log, mgrClient)
+		// status and annotation updates of merged routes must not trigger the other routes
+		specChanged := builder.WithPredicates(predicate.GenerationChangedPredicate{})
 
 		builder := ctrl.NewControllerManagedBy(mgr).
-			For(routeInfo.gatewayApiType, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+			// annotations configure the route DNS records, changing them does not bump the generation
+			For(routeInfo.gatewayApiType, builder.WithPredicates(
+				predicate.Or(predicate.GenerationChangedPredicate{}, k8s.AnnotationsChangedPredicate(
+					externaldns.DnsRecordTtlAnnotation,
+					externaldns.DnsRecordTypeAnnotation,
+					externaldns.DnsRecordProviderSpecificAnnotation,
+					externaldns.DnsEndpointLabelsAnnotation,
+				)))).
 			Watches(&gwv1beta1.Gateway{}, gwEventHandler).
 			Watches(&corev1.Service{}, svcEventHandler.MapToRoute(routeInfo.routeType)).
 			Watches(&anv1alpha1.ServiceImport{}, svcImportEventHandler.MapToRoute(routeInfo.routeType)).
-			Watches(&discoveryv1.EndpointSlice{}, svcEventHandler.MapToRoute(routeInfo.routeType))
+			Watches(&discoveryv1.EndpointSlice{}, svcEventHandler.MapToRoute(routeInfo.routeType)).
+			Watches(&corev1.Namespace{}, nsEventHandler.MapToRoute(routeInfo.routeType),
+				builder.WithPredicates(predicate.LabelChangedPredicate{}))
+
+		if driftDetector != nil {
+			builder.WatchesRawSource(driftDetector.RouteRepairSource(routeInfo.routeType), &handler.EnqueueRequestForObject{})
+		}
+
+		if config.MergeRoutesByHostname && routeInfo.routeType != core.TlsRouteType {
+			// rules of merged routes conflict, and are removed, depending on the other routes of the service,
+			// which can be HTTPRoutes and GRPCRoutes
+			mapToMergedRoutes := handler.EnqueueRequestsFromMapFunc(reconciler.mapToMergedRoutes)
+			builder.Watches(&gwv1beta1.HTTPRoute{}, mapToMergedRoutes, specChanged).
+				Watches(&gwv1alpha2.GRPCRoute{}, mapToMergedRoutes, specChanged)
+		}
 
 		if ok, err := k8s.IsGVKSupported(mgr, anv1alpha1.GroupVersion.String(), anv1alpha1.TargetGroupPolicyKind); ok {
 			builder.Watches(&anv1alpha1.TargetGroupPolicy{}, svcEventHandler.MapToRoute(routeInfo.routeType))
@@ -130,6 +170,26 @@ func RegisterAllRouteControllers(
 			log.Infof("TargetGroupPolicy CRD is not installed, skipping watch")
 		}
 
+		if routeInfo.routeType == core.HttpRouteType {
+			if ok, err := k8s.IsGVKSupported(mgr, anv1alpha1.GroupVersion.String(), anv1alpha1.FixedResponseKind); ok {
+				builder.Watches(&anv1alpha1.FixedResponse{}, fixedResponseEventHandler.MapToRoute(routeInfo.routeType))
+			} else {
+				if err != nil {
+					return err
+				}
+				log.Infof("FixedResponse CRD is not installed, skipping watch")
+			}
+		}
+
+		if ok, err := k8s.IsGVKSupported(mgr, gwv1beta1.GroupVersion.String(), "ReferenceGrant"); ok {
+			builder.Watches(&gwv1beta1.ReferenceGrant{}, refGrantEventHandler.MapToRoute(routeInfo.routeType))
+		} else {
+			if err != nil {
+				return err
+			}
+			log.Infof("ReferenceGrant CRD is not installed, skipping watch")
+		}
+
 		if ok, err := k8s.IsGVKSupported(mgr, "externaldns.k8s.io/v1alpha1", "DNSEndpoint"); ok {
 			builder.Owns(&endpoint.DNSEndpoint{})
 		} else {
@@ -187,6 +247,12 @@ func (r *routeReconciler) reconcileDelete(ctx context.Context, req ctrl.Request,
 		return fmt.Errorf("failed to cleanup route %s, %s: %w", route.Name(), route.Namespace(), err)
 	}
 
+	if config.DryRunMode {
+		// nothing was cleaned up, keep the finalizer so the resources are not leaked
+		r.log.Infow("reconciled in dry-run mode, keeping finalizer", "name", req.Name)
+		return nil
+	}
+
 	if err := updateRouteListenerStatus(ctx, r.client, route); err != nil {
 		return err
 	}
@@ -208,24 +274,36 @@ func (r *routeReconciler) getRoute(ctx context.Context, req ctrl.Request) (core.
 	}
 }
 
+// updates the listener status of every parent gateway of the route, gateways that do not exist are skipped
 func updateRouteListenerStatus(ctx context.Context, k8sClient client.Client, route core.Route) error {
-	gw := &gwv1beta1.Gateway{}
+	updated := utils.NewSet[types.NamespacedName]()
+	for _, parentRef := range route.Spec().ParentRefs() {
+		gwNamespace := route.Namespace()
+		if parentRef.Namespace != nil {
+			gwNamespace = string(*parentRef.Namespace)
+		}
+		gwName := types.NamespacedName{
+			Namespace: gwNamespace,
+			Name:      string(parentRef.Name),
+		}
+		if updated.Contains(gwName) {
+			continue
+		}
+		updated.Put(gwName)
 
-	gwNamespace := route.Namespace()
-	if route.Spec().ParentRefs()[0].Namespace != nil {
-		gwNamespace = string(*route.Spec().ParentRefs()[0].Namespace)
-	}
-	gwName := types.NamespacedName{
-		Namespace: gwNamespace,
-		// TODO assume one parent for now and point to service network
-		Name: string(route.Spec().ParentRefs()[0].Name),
-	}
+		gw := &gwv1beta1.Gateway{}
+		if err := k8sClient.Get(ctx, gwName, gw); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return fmt.Errorf("update route listener: failed to get gw %s, err: %w", gwName, err)
+		}
 
-	if err := k8sClient.Get(ctx, gwName, gw); err != nil {
-		return fmt.Errorf("update route listener: gw not found, gw: %s, err: %w", gwName, err)
+		if err := UpdateGWListenerStatus(ctx, k8sClient, gw); err != nil {
+			return err
+		}
 	}
-
-	return UpdateGWListenerStatus(ctx, k8sClient, gw)
+	return nil
 }
 
 func (r *routeReconciler) isRouteRelevant(ctx context.Context, route core.Route) bool {
@@ -272,13 +350,20 @@ func (r *routeReconciler) isRouteRelevant(ctx context.Context, route core.Route)
 	return false
 }
 
+// Builds and deploys the route stack. When only some of the route rules are invalid, the remaining
+// rules are deployed and the *gateway.PartiallyInvalidRouteError is returned along with the stack.
 func (r *routeReconciler) buildAndDeployModel(
 	ctx context.Context,
 	route core.Route,
 ) (core.Stack, error) {
 	stack, err := r.modelBuilder.Build(ctx, route)
 
-	if err != nil {
+	var partialErr *gateway.PartiallyInvalidRouteError
+	if errors.As(err, &partialErr) {
+		r.eventRecorder.Event(route.K8sObject(), corev1.EventTypeWarning,
+			k8s.RouteEventReasonFailedBuildModel, partialErr.Message)
+		r.log.Infof("buildAndDeployModel, partially built model for %s: %s", route.Name(), partialErr.Message)
+	} else if err != nil {
 		r.eventRecorder.Event(route.K8sObject(), corev1.EventTypeWarning,
 			k8s.RouteEventReasonFailedBuildModel, fmt.Sprintf("Failed build model due to %s", err))
 		r.log.Infof("buildAndDeployModel, Failed build model for %s due to %s", route.Name(), err)
@@ -295,7 +380,11 @@ func (r *routeReconciler) buildAndDeployModel(
 
 	r.log.Debugf("stack: %s", json)
 
-	if err := r.stackDeployer.Deploy(ctx, stack); err != nil {
+	if config.DryRunMode {
+		if err := r.planModel(ctx, route, stack); err != nil {
+			return nil, err
+		}
+	} else if err := r.stackDeployer.Deploy(ctx, stack); err != nil {
 		if errors.As(err, &lattice.RetryErr) {
 			r.eventRecorder.Event(route.K8sObject(), corev1.EventTypeNormal,
 				k8s.RouteEventReasonRetryReconcile, "retry reconcile...")
@@ -306,7 +395,39 @@ func (r *routeReconciler) buildAndDeployModel(
 		return nil, err
 	}
 
-	return stack, err
+	if partialErr != nil {
+		return stack, partialErr
+	}
+	return stack, nil
+}
+
+// computes the changes deploying the stack would make, and reports them on the route
+func (r *routeReconciler) planModel(ctx context.Context, route core.Route, stack core.Stack) error {
+	changes, err := r.stackPlanner.Plan(ctx, stack)
+	if err != nil {
+		r.eventRecorder.Event(route.K8sObject(), corev1.EventTypeWarning,
+			k8s.RouteEventReasonFailedPlanModel, fmt.Sprintf("Failed plan model due to %s", err))
+		return err
+	}
+
+	plan := changes.String()
+	r.log.Infof("Dry-run plan for route %s-%s: %s", route.Name(), route.Namespace(), plan)
+	r.eventRecorder.Event(route.K8sObject(), corev1.EventTypeNormal,
+		k8s.RouteEventReasonPlanSucceed, fmt.Sprintf("Dry-run plan: %s", changes.Summary()))
+
+	if route.K8sObject().GetAnnotations()[DryRunPlanAnnotation] == plan {
+		return nil
+	}
+	routeOld := route.DeepCopy()
+	if len(route.K8sObject().GetAnnotations()) == 0 {
+		route.K8sObject().SetAnnotations(make(map[string]string))
+	}
+	route.K8sObject().GetAnnotations()[DryRunPlanAnnotation] = plan
+	// the route may be gone already when planning its deletion
+	if err := r.client.Patch(ctx, route.K8sObject(), client.MergeFrom(routeOld.K8sObject())); client.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("failed to update route dry-run plan due to err %w", err)
+	}
+	return nil
 }
 
 func (r *routeReconciler) reconcileUpsert(ctx context.Context, req ctrl.Request, route core.Route) error {
@@ -314,9 +435,8 @@ func (r *routeReconciler) reconcileUpsert(ctx context.Context, req ctrl.Request,
 	r.eventRecorder.Event(route.K8sObject(), corev1.EventTypeNormal,
 		k8s.RouteEventReasonReconcile, "Adding/Updating Reconcile")
 
-	if err := r.finalizerManager.AddFinalizers(ctx, route.K8sObject(), routeTypeToFinalizer[r.routeType]); err != nil {
-		r.eventRecorder.Event(route.K8sObject(), corev1.EventTypeWarning, k8s.RouteEventReasonFailedAddFinalizer, fmt.Sprintf("Failed add finalizer due to %s", err))
-	}
+	// the finalizer is added once the route is first deployed
+	deployed := controllerutil.ContainsFinalizer(route.K8sObject(), routeTypeToFinalizer[r.routeType])
 
 	if err := r.validateRoute(ctx, route); err != nil {
 		// TODO: we suppose to stop reconciliation here, but that will create problem when
@@ -326,6 +446,20 @@ func (r *routeReconciler) reconcileUpsert(ctx context.Context, req ctrl.Request,
 		r.log.Infof("route: %s: %s", route.Name(), err)
 	}
 
+	if err := updateRouteListenerStatus(ctx, r.client, route); err != nil {
+		r.log.Infof("route: %s: failed to update gateway listener status: %s", route.Name(), err)
+	}
+
+	if core.IsRouteDetachedFromAllParents(route) {
+		return r.detachRoute(ctx, route, deployed)
+	}
+
+	if config.DryRunMode {
+		// no resources are deployed, there is nothing to clean up on deletion
+	} else if err := r.finalizerManager.AddFinalizers(ctx, route.K8sObject(), routeTypeToFinalizer[r.routeType]); err != nil {
+		r.eventRecorder.Event(route.K8sObject(), corev1.EventTypeWarning, k8s.RouteEventReasonFailedAddFinalizer, fmt.Sprintf("Failed add finalizer due to %s", err))
+	}
+
 	backendRefIPFamiliesErr := r.validateBackendRefsIpFamilies(ctx, route)
 
 	if backendRefIPFamiliesErr != nil {
@@ -348,7 +482,38 @@ func (r *routeReconciler) reconcileUpsert(ctx context.Context, req ctrl.Request,
 		return backendRefIPFamiliesErr
 	}
 
-	if _, err := r.buildAndDeployModel(ctx, route); err != nil {
+	if deployed && !config.DryRunMode {
+		if err := r.leavePreviousService(ctx, route); err != nil {
+			return err
+		}
+	}
+
+	var partialErr *gateway.PartiallyInvalidRouteError
+	if _, err := r.buildAndDeployModel(ctx, route); errors.As(err, &partialErr) {
+		// valid rules are deployed, only report the dropped ones
+		r.setParentsCondition(route, gwv1.RouteConditionPartiallyInvalid, gwv1.RouteReasonUnsupportedValue, partialErr.Message)
+		if err = r.client.Status().Update(ctx, route.K8sObject()); err != nil {
+			return fmt.Errorf("failed to update route status for dropped rules due to err %w", err)
+		}
+	} else if err != nil {
+		var unsupportedErr *gateway.UnsupportedRouteError
+		if errors.As(err, &unsupportedErr) {
+			// Stop reconciliation of this route until its spec changes, retrying cannot succeed
+			r.setParentsCondition(route, gwv1.RouteConditionAccepted, unsupportedErr.Reason, unsupportedErr.Message)
+			if err = r.client.Status().Update(ctx, route.K8sObject()); err != nil {
+				return fmt.Errorf("failed to update route status for unsupported value due to err %w", err)
+			}
+			return nil
+		}
+		var svcImportErr *lattice.ServiceImportError
+		if errors.As(err, &svcImportErr) {
+			// keep retrying, exports of other clusters are not watched
+			r.setParentsCondition(route, gwv1.RouteConditionResolvedRefs, svcImportErr.Reason, svcImportErr.Message)
+			if updateErr := r.client.Status().Update(ctx, route.K8sObject()); updateErr != nil {
+				return fmt.Errorf("failed to update route status for service import due to err %w", updateErr)
+			}
+			return err
+		}
 		if services.IsConflictError(err) {
 			// Stop reconciliation of this route if the route cannot be owned / has conflict
 			route.Status().UpdateParentRefs(route.Spec().ParentRefs()[0], config.LatticeGatewayControllerName)
@@ -367,10 +532,22 @@ func (r *routeReconciler) reconcileUpsert(ctx context.Context, req ctrl.Request,
 		return err
 	}
 
+	if config.DryRunMode {
+		r.log.Infow("reconciled in dry-run mode", "name", req.Name)
+		return nil
+	}
+
 	r.eventRecorder.Event(route.K8sObject(), corev1.EventTypeNormal,
 		k8s.RouteEventReasonDeploySucceed, "Adding/Updating reconcile Done!")
 
+	if err := r.updateMergedServiceAnnotation(ctx, route); err != nil {
+		return err
+	}
+
 	svcName := k8sutils.LatticeServiceName(route.Name(), route.Namespace())
+	if key, ok := gateway.RouteMergeKeyOf(route); ok {
+		svcName = key.LatticeServiceName()
+	}
 	svc, err := r.cloud.Lattice().FindService(ctx, svcName)
 	if err != nil && !services.IsNotFoundError(err) {
 		return err
@@ -389,6 +566,102 @@ func (r *routeReconciler) reconcileUpsert(ctx context.Context, req ctrl.Request,
 	return nil
 }
 
+// A route none of its parents accept is not deployed. The lattice resources of a route deployed before are
+// removed and so is its finalizer, the route is deployed again once a parent listener allows it.
+func (r *routeReconciler) detachRoute(ctx context.Context, route core.Route, deployed bool) error {
+	r.eventRecorder.Event(route.K8sObject(), corev1.EventTypeWarning,
+		k8s.RouteEventReasonNotAllowedByListeners, "Route is not allowed by any parent listener")
+	if !deployed {
+		r.log.Infof("Route %s-%s is not allowed by any parent, not deploying it", route.Name(), route.Namespace())
+		return nil
+	}
+	r.log.Infof("Route %s-%s is not allowed by any parent, removing its resources", route.Name(), route.Namespace())
+
+	// build the model as if the route was deleted, the route itself is kept
+	detached := route.DeepCopy()
+	now := metav1.Now()
+	detached.K8sObject().SetDeletionTimestamp(&now)
+	if _, err := r.buildAndDeployModel(ctx, detached); err != nil {
+		return fmt.Errorf("failed to detach route %s, %s: %w", route.Name(), route.Namespace(), err)
+	}
+
+	if config.DryRunMode {
+		// nothing was cleaned up, keep the finalizer so the resources are not leaked
+		return nil
+	}
+	return r.finalizerManager.RemoveFinalizers(ctx, route.K8sObject(), routeTypeToFinalizer[r.routeType])
+}
+
+// Removes the route from the lattice service it was last deployed to when it now belongs to another one,
+// because its hostname or gateway changed, or merge mode was enabled or disabled since.
+func (r *routeReconciler) leavePreviousService(ctx context.Context, route core.Route) error {
+	previous, _ := gateway.DeployedRouteMergeKey(route)
+	current, _ := gateway.RouteMergeKeyOf(route)
+	if previous == current {
+		return nil
+	}
+	r.log.Infof("Route %s-%s moved from service %q to %q, removing it from the previous one",
+		route.Name(), route.Namespace(), previous, current)
+
+	// the deleted route is built from the service recorded by its annotation
+	leaving := route.DeepCopy()
+	now := metav1.Now()
+	leaving.K8sObject().SetDeletionTimestamp(&now)
+	if _, err := r.buildAndDeployModel(ctx, leaving); err != nil {
+		return fmt.Errorf("failed to remove route %s, %s from its previous service: %w", route.Name(), route.Namespace(), err)
+	}
+	return nil
+}
+
+// records the merged service the route is deployed to, so that it can be removed from it later on
+func (r *routeReconciler) updateMergedServiceAnnotation(ctx context.Context, route core.Route) error {
+	key, merged := gateway.RouteMergeKeyOf(route)
+	value, found := route.K8sObject().GetAnnotations()[gateway.MergedServiceAnnotation]
+	if merged == found && value == key.String() || !merged && !found {
+		return nil
+	}
+
+	routeOld := route.DeepCopy()
+	if merged {
+		if len(route.K8sObject().GetAnnotations()) == 0 {
+			route.K8sObject().SetAnnotations(make(map[string]string))
+		}
+		route.K8sObject().GetAnnotations()[gateway.MergedServiceAnnotation] = key.String()
+	} else {
+		delete(route.K8sObject().GetAnnotations(), gateway.MergedServiceAnnotation)
+	}
+	if err := r.client.Patch(ctx, route.K8sObject(), client.MergeFrom(routeOld.K8sObject())); err != nil {
+		return fmt.Errorf("failed to update route merged service due to err %w", err)
+	}
+	return nil
+}
+
+// enqueues the other routes of the reconciler type merged into the service of the route
+func (r *routeReconciler) mapToMergedRoutes(ctx context.Context, obj client.Object) []reconcile.Request {
+	route, err := core.NewRoute(obj)
+	if err != nil {
+		return nil
+	}
+	key, ok := gateway.RouteMergeKeyOf(route)
+	if !ok {
+		return nil
+	}
+	routes, err := gateway.ListMergedRoutes(ctx, r.client, key)
+	if err != nil {
+		r.log.Errorf("Failed to list routes merged into %s, %s", key, err)
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, merged := range routes {
+		if mergedType, _ := gateway.MergeableRouteType(merged); mergedType != r.routeType || gateway.IsSameRoute(merged, route) {
+			continue
+		}
+		requests = append(requests, reconcile.Request{NamespacedName: k8s.NamespacedName(merged.K8sObject())})
+	}
+	return requests
+}
+
 func (r *routeReconciler) updateRouteAnnotation(ctx context.Context, dns string, route core.Route) error {
 	r.log.Debugf("Updating route %s-%s with DNS %s", route.Name(), route.Namespace(), dns)
 	routeOld := route.DeepCopy()
@@ -445,8 +718,8 @@ var (
 //	https://gateway-api.sigs.k8s.io/reference/spec/#gateway.networking.k8s.io%2fv1.RouteConditionType
 //
 // There are 3 condition types: Accepted, PartiallyInvalid, ResolvedRefs.
-// We dont support PartiallyInvalid for now and reject entire route if there is at least one invalid field.
 // Accepted type is related to parentRefs, and ResolvedRefs to backendRefs. These 2 are validated independently.
+// PartiallyInvalid is set after the model build, when invalid rules were dropped and the remaining ones deployed.
 func (r *routeReconciler) validateRoute(ctx context.Context, route core.Route) error {
 	parentRefsAccepted, err := r.validateRouteParentRefs(ctx, route)
 	if err != nil {
@@ -494,16 +767,8 @@ func (r *routeReconciler) hasNotAcceptedCondition(route core.Route) bool {
 
 // find Gateway by Route and parentRef, returns nil if not found
 func (r *routeReconciler) findRouteParentGw(ctx context.Context, route core.Route, parentRef gwv1beta1.ParentReference) (*gwv1beta1.Gateway, error) {
-	ns := route.Namespace()
-	if parentRef.Namespace != nil && *parentRef.Namespace != "" {
-		ns = string(*parentRef.Namespace)
-	}
-	gwName := types.NamespacedName{
-		Namespace: ns,
-		Name:      string(parentRef.Name),
-	}
 	gw := &gwv1beta1.Gateway{}
-	err := r.client.Get(ctx, gwName, gw)
+	err := r.client.Get(ctx, gateway.ParentRefGatewayName(route, parentRef), gw)
 	if err != nil {
 		return nil, client.IgnoreNotFound(err)
 	}
@@ -517,14 +782,32 @@ func (r *routeReconciler) findRouteParentGw(ctx context.Context, route core.Rout
 //
 // If parent GW exists will check:
 // - NoMatchingParent: parentRef sectionName and port matches Listener name and port
-// - TODO: NoMatchingListenerHostname: listener hostname matches one of route hostnames
-// - TODO: NotAllowedByListeners: listener allowedRoutes contains route GroupKind
+// - NotAllowedByListeners: listener allowedRoutes contains route GroupKind and namespace
+// - NoMatchingListenerHostname: listener hostname matches one of route hostnames
+// - IncompatibleListeners: listener port, protocol and certificate are compatible with the ones of the
+// preceding accepted parentRefs, the route is deployed to a single lattice service for all of them
 func (r *routeReconciler) validateRouteParentRefs(ctx context.Context, route core.Route) ([]gwv1beta1.RouteParentStatus, error) {
 	if len(route.Spec().ParentRefs()) == 0 {
 		return nil, ErrParentRefsNotFound
 	}
 
+	// the route is still accepted when some of its hostnames cannot be served, they are listed in the message
+	var unservableMsgs []string
+	domainNames, wildcards := gateway.SplitRouteHostnames(route)
+	if len(wildcards) > 0 {
+		unservableMsgs = append(unservableMsgs, fmt.Sprintf(
+			"hostnames %s are not served, VPC Lattice does not support wildcard domain names",
+			strings.Join(wildcards, ", ")))
+	}
+	if len(domainNames) > 1 {
+		unservableMsgs = append(unservableMsgs, fmt.Sprintf(
+			"hostnames %s are not served, a VPC Lattice service only has the custom domain name %s",
+			strings.Join(domainNames[1:], ", "), domainNames[0]))
+	}
+	unservableMsg := strings.Join(unservableMsgs, "; ")
+
 	parentStatuses := []gwv1beta1.RouteParentStatus{}
+	var acceptedListeners []gateway.ParentListenerConfig
 	for _, parentRef := range route.Spec().ParentRefs() {
 		gw, err := r.findRouteParentGw(ctx, route, parentRef)
 		if err != nil {
@@ -535,6 +818,8 @@ func (r *routeReconciler) validateRouteParentRefs(ctx context.Context, route cor
 		}
 
 		noMatchingParent := true
+		notAllowedByListeners := true
+		noMatchingListenerHostname := true
 		for _, listener := range gw.Spec.Listeners {
 			if parentRef.Port != nil && *parentRef.Port != listener.Port {
 				continue
@@ -543,6 +828,19 @@ func (r *routeReconciler) validateRouteParentRefs(ctx context.Context, route cor
 				continue
 			}
 			noMatchingParent = false
+
+			allowed, err := listenerAllowsRoute(ctx, r.client, gw, listener, route)
+			if err != nil {
+				return nil, err
+			}
+			if !allowed {
+				continue
+			}
+			notAllowedByListeners = false
+
+			if listenerHostnameMatchesRoute(listener, route) {
+				noMatchingListenerHostname = false
+			}
 		}
 
 		parentStatus := gwv1beta1.RouteParentStatus{
@@ -551,12 +849,32 @@ func (r *routeReconciler) validateRouteParentRefs(ctx context.Context, route cor
 			Conditions:     []metav1.Condition{},
 		}
 
+		var incompatibleErr error
+		if !noMatchingParent && !notAllowedByListeners && !noMatchingListenerHostname {
+			listenerConfig, err := gateway.NewParentListenerConfig(gw, parentRef)
+			if err != nil {
+				return nil, err
+			}
+			if incompatibleErr = listenerConfig.IncompatibleWith(acceptedListeners...); incompatibleErr == nil {
+				acceptedListeners = append(acceptedListeners, listenerConfig)
+			}
+		}
+
 		var cnd metav1.Condition
 		switch {
 		case noMatchingParent:
 			cnd = r.newCondition(route, gwv1beta1.RouteConditionAccepted, gwv1.RouteReasonNoMatchingParent, "")
+		case notAllowedByListeners:
+			cnd = r.newCondition(route, gwv1beta1.RouteConditionAccepted, gwv1.RouteReasonNotAllowedByListeners,
+				fmt.Sprintf("%s %s-%s is not allowed by the listeners of gateway %s-%s",
+					route.GroupKind().Kind, route.Name(), route.Namespace(), gw.Name, gw.Namespace))
+		case noMatchingListenerHostname:
+			cnd = r.newCondition(route, gwv1beta1.RouteConditionAccepted, gwv1.RouteReasonNoMatchingListenerHostname,
+				fmt.Sprintf("none of the route hostnames match a listener hostname of gateway %s-%s", gw.Name, gw.Namespace))
+		case incompatibleErr != nil:
+			cnd = r.newCondition(route, gwv1beta1.RouteConditionAccepted, core.RouteReasonIncompatibleListeners, incompatibleErr.Error())
 		default:
-			cnd = r.newCondition(route, gwv1beta1.RouteConditionAccepted, gwv1beta1.RouteReasonAccepted, "")
+			cnd = r.newCondition(route, gwv1beta1.RouteConditionAccepted, gwv1beta1.RouteReasonAccepted, unservableMsg)
 		}
 		meta.SetStatusCondition(&parentStatus.Conditions, cnd)
 		parentStatuses = append(parentStatuses, parentStatus)
@@ -568,24 +886,53 @@ func (r *routeReconciler) validateRouteParentRefs(ctx context.Context, route cor
 // set of valid Kinds for Route Backend References
 var validBackendKinds = utils.NewSet("Service", "ServiceImport")
 
-// validate route's backed references, will return non-accepted
-// condition if at least one backendRef not in a valid state
+// validate route's backed references per rule, will return non-accepted
+// condition listing every rule with a backendRef not in a valid state
 func (r *routeReconciler) validateBackedRefs(ctx context.Context, route core.Route) (metav1.Condition, error) {
 	var empty metav1.Condition
-	for _, rule := range route.Spec().Rules() {
+	var reason gwv1beta1.RouteConditionReason
+	var msgs []string
+	for i, rule := range route.Spec().Rules() {
 		for _, ref := range rule.BackendRefs() {
 			kind := "Service"
 			if ref.Kind() != nil {
 				kind = string(*ref.Kind())
 			}
 			if !validBackendKinds.Contains(kind) {
-				return r.newCondition(route, gwv1beta1.RouteConditionResolvedRefs, gwv1beta1.RouteReasonInvalidKind, kind), nil
+				if reason == "" {
+					reason = gwv1beta1.RouteReasonInvalidKind
+				}
+				msgs = append(msgs, fmt.Sprintf("rule %d: backendRef %s has invalid kind %s", i, ref.Name(), kind))
+				continue
+			}
+			// ServiceImports of either group resolve to the ones of the configured group
+			if kind == "ServiceImport" && ref.Group() != nil && !anv1alpha1.IsMulticlusterGroup(string(*ref.Group())) {
+				if reason == "" {
+					reason = gwv1beta1.RouteReasonInvalidKind
+				}
+				msgs = append(msgs, fmt.Sprintf("rule %d: backendRef %s has invalid group %s for kind %s",
+					i, ref.Name(), *ref.Group(), kind))
+				continue
 			}
 
 			namespace := route.Namespace()
 			if ref.Namespace() != nil {
 				namespace = string(*ref.Namespace())
 			}
+
+			permitted, err := gateway.IsBackendRefPermitted(ctx, r.client, route, ref)
+			if err != nil {
+				return empty, err
+			}
+			if !permitted {
+				if reason == "" {
+					reason = gwv1beta1.RouteReasonRefNotPermitted
+				}
+				msgs = append(msgs, fmt.Sprintf("rule %d: backendRef %s in namespace %s is not permitted by any ReferenceGrant",
+					i, ref.Name(), namespace))
+				continue
+			}
+
 			objKey := types.NamespacedName{
 				Namespace: namespace,
 				Name:      string(ref.Name()),
@@ -600,18 +947,48 @@ func (r *routeReconciler) validateBackedRefs(ctx context.Context, route core.Rou
 			default:
 				return empty, fmt.Errorf("invalid backed end ref kind, must be validated before, kind=%s", kind)
 			}
-			err := r.client.Get(ctx, objKey, obj)
+			err = r.client.Get(ctx, objKey, obj)
 			if err != nil {
 				if apierrors.IsNotFound(err) {
-					msg := fmt.Sprintf("backendRef name: %s", ref.Name())
-					return r.newCondition(route, gwv1beta1.RouteConditionResolvedRefs, gwv1beta1.RouteReasonBackendNotFound, msg), nil
+					if reason == "" {
+						reason = gwv1beta1.RouteReasonBackendNotFound
+					}
+					msgs = append(msgs, fmt.Sprintf("rule %d: backendRef name: %s", i, ref.Name()))
 				}
+				continue
+			}
+			if kind == "ServiceImport" && gateway.IsOtherAccountServiceImport(obj.GetAnnotations()) {
+				if reason == "" {
+					reason = gwv1beta1.RouteReasonRefNotPermitted
+				}
+				msgs = append(msgs, fmt.Sprintf("rule %d: backendRef %s imports from account %s, VPC Lattice cannot forward to target groups of another account",
+					i, ref.Name(), obj.GetAnnotations()[gateway.ServiceImportAccountAnnotation]))
 			}
 		}
 	}
+	if len(msgs) > 0 {
+		return r.newCondition(route, gwv1beta1.RouteConditionResolvedRefs, reason, strings.Join(msgs, "; ")), nil
+	}
 	return r.newCondition(route, gwv1beta1.RouteConditionResolvedRefs, gwv1beta1.RouteReasonResolvedRefs, ""), nil
 }
 
+// sets the condition on every parent status of the route
+func (r *routeReconciler) setParentsCondition(route core.Route, t gwv1.RouteConditionType, reason gwv1.RouteConditionReason, msg string) {
+	if len(route.Status().Parents()) == 0 {
+		route.Status().UpdateParentRefs(route.Spec().ParentRefs()[0], config.LatticeGatewayControllerName)
+	}
+	cnd := r.newCondition(route, t, reason, msg)
+	if t == gwv1.RouteConditionPartiallyInvalid {
+		// PartiallyInvalid is only ever set when true
+		cnd.Status = metav1.ConditionTrue
+	}
+	parents := route.Status().Parents()
+	for i := range parents {
+		meta.SetStatusCondition(&parents[i].Conditions, cnd)
+	}
+	route.Status().SetParents(parents)
+}
+
 func (r *routeReconciler) newCondition(route core.Route, t gwv1beta1.RouteConditionType, reason gwv1beta1.RouteConditionReason, msg string) metav1.Condition {
 	status := metav1.ConditionTrue
 	if reason != gwv1beta1.RouteReasonAccepted && reason != gwv1beta1.RouteReasonResolvedRefs {
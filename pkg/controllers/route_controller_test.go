@@ -2,12 +2,14 @@ package controllers
 
 import (
 	"context"
+	"fmt"
 	mock_client "github.com/aws/aws-application-networking-k8s/mocks/controller-runtime/client"
 	anv1alpha1 "github.com/aws/aws-application-networking-k8s/pkg/apis/applicationnetworking/v1alpha1"
 	aws2 "github.com/aws/aws-application-networking-k8s/pkg/aws"
 	mocks "github.com/aws/aws-application-networking-k8s/pkg/aws/services"
 	"github.com/aws/aws-application-networking-k8s/pkg/config"
 	"github.com/aws/aws-application-networking-k8s/pkg/deploy"
+	"github.com/aws/aws-application-networking-k8s/pkg/deploy/lattice"
 	"github.com/aws/aws-application-networking-k8s/pkg/gateway"
 	"github.com/aws/aws-application-networking-k8s/pkg/k8s"
 	"github.com/aws/aws-application-networking-k8s/pkg/model/core"
@@ -18,14 +20,17 @@ import (
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
 	discoveryv1 "k8s.io/api/discovery/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/external-dns/endpoint"
+	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
 	gwv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 	"testing"
 )
@@ -123,6 +128,7 @@ func TestRouteReconciler_ReconcileCreates(t *testing.T) {
 
 	kind := gwv1beta1.Kind("Service")
 	port := gwv1beta1.PortNumber(80)
+	pathPrefix := gwv1.PathMatchPathPrefix
 	route := gwv1beta1.HTTPRoute{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      "my-route",
@@ -138,6 +144,14 @@ func TestRouteReconciler_ReconcileCreates(t *testing.T) {
 			},
 			Rules: []gwv1beta1.HTTPRouteRule{
 				{
+					Matches: []gwv1beta1.HTTPRouteMatch{
+						{
+							Path: &gwv1beta1.HTTPPathMatch{
+								Type:  &pathPrefix,
+								Value: aws.String("/api"),
+							},
+						},
+					},
 					BackendRefs: []gwv1beta1.HTTPBackendRef{
 						{
 							BackendRef: gwv1beta1.BackendRef{
@@ -306,6 +320,519 @@ func TestRouteReconciler_ReconcileCreates(t *testing.T) {
 
 }
 
+func TestRouteReconciler_ReconcileUnsupportedRoute(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+	ctx := context.TODO()
+
+	k8sClient := newValidationTestClient(ctx)
+	route := newValidationTestRoute()
+	k8sClient.Create(ctx, route.DeepCopy())
+
+	mockModelBuilder := gateway.NewMockLatticeServiceBuilder(c)
+	mockModelBuilder.EXPECT().Build(gomock.Any(), gomock.Any()).Return(nil, &gateway.UnsupportedRouteError{
+		Reason:  gwv1.RouteReasonUnsupportedValue,
+		Message: "query parameter matches are not supported",
+	})
+
+	rc := newValidationTestReconciler(c, k8sClient, mockModelBuilder, nil)
+
+	routeName := k8s.NamespacedName(route)
+	result, err := rc.Reconcile(ctx, reconcile.Request{NamespacedName: routeName})
+	assert.Nil(t, err)
+	assert.False(t, result.Requeue)
+
+	updated := &gwv1beta1.HTTPRoute{}
+	assert.NoError(t, k8sClient.Get(ctx, routeName, updated))
+	assert.Len(t, updated.Status.Parents, 1)
+	cnd := meta.FindStatusCondition(updated.Status.Parents[0].Conditions, string(gwv1beta1.RouteConditionAccepted))
+	assert.NotNil(t, cnd)
+	assert.Equal(t, metav1.ConditionFalse, cnd.Status)
+	assert.Equal(t, string(gwv1.RouteReasonUnsupportedValue), cnd.Reason)
+}
+
+func TestRouteReconciler_ReconcilePartiallyInvalidRoute(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+	ctx := context.TODO()
+
+	k8sClient := newValidationTestClient(ctx)
+	route := newValidationTestRoute()
+	k8sClient.Create(ctx, route.DeepCopy())
+
+	stack := core.NewDefaultStack(core.StackID(k8s.NamespacedName(route)))
+	mockModelBuilder := gateway.NewMockLatticeServiceBuilder(c)
+	mockModelBuilder.EXPECT().Build(gomock.Any(), gomock.Any()).Return(stack, &gateway.PartiallyInvalidRouteError{
+		DroppedRules: []int{0},
+		Message:      "Dropped Rule(s) 0: query parameter matches are not supported",
+	})
+
+	mockCloud := aws2.NewMockCloud(c)
+	mockLattice := mocks.NewMockLattice(c)
+	mockCloud.EXPECT().Lattice().Return(mockLattice).AnyTimes()
+	mockLattice.EXPECT().FindService(ctx, gomock.Any()).Return(
+		&vpclattice.ServiceSummary{
+			DnsEntry: &vpclattice.DnsEntry{
+				DomainName: aws.String("my-fqdn.lattice.on.aws"),
+			},
+		}, nil)
+
+	deployer := &fakeStackDeployer{}
+	rc := newValidationTestReconciler(c, k8sClient, mockModelBuilder, deployer)
+	rc.cloud = mockCloud
+
+	routeName := k8s.NamespacedName(route)
+	result, err := rc.Reconcile(ctx, reconcile.Request{NamespacedName: routeName})
+	assert.Nil(t, err)
+	assert.False(t, result.Requeue)
+	assert.True(t, deployer.deployed) // valid rules are still deployed
+
+	updated := &gwv1beta1.HTTPRoute{}
+	assert.NoError(t, k8sClient.Get(ctx, routeName, updated))
+	assert.Len(t, updated.Status.Parents, 1)
+	cnd := meta.FindStatusCondition(updated.Status.Parents[0].Conditions, string(gwv1.RouteConditionPartiallyInvalid))
+	assert.NotNil(t, cnd)
+	assert.Equal(t, metav1.ConditionTrue, cnd.Status)
+	assert.Equal(t, string(gwv1.RouteReasonUnsupportedValue), cnd.Reason)
+	cnd = meta.FindStatusCondition(updated.Status.Parents[0].Conditions, string(gwv1beta1.RouteConditionAccepted))
+	assert.Equal(t, metav1.ConditionTrue, cnd.Status)
+}
+
+func TestRouteReconciler_ReconcileServiceImportNotFound(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+	ctx := context.TODO()
+
+	k8sClient := newValidationTestClient(ctx)
+	route := newValidationTestRoute()
+	k8sClient.Create(ctx, route.DeepCopy())
+
+	stack := core.NewDefaultStack(core.StackID(k8s.NamespacedName(route)))
+	mockModelBuilder := gateway.NewMockLatticeServiceBuilder(c)
+	mockModelBuilder.EXPECT().Build(gomock.Any(), gomock.Any()).Return(stack, nil)
+
+	deployer := &fakeStackDeployer{err: fmt.Errorf("error during rule synthesis %w", &lattice.ServiceImportError{
+		Reason:  gwv1.RouteReasonBackendNotFound,
+		Message: "no target group of service ns1/backend is exported for port 8080",
+	})}
+	rc := newValidationTestReconciler(c, k8sClient, mockModelBuilder, deployer)
+
+	routeName := k8s.NamespacedName(route)
+	result, err := rc.Reconcile(ctx, reconcile.Request{NamespacedName: routeName})
+	assert.Nil(t, err)
+	assert.NotZero(t, result.RequeueAfter) // exports of other clusters are not watched
+
+	updated := &gwv1beta1.HTTPRoute{}
+	assert.NoError(t, k8sClient.Get(ctx, routeName, updated))
+	assert.Len(t, updated.Status.Parents, 1)
+	cnd := meta.FindStatusCondition(updated.Status.Parents[0].Conditions, string(gwv1beta1.RouteConditionResolvedRefs))
+	assert.NotNil(t, cnd)
+	assert.Equal(t, metav1.ConditionFalse, cnd.Status)
+	assert.Equal(t, string(gwv1.RouteReasonBackendNotFound), cnd.Reason)
+	assert.Contains(t, cnd.Message, "port 8080")
+}
+
+func TestRouteReconciler_ReconcileRouteNotAllowedByListeners(t *testing.T) {
+	tests := []struct {
+		name     string
+		deployed bool
+	}{
+		{name: "new route is not deployed"},
+		// e.g. a cross-namespace route deployed before allowedRoutes was enforced
+		{name: "deployed route is removed", deployed: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+			ctx := context.TODO()
+
+			k8sClient := newValidationTestClient(ctx)
+			// listener allows routes from the gateway namespace only by default
+			route := newValidationTestRoute()
+			route.Namespace = "ns2"
+			route.Spec.ParentRefs[0].Namespace = (*gwv1beta1.Namespace)(aws.String("ns1"))
+			if tt.deployed {
+				route.Finalizers = []string{routeTypeToFinalizer[core.HttpRouteType]}
+			}
+			k8sClient.Create(ctx, route.DeepCopy())
+
+			mockModelBuilder := gateway.NewMockLatticeServiceBuilder(c)
+			if tt.deployed {
+				stack := core.NewDefaultStack(core.StackID(k8s.NamespacedName(route)))
+				mockModelBuilder.EXPECT().Build(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, route core.Route) (core.Stack, error) {
+						// previously deployed resources are cleaned up
+						assert.False(t, route.DeletionTimestamp().IsZero())
+						return stack, nil
+					})
+			}
+			deployer := &fakeStackDeployer{}
+			rc := newValidationTestReconciler(c, k8sClient, mockModelBuilder, deployer)
+			if tt.deployed {
+				rc.finalizerManager.(*k8s.MockFinalizerManager).EXPECT().
+					RemoveFinalizers(gomock.Any(), gomock.Any(), routeTypeToFinalizer[core.HttpRouteType]).Return(nil)
+			}
+
+			routeName := k8s.NamespacedName(route)
+			result, err := rc.Reconcile(ctx, reconcile.Request{NamespacedName: routeName})
+			assert.Nil(t, err)
+			assert.False(t, result.Requeue)
+			assert.Equal(t, tt.deployed, deployer.deployed)
+
+			updated := &gwv1beta1.HTTPRoute{}
+			assert.NoError(t, k8sClient.Get(ctx, routeName, updated))
+			assert.True(t, updated.DeletionTimestamp.IsZero())
+			assert.Len(t, updated.Status.Parents, 1)
+			cnd := meta.FindStatusCondition(updated.Status.Parents[0].Conditions, string(gwv1beta1.RouteConditionAccepted))
+			assert.NotNil(t, cnd)
+			assert.Equal(t, metav1.ConditionFalse, cnd.Status)
+			assert.Equal(t, string(gwv1.RouteReasonNotAllowedByListeners), cnd.Reason)
+		})
+	}
+}
+
+func TestRouteReconciler_ValidateBackendRefsNotPermitted(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+	ctx := context.TODO()
+
+	k8sClient := newValidationTestClient(ctx)
+	k8sClient.Create(ctx, &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "backend", Namespace: "ns2"}})
+
+	route := newValidationTestRoute()
+	route.Spec.Rules[0].BackendRefs = []gwv1beta1.HTTPBackendRef{
+		{
+			BackendRef: gwv1beta1.BackendRef{
+				BackendObjectReference: gwv1beta1.BackendObjectReference{
+					Name:      "backend",
+					Namespace: (*gwv1beta1.Namespace)(aws.String("ns2")),
+				},
+			},
+		},
+	}
+	rc := newValidationTestReconciler(c, k8sClient, nil, nil)
+
+	cnd, err := rc.validateBackedRefs(ctx, core.NewHTTPRoute(*route))
+	assert.NoError(t, err)
+	assert.Equal(t, metav1.ConditionFalse, cnd.Status)
+	assert.Equal(t, string(gwv1beta1.RouteReasonRefNotPermitted), cnd.Reason)
+
+	grant := &gwv1beta1.ReferenceGrant{
+		ObjectMeta: metav1.ObjectMeta{Name: "allow-ns1", Namespace: "ns2"},
+		Spec: gwv1beta1.ReferenceGrantSpec{
+			From: []gwv1beta1.ReferenceGrantFrom{{Group: gwv1beta1.GroupName, Kind: "HTTPRoute", Namespace: "ns1"}},
+			To:   []gwv1beta1.ReferenceGrantTo{{Group: "", Kind: "Service"}},
+		},
+	}
+	assert.NoError(t, k8sClient.Create(ctx, grant))
+
+	cnd, err = rc.validateBackedRefs(ctx, core.NewHTTPRoute(*route))
+	assert.NoError(t, err)
+	assert.Equal(t, metav1.ConditionTrue, cnd.Status)
+	assert.Equal(t, string(gwv1beta1.RouteReasonResolvedRefs), cnd.Reason)
+}
+
+func TestRouteReconciler_ValidateBackendRefsServiceImportGroup(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+	ctx := context.TODO()
+
+	k8sClient := newValidationTestClient(ctx)
+	assert.NoError(t, anv1alpha1.AddToSchemeWithMulticlusterGroup(k8sClient.Scheme(), anv1alpha1.MulticlusterGroupName))
+	assert.NoError(t, k8sClient.Create(ctx, &anv1alpha1.ServiceImport{
+		ObjectMeta: metav1.ObjectMeta{Name: "backend", Namespace: "ns1"},
+		Spec:       anv1alpha1.ServiceImportSpec{Type: anv1alpha1.ClusterSetIP},
+	}))
+	rc := newValidationTestReconciler(c, k8sClient, nil, nil)
+
+	tests := []struct {
+		group          *gwv1beta1.Group
+		expectedReason gwv1beta1.RouteConditionReason
+	}{
+		{group: nil, expectedReason: gwv1beta1.RouteReasonResolvedRefs},
+		{group: (*gwv1beta1.Group)(aws.String(anv1alpha1.GroupName)), expectedReason: gwv1beta1.RouteReasonResolvedRefs},
+		{group: (*gwv1beta1.Group)(aws.String(anv1alpha1.MulticlusterGroupName)), expectedReason: gwv1beta1.RouteReasonResolvedRefs},
+		{group: (*gwv1beta1.Group)(aws.String("example.com")), expectedReason: gwv1beta1.RouteReasonInvalidKind},
+	}
+	for _, tt := range tests {
+		route := newValidationTestRoute()
+		route.Spec.Rules[0].BackendRefs = []gwv1beta1.HTTPBackendRef{
+			{
+				BackendRef: gwv1beta1.BackendRef{
+					BackendObjectReference: gwv1beta1.BackendObjectReference{
+						Group: tt.group,
+						Kind:  (*gwv1beta1.Kind)(aws.String("ServiceImport")),
+						Name:  "backend",
+					},
+				},
+			},
+		}
+		cnd, err := rc.validateBackedRefs(ctx, core.NewHTTPRoute(*route))
+		assert.NoError(t, err)
+		assert.Equal(t, string(tt.expectedReason), cnd.Reason)
+	}
+}
+
+func TestRouteReconciler_ValidateBackendRefsOtherAccountServiceImport(t *testing.T) {
+	accountID := config.AccountID
+	defer func() { config.AccountID = accountID }()
+	config.AccountID = "111111111111"
+
+	c := gomock.NewController(t)
+	defer c.Finish()
+	ctx := context.TODO()
+
+	k8sClient := newValidationTestClient(ctx)
+	assert.NoError(t, anv1alpha1.AddToScheme(k8sClient.Scheme()))
+	assert.NoError(t, k8sClient.Create(ctx, &anv1alpha1.ServiceImport{
+		ObjectMeta: metav1.ObjectMeta{Name: "backend", Namespace: "ns1",
+			Annotations: map[string]string{gateway.ServiceImportAccountAnnotation: "222222222222"}},
+		Spec: anv1alpha1.ServiceImportSpec{Type: anv1alpha1.ClusterSetIP},
+	}))
+	rc := newValidationTestReconciler(c, k8sClient, nil, nil)
+
+	route := newValidationTestRoute()
+	route.Spec.Rules[0].BackendRefs = []gwv1beta1.HTTPBackendRef{
+		{
+			BackendRef: gwv1beta1.BackendRef{
+				BackendObjectReference: gwv1beta1.BackendObjectReference{
+					Kind: (*gwv1beta1.Kind)(aws.String("ServiceImport")),
+					Name: "backend",
+				},
+			},
+		},
+	}
+	cnd, err := rc.validateBackedRefs(ctx, core.NewHTTPRoute(*route))
+	assert.NoError(t, err)
+	assert.Equal(t, metav1.ConditionFalse, cnd.Status)
+	assert.Equal(t, string(gwv1beta1.RouteReasonRefNotPermitted), cnd.Reason)
+	assert.Contains(t, cnd.Message, "account 222222222222")
+}
+
+func TestRouteReconciler_ValidateParentRefsUnservableHostnames(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+	ctx := context.TODO()
+
+	k8sClient := newValidationTestClient(ctx)
+	route := newValidationTestRoute()
+	route.Spec.Hostnames = []gwv1beta1.Hostname{"regional.example.com", "*.example.com", "global.example.com"}
+	rc := newValidationTestReconciler(c, k8sClient, nil, nil)
+
+	parentStatuses, err := rc.validateRouteParentRefs(ctx, core.NewHTTPRoute(*route))
+	assert.NoError(t, err)
+	assert.Len(t, parentStatuses, 1)
+	cnd := meta.FindStatusCondition(parentStatuses[0].Conditions, string(gwv1beta1.RouteConditionAccepted))
+	assert.Equal(t, metav1.ConditionTrue, cnd.Status)
+	assert.Contains(t, cnd.Message, "hostnames *.example.com are not served")
+	// only the 1st hostname is the custom domain name of the service
+	assert.Contains(t, cnd.Message, "hostnames global.example.com are not served")
+	assert.Contains(t, cnd.Message, "custom domain name regional.example.com")
+}
+
+func TestRouteReconciler_ValidateParentRefsIncompatibleListeners(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+	ctx := context.TODO()
+
+	k8sClient := newValidationTestClient(ctx)
+	terminate := gwv1.TLSModeTerminate
+	for name, certArn := range map[string]string{"gw-a": "cert-a", "gw-b": "cert-b"} {
+		gw := &gwv1beta1.Gateway{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "ns1"},
+			Spec: gwv1beta1.GatewaySpec{
+				GatewayClassName: "amazon-vpc-lattice",
+				Listeners: []gwv1beta1.Listener{
+					{
+						Name:     "https",
+						Protocol: gwv1.HTTPSProtocolType,
+						Port:     443,
+						TLS: &gwv1beta1.GatewayTLSConfig{
+							Mode: &terminate,
+							Options: map[gwv1beta1.AnnotationKey]gwv1beta1.AnnotationValue{
+								"application-networking.k8s.aws/certificate-arn": gwv1beta1.AnnotationValue(certArn),
+							},
+						},
+					},
+				},
+			},
+		}
+		assert.NoError(t, k8sClient.Create(ctx, gw))
+	}
+
+	route := newValidationTestRoute()
+	route.Spec.ParentRefs = []gwv1beta1.ParentReference{{Name: "my-gateway"}, {Name: "gw-a"}, {Name: "gw-b"}}
+	rc := newValidationTestReconciler(c, k8sClient, nil, nil)
+
+	parentStatuses, err := rc.validateRouteParentRefs(ctx, core.NewHTTPRoute(*route))
+	assert.NoError(t, err)
+	assert.Len(t, parentStatuses, 3)
+	for i, expectedReason := range []gwv1.RouteConditionReason{
+		gwv1.RouteReasonAccepted,
+		gwv1.RouteReasonAccepted,
+		core.RouteReasonIncompatibleListeners,
+	} {
+		cnd := meta.FindStatusCondition(parentStatuses[i].Conditions, string(gwv1beta1.RouteConditionAccepted))
+		assert.Equal(t, string(expectedReason), cnd.Reason)
+	}
+	cnd := meta.FindStatusCondition(parentStatuses[2].Conditions, string(gwv1beta1.RouteConditionAccepted))
+	assert.Equal(t, metav1.ConditionFalse, cnd.Status)
+	assert.Contains(t, cnd.Message, "listener https of gateway ns1/gw-a")
+}
+
+func TestRouteReconciler_ReconcileDryRun(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+	ctx := context.TODO()
+
+	config.DryRunMode = true
+	defer func() { config.DryRunMode = false }()
+
+	k8sClient := newValidationTestClient(ctx)
+	route := newValidationTestRoute()
+	k8sClient.Create(ctx, route.DeepCopy())
+
+	stack := core.NewDefaultStack(core.StackID(k8s.NamespacedName(route)))
+	mockModelBuilder := gateway.NewMockLatticeServiceBuilder(c)
+	mockModelBuilder.EXPECT().Build(gomock.Any(), gomock.Any()).Return(stack, nil)
+
+	// the deployer and the lattice client are not used in dry-run mode
+	deployer := &fakeStackDeployer{}
+	rc := newValidationTestReconciler(c, k8sClient, mockModelBuilder, deployer)
+	rc.stackPlanner = &fakeStackPlanner{changes: lattice.ChangeSet{
+		{Action: lattice.ChangeActionCreate, ResourceType: lattice.ChangeResourceService, Name: "route-ns1"},
+	}}
+
+	routeName := k8s.NamespacedName(route)
+	result, err := rc.Reconcile(ctx, reconcile.Request{NamespacedName: routeName})
+	assert.Nil(t, err)
+	assert.False(t, result.Requeue)
+	assert.False(t, deployer.deployed)
+
+	updated := &gwv1beta1.HTTPRoute{}
+	assert.NoError(t, k8sClient.Get(ctx, routeName, updated))
+	assert.Equal(t, "1 to create, 0 to update, 0 to delete\ncreate service route-ns1",
+		updated.Annotations[DryRunPlanAnnotation])
+}
+
+type fakeStackDeployer struct {
+	deployed bool
+	err      error
+}
+
+func (d *fakeStackDeployer) Deploy(ctx context.Context, stack core.Stack) error {
+	d.deployed = true
+	return d.err
+}
+
+type fakeStackPlanner struct {
+	changes lattice.ChangeSet
+}
+
+func (p *fakeStackPlanner) Plan(ctx context.Context, stack core.Stack) (lattice.ChangeSet, error) {
+	return p.changes, nil
+}
+
+func newValidationTestClient(ctx context.Context) client.Client {
+	k8sScheme := runtime.NewScheme()
+	clientgoscheme.AddToScheme(k8sScheme)
+	gwv1beta1.AddToScheme(k8sScheme)
+	addOptionalCRDs(k8sScheme)
+
+	k8sClient := testclient.
+		NewClientBuilder().
+		WithScheme(k8sScheme).
+		WithStatusSubresource(&gwv1beta1.HTTPRoute{}).
+		Build()
+
+	gwClass := &gwv1beta1.GatewayClass{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "amazon-vpc-lattice",
+			Namespace: defaultNamespace,
+		},
+		Spec: gwv1beta1.GatewayClassSpec{
+			ControllerName: config.LatticeGatewayControllerName,
+		},
+	}
+	k8sClient.Create(ctx, gwClass.DeepCopy())
+
+	gw := &gwv1beta1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-gateway",
+			Namespace: "ns1",
+		},
+		Spec: gwv1beta1.GatewaySpec{
+			GatewayClassName: "amazon-vpc-lattice",
+			Listeners: []gwv1beta1.Listener{
+				{
+					Name:     "http",
+					Protocol: "HTTP",
+					Port:     80,
+				},
+			},
+		},
+	}
+	k8sClient.Create(ctx, gw.DeepCopy())
+	return k8sClient
+}
+
+func newValidationTestRoute() *gwv1beta1.HTTPRoute {
+	return &gwv1beta1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-route",
+			Namespace: "ns1",
+		},
+		Spec: gwv1beta1.HTTPRouteSpec{
+			CommonRouteSpec: gwv1beta1.CommonRouteSpec{
+				ParentRefs: []gwv1beta1.ParentReference{
+					{
+						Name: "my-gateway",
+					},
+				},
+			},
+			Rules: []gwv1beta1.HTTPRouteRule{
+				{
+					Matches: []gwv1beta1.HTTPRouteMatch{
+						{
+							QueryParams: []gwv1beta1.HTTPQueryParamMatch{
+								{
+									Name:  "version",
+									Value: "beta",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func newValidationTestReconciler(
+	c *gomock.Controller,
+	k8sClient client.Client,
+	modelBuilder gateway.LatticeServiceBuilder,
+	stackDeployer deploy.StackDeployer,
+) *routeReconciler {
+	mockEventRecorder := mock_client.NewMockEventRecorder(c)
+	mockEventRecorder.EXPECT().Event(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+	mockFinalizer := k8s.NewMockFinalizerManager(c)
+	mockFinalizer.EXPECT().AddFinalizers(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+
+	return &routeReconciler{
+		routeType:        core.HttpRouteType,
+		log:              gwlog.FallbackLogger,
+		client:           k8sClient,
+		scheme:           k8sClient.Scheme(),
+		finalizerManager: mockFinalizer,
+		eventRecorder:    mockEventRecorder,
+		modelBuilder:     modelBuilder,
+		stackDeployer:    stackDeployer,
+		stackMarshaller:  deploy.NewDefaultStackMarshaller(),
+	}
+}
+
 func addOptionalCRDs(scheme *runtime.Scheme) {
 	dnsEndpoint := schema.GroupVersion{
 		Group:   "externaldns.k8s.io",
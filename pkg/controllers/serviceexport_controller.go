@@ -18,22 +18,39 @@ package controllers
 
 import (
 	"context"
-	"errors"
 	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
 
 	"github.com/aws/aws-application-networking-k8s/pkg/controllers/eventhandlers"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
+	pkg_builder "sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	anv1alpha1 "github.com/aws/aws-application-networking-k8s/pkg/apis/applicationnetworking/v1alpha1"
-	"github.com/aws/aws-application-networking-k8s/pkg/aws"
+	pkg_aws "github.com/aws/aws-application-networking-k8s/pkg/aws"
+	"github.com/aws/aws-application-networking-k8s/pkg/config"
 	"github.com/aws/aws-application-networking-k8s/pkg/deploy"
+	"github.com/aws/aws-application-networking-k8s/pkg/deploy/lattice"
 	"github.com/aws/aws-application-networking-k8s/pkg/gateway"
 	"github.com/aws/aws-application-networking-k8s/pkg/k8s"
+	"github.com/aws/aws-application-networking-k8s/pkg/model/core"
+	model "github.com/aws/aws-application-networking-k8s/pkg/model/lattice"
 	lattice_runtime "github.com/aws/aws-application-networking-k8s/pkg/runtime"
+	"github.com/aws/aws-application-networking-k8s/pkg/utils"
 	"github.com/aws/aws-application-networking-k8s/pkg/utils/gwlog"
 	discoveryv1 "k8s.io/api/discovery/v1"
 )
@@ -47,17 +64,22 @@ type serviceExportReconciler struct {
 	modelBuilder     gateway.SvcExportTargetGroupModelBuilder
 	stackDeployer    deploy.StackDeployer
 	stackMarshaller  deploy.StackMarshaller
+	tgLister         *svcExportTGLister
 }
 
 const (
 	serviceExportFinalizer = "serviceexport.k8s.aws/resources"
+
+	// exports of other clusters trigger no event, the Conflict conditions are checked against them periodically
+	serviceExportConflictCheckInterval = 5 * time.Minute
 )
 
 func RegisterServiceExportController(
 	log gwlog.Logger,
-	cloud aws.Cloud,
+	cloud pkg_aws.Cloud,
 	finalizerManager k8s.FinalizerManager,
 	mgr ctrl.Manager,
+	driftDetector *DriftDetector,
 ) error {
 	mgrClient := mgr.GetClient()
 	scheme := mgr.GetScheme()
@@ -76,14 +98,34 @@ func RegisterServiceExportController(
 		stackDeployer:    stackDeploy,
 		eventRecorder:    eventRecorder,
 		stackMarshaller:  stackMarshaller,
+		tgLister:         newSvcExportTGLister(lattice.NewTargetGroupManager(log, cloud), serviceExportConflictCheckInterval),
+	}
+
+	// the checker refreshes the target groups every interval, reconciles use its latest listing
+	conflictChecker := &serviceExportConflictChecker{
+		log:      log,
+		client:   mgrClient,
+		tgLister: r.tgLister,
+		interval: serviceExportConflictCheckInterval,
+		exports:  make(chan event.GenericEvent),
+	}
+	if err := mgr.Add(conflictChecker); err != nil {
+		return err
 	}
 
 	svcEventHandler := eventhandlers.NewServiceEventHandler(log, r.client)
 
+	// with the status subresource of the upstream CRD, status updates do not change the generation
 	builder := ctrl.NewControllerManagedBy(mgr).
-		For(&anv1alpha1.ServiceExport{}).
+		For(&anv1alpha1.ServiceExport{}, pkg_builder.WithPredicates(
+			predicate.Or(predicate.GenerationChangedPredicate{}, predicate.AnnotationChangedPredicate{}))).
 		Watches(&corev1.Service{}, svcEventHandler.MapToServiceExport()).
-		Watches(&discoveryv1.EndpointSlice{}, svcEventHandler.MapToServiceExport())
+		Watches(&discoveryv1.EndpointSlice{}, svcEventHandler.MapToServiceExport()).
+		WatchesRawSource(&source.Channel{Source: conflictChecker.exports}, &handler.EnqueueRequestForObject{})
+
+	if driftDetector != nil {
+		builder.WatchesRawSource(driftDetector.ServiceExportRepairSource(), &handler.EnqueueRequestForObject{})
+	}
 
 	if ok, err := k8s.IsGVKSupported(mgr, anv1alpha1.GroupVersion.String(), anv1alpha1.TargetGroupPolicyKind); ok {
 		builder.Watches(&anv1alpha1.TargetGroupPolicy{}, svcEventHandler.MapToServiceExport())
@@ -100,6 +142,9 @@ func RegisterServiceExportController(
 //+kubebuilder:rbac:groups=application-networking.k8s.aws,resources=serviceexports,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=application-networking.k8s.aws,resources=serviceexports/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=application-networking.k8s.aws,resources=serviceexports/finalizers,verbs=update
+//+kubebuilder:rbac:groups=multicluster.x-k8s.io,resources=serviceexports,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=multicluster.x-k8s.io,resources=serviceexports/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=multicluster.x-k8s.io,resources=serviceexports/finalizers,verbs=update
 
 func (r *serviceExportReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	r.log.Infow("reconcile", "name", req.Name)
@@ -123,7 +168,7 @@ func (r *serviceExportReconciler) reconcile(ctx context.Context, req ctrl.Reques
 	r.log.Debugf("Found matching service export %s-%s", srvExport.Name, srvExport.Namespace)
 
 	if !srvExport.DeletionTimestamp.IsZero() {
-		if err := r.buildAndDeployModel(ctx, srvExport); err != nil {
+		if _, err := r.buildAndDeployModel(ctx, srvExport); err != nil {
 			return err
 		}
 		err := r.finalizerManager.RemoveFinalizers(ctx, srvExport, serviceExportFinalizer)
@@ -134,19 +179,28 @@ func (r *serviceExportReconciler) reconcile(ctx context.Context, req ctrl.Reques
 		return nil
 	} else {
 		if err := r.finalizerManager.AddFinalizers(ctx, srvExport, serviceExportFinalizer); err != nil {
-			r.eventRecorder.Event(srvExport, corev1.EventTypeWarning, k8s.GatewayEventReasonFailedAddFinalizer, fmt.Sprintf("Failed add finalizer due to %v", err))
-			return errors.New("TODO")
+			r.eventRecorder.Event(srvExport, corev1.EventTypeWarning, k8s.ServiceExportEventReasonFailedAddFinalizer, fmt.Sprintf("Failed add finalizer due to %v", err))
+			return fmt.Errorf("failed to add finalizer to service export %s-%s due to %w",
+				srvExport.Name, srvExport.Namespace, err)
 		}
 
-		err := r.buildAndDeployModel(ctx, srvExport)
+		stack, err := r.buildAndDeployModel(ctx, srvExport)
+		if statusErr := r.updateStatus(ctx, srvExport, stack, err); statusErr != nil {
+			r.log.Infof("Failed to update status of service export %s-%s due to %s",
+				srvExport.Name, srvExport.Namespace, statusErr)
+			if err == nil {
+				return statusErr
+			}
+		}
 		return err
 	}
 }
 
+// buildAndDeployModel returns the stack once built, even when it fails to deploy
 func (r *serviceExportReconciler) buildAndDeployModel(
 	ctx context.Context,
 	srvExport *anv1alpha1.ServiceExport,
-) error {
+) (core.Stack, error) {
 	stack, err := r.modelBuilder.Build(ctx, srvExport)
 
 	if err != nil {
@@ -157,7 +211,7 @@ func (r *serviceExportReconciler) buildAndDeployModel(
 			k8s.GatewayEventReasonFailedBuildModel,
 			fmt.Sprintf("Failed BuildModel due to %s", err))
 
-		return err
+		return nil, err
 	}
 
 	json, err := r.stackMarshaller.Marshal(stack)
@@ -169,9 +223,269 @@ func (r *serviceExportReconciler) buildAndDeployModel(
 	if err := r.stackDeployer.Deploy(ctx, stack); err != nil {
 		r.eventRecorder.Event(srvExport, corev1.EventTypeWarning,
 			k8s.ServiceExportEventReasonFailedDeployModel, fmt.Sprintf("Failed deploy model due to %s", err))
-		return err
+		return stack, err
 	}
 
 	r.log.Debugf("Successfully deployed model for service export %s-%s", srvExport.Name, srvExport.Namespace)
-	return err
+	return stack, nil
+}
+
+// updateStatus sets the Valid condition from the outcome of the deployment. Once the target groups are
+// created, they are recorded with the Conflict condition, comparing them with the exports of other clusters.
+// Only the conditions are written to the upstream CRD, through its status subresource.
+func (r *serviceExportReconciler) updateStatus(
+	ctx context.Context,
+	srvExport *anv1alpha1.ServiceExport,
+	stack core.Stack,
+	deployErr error,
+) error {
+	updated := srvExport.DeepCopy()
+	valid := anv1alpha1.ServiceExportCondition{
+		Type:   anv1alpha1.ServiceExportValid,
+		Status: corev1.ConditionFalse,
+	}
+	switch {
+	case stack == nil && apierrors.IsNotFound(deployErr):
+		valid.Reason = aws.String(anv1alpha1.ServiceExportReasonServiceNotFound)
+		valid.Message = aws.String(deployErr.Error())
+	case stack == nil:
+		valid.Reason = aws.String(anv1alpha1.ServiceExportReasonFailedBuildModel)
+		valid.Message = aws.String(deployErr.Error())
+	case deployErr != nil:
+		valid.Reason = aws.String(anv1alpha1.ServiceExportReasonFailedDeployModel)
+		valid.Message = aws.String(deployErr.Error())
+	default:
+		var tgs []*model.TargetGroup
+		if err := stack.ListResources(&tgs); err != nil {
+			return err
+		}
+		conflict, err := r.conflictCondition(ctx, srvExport, tgs)
+		if err != nil {
+			return err
+		}
+		if conflict.Status == corev1.ConditionTrue && !hasServiceExportCondition(srvExport.Status.Conditions, conflict) {
+			r.eventRecorder.Event(srvExport, corev1.EventTypeWarning, k8s.ServiceExportEventReasonConflict, *conflict.Message)
+		}
+		updated.Status.Conditions = setServiceExportCondition(updated.Status.Conditions, conflict)
+		// the upstream CRD has no targetGroups, they would never be stored
+		if !hasMulticlusterStatusSubresource() {
+			updated.Status.TargetGroups = exportedTargetGroups(tgs)
+		}
+
+		valid.Status = corev1.ConditionTrue
+		valid.Reason = aws.String(anv1alpha1.ServiceExportReasonTargetGroupCreated)
+		valid.Message = aws.String("Target groups created")
+	}
+	updated.Status.Conditions = setServiceExportCondition(updated.Status.Conditions, valid)
+
+	if equality.Semantic.DeepEqual(updated.Status, srvExport.Status) {
+		return nil
+	}
+	// the CRD of the controller has no status subresource, the upstream one has
+	if hasMulticlusterStatusSubresource() {
+		return r.client.Status().Patch(ctx, updated, client.MergeFrom(srvExport))
+	}
+	return r.client.Patch(ctx, updated, client.MergeFrom(srvExport))
+}
+
+// conflictCondition compares the target groups of the export with the ones other clusters export for the
+// same service. Clients of a ServiceImport are sent to any of them, they must serve the same ports and protocols.
+// The exported target groups are listed at most once per conflict check interval for all ServiceExports.
+func (r *serviceExportReconciler) conflictCondition(
+	ctx context.Context,
+	srvExport *anv1alpha1.ServiceExport,
+	tgs []*model.TargetGroup,
+) (anv1alpha1.ServiceExportCondition, error) {
+	exportedTgs, err := r.tgLister.List(ctx)
+	if err != nil {
+		return anv1alpha1.ServiceExportCondition{}, fmt.Errorf("failed to list exported target groups due to %w", err)
+	}
+	return serviceExportConflict(srvExport, tgs, exportedTgs), nil
+}
+
+func serviceExportConflict(
+	srvExport *anv1alpha1.ServiceExport,
+	tgs []*model.TargetGroup,
+	exportedTgs []*model.TargetGroup,
+) anv1alpha1.ServiceExportCondition {
+	localTgs := make(map[int32]*model.TargetGroup)
+	for _, tg := range tgs {
+		localTgs[tg.Spec.Port] = tg
+	}
+
+	var portConflicts, protocolConflicts []string
+	for _, exportedTg := range exportedTgs {
+		spec := exportedTg.Spec
+		if !isExportOf(exportedTg, srvExport) || spec.K8SClusterName == config.ClusterName {
+			continue
+		}
+		localTg, ok := localTgs[spec.Port]
+		if !ok {
+			portConflicts = append(portConflicts, fmt.Sprintf("cluster %s exports port %d", spec.K8SClusterName, spec.Port))
+		} else if spec.Protocol != localTg.Spec.Protocol || spec.ProtocolVersion != localTg.Spec.ProtocolVersion {
+			protocolConflicts = append(protocolConflicts, fmt.Sprintf("cluster %s exports port %d with protocol %s %s",
+				spec.K8SClusterName, spec.Port, spec.Protocol, spec.ProtocolVersion))
+		}
+	}
+	// target groups are listed in no particular order, the message must not change between checks
+	sort.Strings(portConflicts)
+	sort.Strings(protocolConflicts)
+
+	conflict := anv1alpha1.ServiceExportCondition{
+		Type:    anv1alpha1.ServiceExportConflict,
+		Status:  corev1.ConditionFalse,
+		Reason:  aws.String(anv1alpha1.ServiceExportReasonNoConflicts),
+		Message: aws.String("Exports of other clusters are compatible"),
+	}
+	if len(protocolConflicts) > 0 {
+		conflict.Status = corev1.ConditionTrue
+		conflict.Reason = aws.String(anv1alpha1.ServiceExportReasonProtocolConflict)
+		conflict.Message = aws.String("Incompatible exports: " + strings.Join(append(protocolConflicts, portConflicts...), ", "))
+	} else if len(portConflicts) > 0 {
+		conflict.Status = corev1.ConditionTrue
+		conflict.Reason = aws.String(anv1alpha1.ServiceExportReasonPortConflict)
+		conflict.Message = aws.String("Incompatible exports: " + strings.Join(portConflicts, ", "))
+	}
+	return conflict
+}
+
+func isExportOf(tg *model.TargetGroup, srvExport *anv1alpha1.ServiceExport) bool {
+	return tg.Spec.K8SServiceName == srvExport.Name && tg.Spec.K8SServiceNamespace == srvExport.Namespace
+}
+
+func exportedTargetGroups(tgs []*model.TargetGroup) []anv1alpha1.ExportedTargetGroup {
+	var exported []anv1alpha1.ExportedTargetGroup
+	for _, tg := range tgs {
+		if tg.IsDeleted || tg.Status == nil {
+			continue
+		}
+		exported = append(exported, anv1alpha1.ExportedTargetGroup{
+			Arn:             tg.Status.Arn,
+			Port:            tg.Spec.Port,
+			Protocol:        tg.Spec.Protocol,
+			ProtocolVersion: tg.Spec.ProtocolVersion,
+		})
+	}
+	return exported
+}
+
+// setServiceExportCondition replaces the condition of the same type, its transition time is kept
+// when the status is unchanged
+func setServiceExportCondition(
+	conditions []anv1alpha1.ServiceExportCondition,
+	newCond anv1alpha1.ServiceExportCondition,
+) []anv1alpha1.ServiceExportCondition {
+	now := metav1.Now()
+	newCond.LastTransitionTime = &now
+	newConditions := make([]anv1alpha1.ServiceExportCondition, 0, len(conditions)+1)
+	found := false
+	for _, cond := range conditions {
+		if cond.Type != newCond.Type {
+			newConditions = append(newConditions, cond)
+			continue
+		}
+		if cond.Status == newCond.Status && cond.LastTransitionTime != nil {
+			newCond.LastTransitionTime = cond.LastTransitionTime
+		}
+		newConditions = append(newConditions, newCond)
+		found = true
+	}
+	if !found {
+		newConditions = append(newConditions, newCond)
+	}
+	return newConditions
+}
+
+func hasServiceExportCondition(conditions []anv1alpha1.ServiceExportCondition, cond anv1alpha1.ServiceExportCondition) bool {
+	for _, c := range conditions {
+		if c.Type == cond.Type && c.Status == cond.Status &&
+			aws.StringValue(c.Reason) == aws.StringValue(cond.Reason) &&
+			aws.StringValue(c.Message) == aws.StringValue(cond.Message) {
+			return true
+		}
+	}
+	return false
+}
+
+// serviceExportConflictChecker periodically compares the exports of other clusters with the Conflict condition of
+// the ServiceExports of the cluster, and sends the ones whose condition is outdated to the controller. The target
+// groups of the cluster are found among the exported ones, the check does not build the ServiceExports.
+type serviceExportConflictChecker struct {
+	log      gwlog.Logger
+	client   client.Client
+	tgLister *svcExportTGLister
+	interval time.Duration
+	exports  chan event.GenericEvent
+}
+
+// Start implements manager.Runnable, the check runs every interval until the context is done
+func (c *serviceExportConflictChecker) Start(ctx context.Context) error {
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			c.check(ctx)
+		}
+	}
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable, only the leader reconciles ServiceExports
+func (c *serviceExportConflictChecker) NeedLeaderElection() bool {
+	return true
+}
+
+func (c *serviceExportConflictChecker) check(ctx context.Context) {
+	svcExports := &anv1alpha1.ServiceExportList{}
+	if err := c.client.List(ctx, svcExports); err != nil {
+		c.log.Errorf("Failed to list ServiceExports for conflict check due to %s", err)
+		return
+	}
+	// exports without the condition have no target group yet, they are reconciled anyway
+	var checked []*anv1alpha1.ServiceExport
+	for i := range svcExports.Items {
+		svcExport := &svcExports.Items[i]
+		if svcExport.DeletionTimestamp.IsZero() &&
+			hasServiceExportConditionType(svcExport.Status.Conditions, anv1alpha1.ServiceExportConflict) {
+			checked = append(checked, svcExport)
+		}
+	}
+	if len(checked) == 0 {
+		return
+	}
+	exportedTgs, err := c.tgLister.Refresh(ctx)
+	if err != nil {
+		c.log.Errorf("Failed to list exported target groups for conflict check due to %s", err)
+		return
+	}
+
+	var outdated []*anv1alpha1.ServiceExport
+	for _, svcExport := range checked {
+		localTgs := utils.SliceFilter(exportedTgs, func(tg *model.TargetGroup) bool {
+			return isExportOf(tg, svcExport) && tg.Spec.K8SClusterName == config.ClusterName
+		})
+		conflict := serviceExportConflict(svcExport, localTgs, exportedTgs)
+		if !hasServiceExportCondition(svcExport.Status.Conditions, conflict) {
+			outdated = append(outdated, svcExport)
+		}
+	}
+	for _, svcExport := range outdated {
+		c.log.Infof("Conflict condition of ServiceExport %s-%s is outdated", svcExport.Name, svcExport.Namespace)
+		select {
+		case c.exports <- event.GenericEvent{Object: svcExport}:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func hasServiceExportConditionType(conditions []anv1alpha1.ServiceExportCondition, condType anv1alpha1.ServiceExportConditionType) bool {
+	for _, c := range conditions {
+		if c.Type == condType {
+			return true
+		}
+	}
+	return false
 }
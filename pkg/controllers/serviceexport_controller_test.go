@@ -0,0 +1,383 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/vpclattice"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	mock_client "github.com/aws/aws-application-networking-k8s/mocks/controller-runtime/client"
+	anv1alpha1 "github.com/aws/aws-application-networking-k8s/pkg/apis/applicationnetworking/v1alpha1"
+	"github.com/aws/aws-application-networking-k8s/pkg/config"
+	"github.com/aws/aws-application-networking-k8s/pkg/deploy"
+	"github.com/aws/aws-application-networking-k8s/pkg/deploy/lattice"
+	"github.com/aws/aws-application-networking-k8s/pkg/gateway"
+	"github.com/aws/aws-application-networking-k8s/pkg/k8s"
+	"github.com/aws/aws-application-networking-k8s/pkg/model/core"
+	model "github.com/aws/aws-application-networking-k8s/pkg/model/lattice"
+	"github.com/aws/aws-application-networking-k8s/pkg/utils/gwlog"
+)
+
+// sets the status of the target groups of the stack, like the target group synthesizer
+type fakeTargetGroupDeployer struct {
+	err error
+}
+
+func (d *fakeTargetGroupDeployer) Deploy(ctx context.Context, stack core.Stack) error {
+	if d.err != nil {
+		return d.err
+	}
+	var tgs []*model.TargetGroup
+	if err := stack.ListResources(&tgs); err != nil {
+		return err
+	}
+	for _, tg := range tgs {
+		tg.Status = &model.TargetGroupStatus{Arn: "tg-arn", Id: "tg-id"}
+	}
+	return nil
+}
+
+func TestServiceExportReconciler_Status(t *testing.T) {
+	clusterName := config.ClusterName
+	defer func() { config.ClusterName = clusterName }()
+	config.ClusterName = "cluster-1"
+
+	localTgSpec := model.TargetGroupSpec{
+		VpcId:           "vpc-1",
+		Type:            model.TargetGroupTypeIP,
+		Port:            80,
+		Protocol:        vpclattice.TargetGroupProtocolHttp,
+		ProtocolVersion: vpclattice.TargetGroupProtocolVersionHttp1,
+		IpAddressType:   vpclattice.IpAddressTypeIpv4,
+		TargetGroupTagFields: model.TargetGroupTagFields{
+			K8SClusterName:      "cluster-1",
+			K8SSourceType:       model.SourceTypeSvcExport,
+			K8SServiceName:      "svc",
+			K8SServiceNamespace: "default",
+		},
+	}
+	exportedTg := func(cluster string, port int32, protocol, protocolVersion string) *model.TargetGroup {
+		return newSvcExportTG(cluster, "vpc-2", "default", "svc", port, protocol, protocolVersion)
+	}
+
+	tests := []struct {
+		name           string
+		buildErr       error
+		deployErr      error
+		exportedTgs    []*model.TargetGroup
+		expectedValid  string
+		expectConflict string
+		expectedTgs    []anv1alpha1.ExportedTargetGroup
+		expectedEvents []string
+	}{
+		{
+			name: "target group created without conflict",
+			exportedTgs: []*model.TargetGroup{
+				newSvcExportTG("cluster-1", "vpc-1", "default", "svc", 80, vpclattice.TargetGroupProtocolHttp, vpclattice.TargetGroupProtocolVersionHttp1),
+				exportedTg("cluster-2", 80, vpclattice.TargetGroupProtocolHttp, vpclattice.TargetGroupProtocolVersionHttp1),
+				newSvcExportTG("cluster-2", "vpc-2", "default", "other-svc", 8080, vpclattice.TargetGroupProtocolTcp, ""),
+			},
+			expectedValid:  anv1alpha1.ServiceExportReasonTargetGroupCreated,
+			expectConflict: anv1alpha1.ServiceExportReasonNoConflicts,
+			expectedTgs: []anv1alpha1.ExportedTargetGroup{
+				{Arn: "tg-arn", Port: 80, Protocol: "HTTP", ProtocolVersion: "HTTP1"},
+			},
+		},
+		{
+			name: "another cluster exports another protocol",
+			exportedTgs: []*model.TargetGroup{
+				exportedTg("cluster-2", 80, vpclattice.TargetGroupProtocolHttps, vpclattice.TargetGroupProtocolVersionGrpc),
+			},
+			expectedValid:  anv1alpha1.ServiceExportReasonTargetGroupCreated,
+			expectConflict: anv1alpha1.ServiceExportReasonProtocolConflict,
+			expectedTgs: []anv1alpha1.ExportedTargetGroup{
+				{Arn: "tg-arn", Port: 80, Protocol: "HTTP", ProtocolVersion: "HTTP1"},
+			},
+			expectedEvents: []string{"Incompatible exports: cluster cluster-2 exports port 80 with protocol HTTPS GRPC"},
+		},
+		{
+			name: "another cluster exports another port",
+			exportedTgs: []*model.TargetGroup{
+				exportedTg("cluster-2", 8080, vpclattice.TargetGroupProtocolHttp, vpclattice.TargetGroupProtocolVersionHttp1),
+			},
+			expectedValid:  anv1alpha1.ServiceExportReasonTargetGroupCreated,
+			expectConflict: anv1alpha1.ServiceExportReasonPortConflict,
+			expectedTgs: []anv1alpha1.ExportedTargetGroup{
+				{Arn: "tg-arn", Port: 80, Protocol: "HTTP", ProtocolVersion: "HTTP1"},
+			},
+			expectedEvents: []string{"Incompatible exports: cluster cluster-2 exports port 8080"},
+		},
+		{
+			name:           "service not found",
+			buildErr:       apierrors.NewNotFound(schema.GroupResource{Resource: "services"}, "svc"),
+			expectedValid:  anv1alpha1.ServiceExportReasonServiceNotFound,
+			expectedEvents: []string{"Failed BuildModel due to services \"svc\" not found"},
+		},
+		{
+			name:           "invalid target group policy",
+			buildErr:       errors.New("invalid protocol"),
+			expectedValid:  anv1alpha1.ServiceExportReasonFailedBuildModel,
+			expectedEvents: []string{"Failed BuildModel due to invalid protocol"},
+		},
+		{
+			name:           "target group creation fails",
+			deployErr:      errors.New("throttled"),
+			expectedValid:  anv1alpha1.ServiceExportReasonFailedDeployModel,
+			expectedEvents: []string{"Failed deploy model due to throttled"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+			ctx := context.TODO()
+
+			k8sScheme := runtime.NewScheme()
+			clientgoscheme.AddToScheme(k8sScheme)
+			anv1alpha1.AddToScheme(k8sScheme)
+			svcExport := &anv1alpha1.ServiceExport{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:   "default",
+					Name:        "svc",
+					Annotations: map[string]string{"application-networking.k8s.aws/federation": "amazon-vpc-lattice"},
+				},
+			}
+			k8sClient := testclient.NewClientBuilder().WithScheme(k8sScheme).WithObjects(svcExport).Build()
+
+			finalizerManager := k8s.NewMockFinalizerManager(c)
+			finalizerManager.EXPECT().AddFinalizers(gomock.Any(), gomock.Any(), serviceExportFinalizer).Return(nil)
+			modelBuilder := gateway.NewMockSvcExportTargetGroupModelBuilder(c)
+			modelBuilder.EXPECT().Build(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(ctx context.Context, svcExport *anv1alpha1.ServiceExport) (core.Stack, error) {
+					if tt.buildErr != nil {
+						return nil, tt.buildErr
+					}
+					stack := core.NewDefaultStack(core.StackID(k8s.NamespacedName(svcExport)))
+					_, err := model.NewTargetGroup(stack, localTgSpec)
+					return stack, err
+				})
+			tgManager := lattice.NewMockTargetGroupManager(c)
+			if tt.exportedTgs != nil {
+				tgManager.EXPECT().ListSvcExportTGs(gomock.Any()).Return(tt.exportedTgs, nil)
+			}
+			eventRecorder := mock_client.NewMockEventRecorder(c)
+			for _, message := range tt.expectedEvents {
+				eventRecorder.EXPECT().Event(gomock.Any(), corev1.EventTypeWarning, gomock.Any(), message)
+			}
+
+			r := &serviceExportReconciler{
+				log:              gwlog.FallbackLogger,
+				client:           k8sClient,
+				finalizerManager: finalizerManager,
+				eventRecorder:    eventRecorder,
+				modelBuilder:     modelBuilder,
+				stackDeployer:    &fakeTargetGroupDeployer{err: tt.deployErr},
+				stackMarshaller:  deploy.NewDefaultStackMarshaller(),
+				tgLister:         newSvcExportTGLister(tgManager, time.Hour),
+			}
+			_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: "svc"}})
+			assert.NoError(t, err)
+
+			updated := &anv1alpha1.ServiceExport{}
+			assert.NoError(t, k8sClient.Get(ctx, types.NamespacedName{Namespace: "default", Name: "svc"}, updated))
+			assert.Equal(t, tt.expectedTgs, updated.Status.TargetGroups)
+
+			conditions := make(map[anv1alpha1.ServiceExportConditionType]anv1alpha1.ServiceExportCondition)
+			for _, cond := range updated.Status.Conditions {
+				conditions[cond.Type] = cond
+			}
+			valid := conditions[anv1alpha1.ServiceExportValid]
+			assert.Equal(t, tt.expectedValid, aws.StringValue(valid.Reason))
+			if tt.expectedValid == anv1alpha1.ServiceExportReasonTargetGroupCreated {
+				assert.Equal(t, corev1.ConditionTrue, valid.Status)
+			} else {
+				assert.Equal(t, corev1.ConditionFalse, valid.Status)
+			}
+
+			conflict, ok := conditions[anv1alpha1.ServiceExportConflict]
+			if tt.expectConflict == "" {
+				assert.False(t, ok)
+				return
+			}
+			assert.Equal(t, tt.expectConflict, aws.StringValue(conflict.Reason))
+			if tt.expectConflict == anv1alpha1.ServiceExportReasonNoConflicts {
+				assert.Equal(t, corev1.ConditionFalse, conflict.Status)
+			} else {
+				assert.Equal(t, corev1.ConditionTrue, conflict.Status)
+			}
+		})
+	}
+}
+
+func TestServiceExportReconciler_StatusSubresource(t *testing.T) {
+	clusterName := config.ClusterName
+	multiclusterAPIGroup := config.MulticlusterAPIGroup
+	defer func() {
+		config.ClusterName = clusterName
+		config.MulticlusterAPIGroup = multiclusterAPIGroup
+	}()
+	config.ClusterName = "cluster-1"
+	config.MulticlusterAPIGroup = config.MulticlusterAPIGroupUpstream
+
+	c := gomock.NewController(t)
+	defer c.Finish()
+	ctx := context.TODO()
+
+	k8sScheme := runtime.NewScheme()
+	clientgoscheme.AddToScheme(k8sScheme)
+	anv1alpha1.AddToSchemeWithMulticlusterGroup(k8sScheme, anv1alpha1.MulticlusterGroupName)
+	svcExport := &anv1alpha1.ServiceExport{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   "default",
+			Name:        "svc",
+			Annotations: map[string]string{"application-networking.k8s.aws/federation": "amazon-vpc-lattice"},
+		},
+	}
+	// the upstream CRD has a status subresource
+	k8sClient := testclient.NewClientBuilder().WithScheme(k8sScheme).
+		WithObjects(svcExport).
+		WithStatusSubresource(svcExport).
+		Build()
+
+	finalizerManager := k8s.NewMockFinalizerManager(c)
+	finalizerManager.EXPECT().AddFinalizers(gomock.Any(), gomock.Any(), serviceExportFinalizer).Return(nil).Times(2)
+	localTg := newSvcExportTG("cluster-1", "vpc-1", "default", "svc", 80, vpclattice.TargetGroupProtocolHttp, vpclattice.TargetGroupProtocolVersionHttp1)
+	localTg.Spec.Type = model.TargetGroupTypeIP
+	localTg.Spec.IpAddressType = vpclattice.IpAddressTypeIpv4
+	modelBuilder := gateway.NewMockSvcExportTargetGroupModelBuilder(c)
+	modelBuilder.EXPECT().Build(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, svcExport *anv1alpha1.ServiceExport) (core.Stack, error) {
+			stack := core.NewDefaultStack(core.StackID(k8s.NamespacedName(svcExport)))
+			_, err := model.NewTargetGroup(stack, localTg.Spec)
+			return stack, err
+		}).Times(2)
+	tgManager := lattice.NewMockTargetGroupManager(c)
+	// the listing is shared by both reconciles
+	tgManager.EXPECT().ListSvcExportTGs(gomock.Any()).Return([]*model.TargetGroup{localTg}, nil)
+
+	r := &serviceExportReconciler{
+		log:              gwlog.FallbackLogger,
+		client:           k8sClient,
+		finalizerManager: finalizerManager,
+		eventRecorder:    mock_client.NewMockEventRecorder(c),
+		modelBuilder:     modelBuilder,
+		stackDeployer:    &fakeTargetGroupDeployer{},
+		stackMarshaller:  deploy.NewDefaultStackMarshaller(),
+		tgLister:         newSvcExportTGLister(tgManager, time.Hour),
+	}
+	_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: "svc"}})
+	assert.NoError(t, err)
+
+	updated := &anv1alpha1.ServiceExport{}
+	assert.NoError(t, k8sClient.Get(ctx, types.NamespacedName{Namespace: "default", Name: "svc"}, updated))
+	// the upstream CRD has no targetGroups
+	assert.Empty(t, updated.Status.TargetGroups)
+	assert.Len(t, updated.Status.Conditions, 2)
+
+	// nothing is written once up to date
+	_, err = r.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: "svc"}})
+	assert.NoError(t, err)
+	reconciled := &anv1alpha1.ServiceExport{}
+	assert.NoError(t, k8sClient.Get(ctx, types.NamespacedName{Namespace: "default", Name: "svc"}, reconciled))
+	assert.Equal(t, updated.ResourceVersion, reconciled.ResourceVersion)
+}
+
+func TestServiceExportReconciler_FinalizerError(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+	ctx := context.TODO()
+
+	k8sScheme := runtime.NewScheme()
+	clientgoscheme.AddToScheme(k8sScheme)
+	anv1alpha1.AddToScheme(k8sScheme)
+	k8sClient := testclient.NewClientBuilder().WithScheme(k8sScheme).WithObjects(&anv1alpha1.ServiceExport{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   "default",
+			Name:        "svc",
+			Annotations: map[string]string{"application-networking.k8s.aws/federation": "amazon-vpc-lattice"},
+		},
+	}).Build()
+
+	finalizerManager := k8s.NewMockFinalizerManager(c)
+	finalizerManager.EXPECT().AddFinalizers(gomock.Any(), gomock.Any(), serviceExportFinalizer).Return(errors.New("conflict"))
+	eventRecorder := mock_client.NewMockEventRecorder(c)
+	eventRecorder.EXPECT().Event(gomock.Any(), corev1.EventTypeWarning, k8s.ServiceExportEventReasonFailedAddFinalizer,
+		"Failed add finalizer due to conflict")
+
+	r := &serviceExportReconciler{
+		log:              gwlog.FallbackLogger,
+		client:           k8sClient,
+		finalizerManager: finalizerManager,
+		eventRecorder:    eventRecorder,
+	}
+	err := r.reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: "svc"}})
+	assert.EqualError(t, err, "failed to add finalizer to service export svc-default due to conflict")
+}
+
+func TestServiceExportConflictChecker(t *testing.T) {
+	clusterName := config.ClusterName
+	defer func() { config.ClusterName = clusterName }()
+	config.ClusterName = "cluster-1"
+
+	c := gomock.NewController(t)
+	defer c.Finish()
+	ctx := context.TODO()
+
+	noConflict := anv1alpha1.ServiceExportCondition{
+		Type:    anv1alpha1.ServiceExportConflict,
+		Status:  corev1.ConditionFalse,
+		Reason:  aws.String(anv1alpha1.ServiceExportReasonNoConflicts),
+		Message: aws.String("Exports of other clusters are compatible"),
+	}
+	newSvcExport := func(name string, conditions ...anv1alpha1.ServiceExportCondition) *anv1alpha1.ServiceExport {
+		return &anv1alpha1.ServiceExport{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name},
+			Status:     anv1alpha1.ServiceExportStatus{Conditions: conditions},
+		}
+	}
+	k8sScheme := runtime.NewScheme()
+	clientgoscheme.AddToScheme(k8sScheme)
+	anv1alpha1.AddToScheme(k8sScheme)
+	k8sClient := testclient.NewClientBuilder().WithScheme(k8sScheme).WithObjects(
+		newSvcExport("svc", noConflict),
+		newSvcExport("other-svc", noConflict),
+		// not deployed yet
+		newSvcExport("new-svc"),
+	).Build()
+
+	tgManager := lattice.NewMockTargetGroupManager(c)
+	tgManager.EXPECT().ListSvcExportTGs(ctx).Return([]*model.TargetGroup{
+		newSvcExportTG("cluster-1", "vpc-1", "default", "svc", 80, vpclattice.TargetGroupProtocolHttp, vpclattice.TargetGroupProtocolVersionHttp1),
+		newSvcExportTG("cluster-2", "vpc-2", "default", "svc", 80, vpclattice.TargetGroupProtocolHttp, vpclattice.TargetGroupProtocolVersionHttp1),
+		newSvcExportTG("cluster-1", "vpc-1", "default", "other-svc", 80, vpclattice.TargetGroupProtocolHttp, vpclattice.TargetGroupProtocolVersionHttp1),
+		newSvcExportTG("cluster-2", "vpc-2", "default", "other-svc", 8080, vpclattice.TargetGroupProtocolHttp, vpclattice.TargetGroupProtocolVersionHttp1),
+	}, nil)
+
+	checker := &serviceExportConflictChecker{
+		log:      gwlog.FallbackLogger,
+		client:   k8sClient,
+		tgLister: newSvcExportTGLister(tgManager, time.Hour),
+		exports:  make(chan event.GenericEvent, 3),
+	}
+	checker.check(ctx)
+
+	// only the export conflicting with the new port of the other cluster is reconciled
+	assert.Len(t, checker.exports, 1)
+	assert.Equal(t, "other-svc", (<-checker.exports).Object.GetName())
+}
@@ -19,17 +19,35 @@ package controllers
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
 
-	anv1alpha1 "github.com/aws/aws-application-networking-k8s/pkg/apis/applicationnetworking/v1alpha1"
-	"github.com/aws/aws-application-networking-k8s/pkg/utils/gwlog"
-
+	"github.com/aws/aws-sdk-go/service/vpclattice"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 
+	anv1alpha1 "github.com/aws/aws-application-networking-k8s/pkg/apis/applicationnetworking/v1alpha1"
+	"github.com/aws/aws-application-networking-k8s/pkg/aws"
+	"github.com/aws/aws-application-networking-k8s/pkg/config"
+	deploy "github.com/aws/aws-application-networking-k8s/pkg/deploy/lattice"
+	"github.com/aws/aws-application-networking-k8s/pkg/gateway"
 	"github.com/aws/aws-application-networking-k8s/pkg/k8s"
+	model "github.com/aws/aws-application-networking-k8s/pkg/model/lattice"
+	lattice_runtime "github.com/aws/aws-application-networking-k8s/pkg/runtime"
+	"github.com/aws/aws-application-networking-k8s/pkg/utils"
+	"github.com/aws/aws-application-networking-k8s/pkg/utils/gwlog"
 )
 
 type serviceImportReconciler struct {
@@ -38,20 +56,26 @@ type serviceImportReconciler struct {
 	Scheme           *runtime.Scheme
 	finalizerManager k8s.FinalizerManager
 	eventRecorder    record.EventRecorder
+	tgLister         *svcExportTGLister
 }
 
 const (
 	serviceImportFinalizer = "serviceimport.k8s.aws/resource"
+
+	// exports of other clusters trigger no event, their target groups are listed again periodically
+	serviceImportResyncInterval = 5 * time.Minute
 )
 
 func RegisterServiceImportController(
 	log gwlog.Logger,
+	cloud aws.Cloud,
 	mgr ctrl.Manager,
 	finalizerManager k8s.FinalizerManager,
 ) error {
 	mgrClient := mgr.GetClient()
 	scheme := mgr.GetScheme()
 	eventRecorder := mgr.GetEventRecorderFor("ServiceImport")
+	tgLister := newSvcExportTGLister(deploy.NewTargetGroupManager(log, cloud), serviceImportResyncInterval)
 
 	r := &serviceImportReconciler{
 		log:              log,
@@ -59,16 +83,36 @@ func RegisterServiceImportController(
 		Scheme:           scheme,
 		finalizerManager: finalizerManager,
 		eventRecorder:    eventRecorder,
+		tgLister:         tgLister,
+	}
+
+	if len(config.ServiceImportNamespaces) > 0 {
+		discoverer := &serviceImportDiscoverer{
+			log:        log,
+			client:     mgrClient,
+			tgLister:   tgLister,
+			namespaces: utils.NewSet(config.ServiceImportNamespaces...),
+			seen:       utils.NewSet[types.NamespacedName](),
+			interval:   serviceImportResyncInterval,
+		}
+		if err := mgr.Add(discoverer); err != nil {
+			return err
+		}
 	}
 
+	// with the status subresource of the upstream CRD, status updates do not change the generation
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&anv1alpha1.ServiceImport{}).
+		For(&anv1alpha1.ServiceImport{}, builder.WithPredicates(
+			predicate.Or(predicate.GenerationChangedPredicate{}, predicate.AnnotationChangedPredicate{}))).
 		Complete(r)
 }
 
 //+kubebuilder:rbac:groups=application-networking.k8s.aws,resources=serviceimports,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=application-networking.k8s.aws,resources=serviceimports/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=application-networking.k8s.aws,resources=serviceimports/finalizers,verbs=update
+//+kubebuilder:rbac:groups=multicluster.x-k8s.io,resources=serviceimports,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=multicluster.x-k8s.io,resources=serviceimports/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=multicluster.x-k8s.io,resources=serviceimports/finalizers,verbs=update
 
 func (r *serviceImportReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	r.log.Infow("reconcile", "name", req.Name)
@@ -91,6 +135,252 @@ func (r *serviceImportReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 		}
 		r.log.Info("Adding/Updating")
 
-		return ctrl.Result{}, nil
+		if err := r.updateExports(ctx, serviceImport); err != nil {
+			r.log.Infow("reconcile error", "name", req.Name, "message", err.Error())
+			return lattice_runtime.HandleReconcileError(err)
+		}
+		return ctrl.Result{RequeueAfter: serviceImportResyncInterval}, nil
+	}
+}
+
+// updateExports sets the clusters exporting the service of the ServiceImport, their ports and the Ready
+// condition. The ports of the spec are overwritten with the exported ones, unless none is found.
+// The CRD of the controller has no status subresource, spec and status are updated together. The upstream
+// CRD has one, and its status only lists the names of the exporting clusters.
+func (r *serviceImportReconciler) updateExports(ctx context.Context, serviceImport *anv1alpha1.ServiceImport) error {
+	tgs, err := r.tgLister.List(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list exported target groups due to %w", err)
+	}
+	svcImportTg := gateway.NewSvcImportTargetGroup(serviceImport.Namespace, serviceImport.Name, serviceImport.Annotations)
+	tgs = utils.SliceFilter(tgs, func(tg *model.TargetGroup) bool {
+		return svcImportTg.Matches(tg)
+	})
+
+	updated := serviceImport.DeepCopy()
+	clusters := exportingClusters(tgs)
+	if len(tgs) > 0 {
+		updated.Spec.Ports = exportedPorts(tgs)
+	}
+
+	if !hasMulticlusterStatusSubresource() {
+		ready := metav1.Condition{
+			Type:   anv1alpha1.ServiceImportConditionReady,
+			Status: metav1.ConditionTrue,
+			Reason: anv1alpha1.ServiceImportReasonResolved,
+			Message: fmt.Sprintf("Resolved to %d target groups exported from %d clusters",
+				len(tgs), len(clusters)),
+		}
+		if len(tgs) == 0 {
+			ready.Status = metav1.ConditionFalse
+			ready.Reason = anv1alpha1.ServiceImportReasonNoMatchingExports
+			ready.Message = fmt.Sprintf("No target group is exported for service %s-%s", serviceImport.Name, serviceImport.Namespace)
+		}
+		updated.Status.Clusters = clusters
+		updated.Status.Conditions = utils.GetNewConditions(updated.Status.Conditions, ready)
+		if equality.Semantic.DeepEqual(updated, serviceImport) {
+			return nil
+		}
+		return r.client.Update(ctx, updated)
+	}
+
+	if !equality.Semantic.DeepEqual(updated.Spec, serviceImport.Spec) {
+		if err := r.client.Update(ctx, updated); err != nil {
+			return err
+		}
+	}
+	status := anv1alpha1.ServiceImportStatus{}
+	for _, cluster := range clusters {
+		status.Clusters = append(status.Clusters, anv1alpha1.ClusterStatus{Cluster: cluster.Cluster})
+	}
+	if equality.Semantic.DeepEqual(status, serviceImport.Status) {
+		return nil
+	}
+	updated.Status = status
+	return r.client.Status().Update(ctx, updated)
+}
+
+// exportingClusters groups target groups by the cluster exporting them, falling back to their VPC when
+// they have no cluster tag
+func exportingClusters(tgs []*model.TargetGroup) []anv1alpha1.ClusterStatus {
+	var clusters []anv1alpha1.ClusterStatus
+	clusterIndex := make(map[string]int)
+	for _, tg := range tgs {
+		cluster := tg.Spec.K8SClusterName
+		if cluster == "" {
+			cluster = tg.Spec.VpcId
+		}
+		i, ok := clusterIndex[cluster]
+		if !ok {
+			i = len(clusters)
+			clusterIndex[cluster] = i
+			clusters = append(clusters, anv1alpha1.ClusterStatus{Cluster: cluster, VpcId: tg.Spec.VpcId})
+		}
+		clusters[i].Ports = append(clusters[i].Ports, exportedPort(tg))
+	}
+	for i := range clusters {
+		sortPorts(clusters[i].Ports)
+	}
+	sort.Slice(clusters, func(i, j int) bool {
+		return clusters[i].Cluster < clusters[j].Cluster
+	})
+	return clusters
+}
+
+// exportedPorts are the distinct ports of the target groups of all clusters
+func exportedPorts(tgs []*model.TargetGroup) []anv1alpha1.ServicePort {
+	var ports []anv1alpha1.ServicePort
+	names := utils.NewSet[string]()
+	for _, tg := range tgs {
+		port := exportedPort(tg)
+		if names.Contains(port.Name) {
+			continue
+		}
+		names.Put(port.Name)
+		ports = append(ports, port)
+	}
+	sortPorts(ports)
+	return ports
+}
+
+// exportedPort is named after the protocol and port of the target group, e.g. http-80
+func exportedPort(tg *model.TargetGroup) anv1alpha1.ServicePort {
+	port := anv1alpha1.ServicePort{
+		Protocol: corev1.ProtocolTCP,
+		Port:     tg.Spec.Port,
+	}
+	appProtocol := strings.ToLower(tg.Spec.Protocol)
+	if tg.Spec.ProtocolVersion == vpclattice.TargetGroupProtocolVersionGrpc {
+		appProtocol = "grpc"
+	}
+	if tg.Spec.Protocol != vpclattice.TargetGroupProtocolTcp {
+		port.AppProtocol = &appProtocol
+	}
+	port.Name = appProtocol + "-" + strconv.Itoa(int(tg.Spec.Port))
+	return port
+}
+
+func sortPorts(ports []anv1alpha1.ServicePort) {
+	sort.Slice(ports, func(i, j int) bool {
+		if ports[i].Port != ports[j].Port {
+			return ports[i].Port < ports[j].Port
+		}
+		return ports[i].Name < ports[j].Name
+	})
+}
+
+// svcExportTGLister lists the exported target groups at most once per interval. The listing is shared by the
+// reconciles of all ServiceImports and their discovery, which would otherwise each list every target group.
+// The ServiceExport controller uses its own lister, refreshed by its conflict check.
+type svcExportTGLister struct {
+	tgManager deploy.TargetGroupManager
+	interval  time.Duration
+	lock      sync.Mutex
+	tgs       []*model.TargetGroup
+	listedAt  time.Time
+}
+
+func newSvcExportTGLister(tgManager deploy.TargetGroupManager, interval time.Duration) *svcExportTGLister {
+	return &svcExportTGLister{tgManager: tgManager, interval: interval}
+}
+
+// List returns the target groups listed within the interval, concurrent callers wait for the same listing.
+// Failed listings are not cached.
+func (l *svcExportTGLister) List(ctx context.Context) ([]*model.TargetGroup, error) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	if !l.listedAt.IsZero() && time.Since(l.listedAt) < l.interval {
+		return l.tgs, nil
+	}
+	return l.list(ctx)
+}
+
+// Refresh lists the target groups regardless of the previous listing, and shares them with the next callers
+func (l *svcExportTGLister) Refresh(ctx context.Context) ([]*model.TargetGroup, error) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	return l.list(ctx)
+}
+
+func (l *svcExportTGLister) list(ctx context.Context) ([]*model.TargetGroup, error) {
+	tgs, err := l.tgManager.ListSvcExportTGs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	l.tgs, l.listedAt = tgs, time.Now()
+	return tgs, nil
+}
+
+// serviceImportDiscoverer periodically creates the ServiceImports of newly seen exported services in the
+// configured namespaces. A ServiceImport deleted afterwards is only created again once the controller restarts.
+type serviceImportDiscoverer struct {
+	log        gwlog.Logger
+	client     client.Client
+	tgLister   *svcExportTGLister
+	namespaces utils.Set[string]
+	seen       utils.Set[types.NamespacedName]
+	interval   time.Duration
+}
+
+// Start implements manager.Runnable, discovery runs at start then every interval until the context is done
+func (d *serviceImportDiscoverer) Start(ctx context.Context) error {
+	d.log.Infof("Starting ServiceImport discovery in namespaces %s every %s", d.namespaces.Items(), d.interval)
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+	for {
+		d.discover(ctx)
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable, only the leader creates ServiceImports
+func (d *serviceImportDiscoverer) NeedLeaderElection() bool {
+	return true
+}
+
+func (d *serviceImportDiscoverer) discover(ctx context.Context) {
+	tgs, err := d.tgLister.List(ctx)
+	if err != nil {
+		d.log.Errorf("Failed to list exported target groups for ServiceImport discovery due to %s", err)
+		return
+	}
+	svcTgs := make(map[types.NamespacedName][]*model.TargetGroup)
+	for _, tg := range tgs {
+		svc := types.NamespacedName{Namespace: tg.Spec.K8SServiceNamespace, Name: tg.Spec.K8SServiceName}
+		if d.namespaces.Contains(svc.Namespace) && !d.seen.Contains(svc) {
+			svcTgs[svc] = append(svcTgs[svc], tg)
+		}
+	}
+	for svc, tgs := range svcTgs {
+		if err := d.createServiceImport(ctx, svc, tgs); err != nil {
+			d.log.Infof("Failed to create ServiceImport %s-%s due to %s", svc.Name, svc.Namespace, err)
+			continue
+		}
+		d.seen.Put(svc)
+	}
+}
+
+func (d *serviceImportDiscoverer) createServiceImport(ctx context.Context, svc types.NamespacedName, tgs []*model.TargetGroup) error {
+	serviceImport := &anv1alpha1.ServiceImport{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: svc.Namespace,
+			Name:      svc.Name,
+		},
+		Spec: anv1alpha1.ServiceImportSpec{
+			Type:  anv1alpha1.ClusterSetIP,
+			Ports: exportedPorts(tgs),
+		},
+	}
+	err := d.client.Create(ctx, serviceImport)
+	if apierrors.IsAlreadyExists(err) {
+		return nil
+	}
+	if err == nil {
+		d.log.Infof("Created ServiceImport %s-%s for the exported service", svc.Name, svc.Namespace)
 	}
+	return err
 }
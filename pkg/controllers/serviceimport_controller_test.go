@@ -0,0 +1,302 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/vpclattice"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	anv1alpha1 "github.com/aws/aws-application-networking-k8s/pkg/apis/applicationnetworking/v1alpha1"
+	"github.com/aws/aws-application-networking-k8s/pkg/config"
+	deploy "github.com/aws/aws-application-networking-k8s/pkg/deploy/lattice"
+	"github.com/aws/aws-application-networking-k8s/pkg/k8s"
+	model "github.com/aws/aws-application-networking-k8s/pkg/model/lattice"
+	"github.com/aws/aws-application-networking-k8s/pkg/utils"
+	"github.com/aws/aws-application-networking-k8s/pkg/utils/gwlog"
+)
+
+func newSvcExportTG(cluster, vpc, namespace, name string, port int32, protocol, protocolVersion string) *model.TargetGroup {
+	return &model.TargetGroup{
+		Spec: model.TargetGroupSpec{
+			VpcId:           vpc,
+			Port:            port,
+			Protocol:        protocol,
+			ProtocolVersion: protocolVersion,
+			TargetGroupTagFields: model.TargetGroupTagFields{
+				K8SClusterName:      cluster,
+				K8SSourceType:       model.SourceTypeSvcExport,
+				K8SServiceName:      name,
+				K8SServiceNamespace: namespace,
+			},
+		},
+		Status: &model.TargetGroupStatus{Id: cluster + "-" + name},
+	}
+}
+
+func TestServiceImportReconciler_UpdateExports(t *testing.T) {
+	exportedTgs := []*model.TargetGroup{
+		newSvcExportTG("cluster-1", "vpc-1", "default", "svc", 80, vpclattice.TargetGroupProtocolHttp, vpclattice.TargetGroupProtocolVersionHttp1),
+		newSvcExportTG("cluster-2", "vpc-2", "default", "svc", 80, vpclattice.TargetGroupProtocolHttp, vpclattice.TargetGroupProtocolVersionHttp1),
+		newSvcExportTG("cluster-2", "vpc-2", "default", "svc", 50051, vpclattice.TargetGroupProtocolHttps, vpclattice.TargetGroupProtocolVersionGrpc),
+		newSvcExportTG("cluster-1", "vpc-1", "default", "other-svc", 80, vpclattice.TargetGroupProtocolHttp, vpclattice.TargetGroupProtocolVersionHttp1),
+	}
+	httpPort := anv1alpha1.ServicePort{Name: "http-80", Protocol: corev1.ProtocolTCP, AppProtocol: aws.String("http"), Port: 80}
+	grpcPort := anv1alpha1.ServicePort{Name: "grpc-50051", Protocol: corev1.ProtocolTCP, AppProtocol: aws.String("grpc"), Port: 50051}
+
+	tests := []struct {
+		name             string
+		annotations      map[string]string
+		expectedClusters []anv1alpha1.ClusterStatus
+		expectedPorts    []anv1alpha1.ServicePort
+		expectedStatus   metav1.ConditionStatus
+		expectedReason   string
+	}{
+		{
+			name: "exports of all clusters",
+			expectedClusters: []anv1alpha1.ClusterStatus{
+				{Cluster: "cluster-1", VpcId: "vpc-1", Ports: []anv1alpha1.ServicePort{httpPort}},
+				{Cluster: "cluster-2", VpcId: "vpc-2", Ports: []anv1alpha1.ServicePort{httpPort, grpcPort}},
+			},
+			expectedPorts:  []anv1alpha1.ServicePort{httpPort, grpcPort},
+			expectedStatus: metav1.ConditionTrue,
+			expectedReason: anv1alpha1.ServiceImportReasonResolved,
+		},
+		{
+			name:        "exports of the annotated cluster",
+			annotations: map[string]string{"application-networking.k8s.aws/aws-eks-cluster-name": "cluster-1"},
+			expectedClusters: []anv1alpha1.ClusterStatus{
+				{Cluster: "cluster-1", VpcId: "vpc-1", Ports: []anv1alpha1.ServicePort{httpPort}},
+			},
+			expectedPorts:  []anv1alpha1.ServicePort{httpPort},
+			expectedStatus: metav1.ConditionTrue,
+			expectedReason: anv1alpha1.ServiceImportReasonResolved,
+		},
+		{
+			name:           "no export in the annotated VPC",
+			annotations:    map[string]string{"application-networking.k8s.aws/aws-vpc": "vpc-3"},
+			expectedStatus: metav1.ConditionFalse,
+			expectedReason: anv1alpha1.ServiceImportReasonNoMatchingExports,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+			ctx := context.TODO()
+
+			k8sScheme := runtime.NewScheme()
+			clientgoscheme.AddToScheme(k8sScheme)
+			anv1alpha1.AddToScheme(k8sScheme)
+			svcImport := &anv1alpha1.ServiceImport{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "svc", Annotations: tt.annotations},
+				Spec:       anv1alpha1.ServiceImportSpec{Type: anv1alpha1.ClusterSetIP},
+			}
+			k8sClient := testclient.NewClientBuilder().WithScheme(k8sScheme).WithObjects(svcImport).Build()
+
+			finalizerManager := k8s.NewMockFinalizerManager(c)
+			finalizerManager.EXPECT().AddFinalizers(gomock.Any(), gomock.Any(), serviceImportFinalizer).Return(nil)
+			tgManager := deploy.NewMockTargetGroupManager(c)
+			tgManager.EXPECT().ListSvcExportTGs(gomock.Any()).Return(exportedTgs, nil)
+
+			r := &serviceImportReconciler{
+				log:              gwlog.FallbackLogger,
+				client:           k8sClient,
+				finalizerManager: finalizerManager,
+				tgLister:         newSvcExportTGLister(tgManager, serviceImportResyncInterval),
+			}
+			result, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: "svc"}})
+			assert.NoError(t, err)
+			assert.Equal(t, serviceImportResyncInterval, result.RequeueAfter)
+
+			updated := &anv1alpha1.ServiceImport{}
+			assert.NoError(t, k8sClient.Get(ctx, types.NamespacedName{Namespace: "default", Name: "svc"}, updated))
+			assert.Equal(t, tt.expectedClusters, updated.Status.Clusters)
+			assert.Equal(t, tt.expectedPorts, updated.Spec.Ports)
+			assert.Len(t, updated.Status.Conditions, 1)
+			cond := updated.Status.Conditions[0]
+			assert.Equal(t, anv1alpha1.ServiceImportConditionReady, cond.Type)
+			assert.Equal(t, tt.expectedStatus, cond.Status)
+			assert.Equal(t, tt.expectedReason, cond.Reason)
+		})
+	}
+}
+
+func TestServiceImportReconciler_ListError(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+	ctx := context.TODO()
+
+	k8sScheme := runtime.NewScheme()
+	clientgoscheme.AddToScheme(k8sScheme)
+	anv1alpha1.AddToScheme(k8sScheme)
+	k8sClient := testclient.NewClientBuilder().WithScheme(k8sScheme).WithObjects(&anv1alpha1.ServiceImport{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "svc"},
+	}).Build()
+
+	finalizerManager := k8s.NewMockFinalizerManager(c)
+	finalizerManager.EXPECT().AddFinalizers(gomock.Any(), gomock.Any(), serviceImportFinalizer).Return(nil)
+	tgManager := deploy.NewMockTargetGroupManager(c)
+	tgManager.EXPECT().ListSvcExportTGs(gomock.Any()).Return(nil, errors.New("throttled"))
+
+	r := &serviceImportReconciler{
+		log:              gwlog.FallbackLogger,
+		client:           k8sClient,
+		finalizerManager: finalizerManager,
+		tgLister:         newSvcExportTGLister(tgManager, serviceImportResyncInterval),
+	}
+	result, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: "svc"}})
+	assert.NoError(t, err)
+	assert.Equal(t, 20*time.Second, result.RequeueAfter)
+
+	// the status is left as is until target groups can be listed
+	svcImport := &anv1alpha1.ServiceImport{}
+	assert.NoError(t, k8sClient.Get(ctx, types.NamespacedName{Namespace: "default", Name: "svc"}, svcImport))
+	assert.Empty(t, svcImport.Status.Conditions)
+}
+
+func TestServiceImportDiscoverer(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+	ctx := context.TODO()
+
+	k8sScheme := runtime.NewScheme()
+	clientgoscheme.AddToScheme(k8sScheme)
+	anv1alpha1.AddToScheme(k8sScheme)
+	k8sClient := testclient.NewClientBuilder().WithScheme(k8sScheme).Build()
+
+	tgManager := deploy.NewMockTargetGroupManager(c)
+	d := &serviceImportDiscoverer{
+		log:        gwlog.FallbackLogger,
+		client:     k8sClient,
+		tgLister:   newSvcExportTGLister(tgManager, 0),
+		namespaces: utils.NewSet("team-a"),
+		seen:       utils.NewSet[types.NamespacedName](),
+		interval:   time.Minute,
+	}
+
+	tgManager.EXPECT().ListSvcExportTGs(ctx).Return([]*model.TargetGroup{
+		newSvcExportTG("cluster-1", "vpc-1", "team-a", "svc", 80, vpclattice.TargetGroupProtocolHttp, vpclattice.TargetGroupProtocolVersionHttp1),
+		newSvcExportTG("cluster-1", "vpc-1", "team-b", "svc", 80, vpclattice.TargetGroupProtocolHttp, vpclattice.TargetGroupProtocolVersionHttp1),
+	}, nil).Times(2)
+
+	d.discover(ctx)
+	created := &anv1alpha1.ServiceImport{}
+	assert.NoError(t, k8sClient.Get(ctx, types.NamespacedName{Namespace: "team-a", Name: "svc"}, created))
+	assert.Equal(t, anv1alpha1.ClusterSetIP, created.Spec.Type)
+	assert.Equal(t, []anv1alpha1.ServicePort{
+		{Name: "http-80", Protocol: corev1.ProtocolTCP, AppProtocol: aws.String("http"), Port: 80},
+	}, created.Spec.Ports)
+	assert.Error(t, k8sClient.Get(ctx, types.NamespacedName{Namespace: "team-b", Name: "svc"}, &anv1alpha1.ServiceImport{}))
+
+	// a ServiceImport deleted after its export was seen is not created again
+	assert.NoError(t, k8sClient.Delete(ctx, created))
+	d.discover(ctx)
+	assert.Error(t, k8sClient.Get(ctx, types.NamespacedName{Namespace: "team-a", Name: "svc"}, &anv1alpha1.ServiceImport{}))
+}
+
+func TestServiceImportReconciler_UpdateExportsStatusSubresource(t *testing.T) {
+	multiclusterAPIGroup := config.MulticlusterAPIGroup
+	defer func() { config.MulticlusterAPIGroup = multiclusterAPIGroup }()
+	config.MulticlusterAPIGroup = config.MulticlusterAPIGroupUpstream
+
+	c := gomock.NewController(t)
+	defer c.Finish()
+	ctx := context.TODO()
+
+	k8sScheme := runtime.NewScheme()
+	clientgoscheme.AddToScheme(k8sScheme)
+	anv1alpha1.AddToSchemeWithMulticlusterGroup(k8sScheme, anv1alpha1.MulticlusterGroupName)
+	svcImport := &anv1alpha1.ServiceImport{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "svc"},
+		Spec:       anv1alpha1.ServiceImportSpec{Type: anv1alpha1.ClusterSetIP},
+	}
+	// the upstream CRD has a status subresource
+	k8sClient := testclient.NewClientBuilder().WithScheme(k8sScheme).
+		WithObjects(svcImport).
+		WithStatusSubresource(svcImport).
+		Build()
+
+	finalizerManager := k8s.NewMockFinalizerManager(c)
+	finalizerManager.EXPECT().AddFinalizers(gomock.Any(), gomock.Any(), serviceImportFinalizer).Return(nil).Times(2)
+	tgManager := deploy.NewMockTargetGroupManager(c)
+	tgManager.EXPECT().ListSvcExportTGs(gomock.Any()).Return([]*model.TargetGroup{
+		newSvcExportTG("cluster-1", "vpc-1", "default", "svc", 80, vpclattice.TargetGroupProtocolHttp, vpclattice.TargetGroupProtocolVersionHttp1),
+	}, nil)
+
+	r := &serviceImportReconciler{
+		log:              gwlog.FallbackLogger,
+		client:           k8sClient,
+		finalizerManager: finalizerManager,
+		tgLister:         newSvcExportTGLister(tgManager, serviceImportResyncInterval),
+	}
+	_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: "svc"}})
+	assert.NoError(t, err)
+
+	updated := &anv1alpha1.ServiceImport{}
+	assert.NoError(t, k8sClient.Get(ctx, types.NamespacedName{Namespace: "default", Name: "svc"}, updated))
+	assert.Equal(t, []anv1alpha1.ServicePort{
+		{Name: "http-80", Protocol: corev1.ProtocolTCP, AppProtocol: aws.String("http"), Port: 80},
+	}, updated.Spec.Ports)
+	// the upstream CRD only has the names of the clusters
+	assert.Equal(t, anv1alpha1.ServiceImportStatus{Clusters: []anv1alpha1.ClusterStatus{{Cluster: "cluster-1"}}}, updated.Status)
+
+	// nothing is written once up to date
+	_, err = r.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: "svc"}})
+	assert.NoError(t, err)
+	reconciled := &anv1alpha1.ServiceImport{}
+	assert.NoError(t, k8sClient.Get(ctx, types.NamespacedName{Namespace: "default", Name: "svc"}, reconciled))
+	assert.Equal(t, updated.ResourceVersion, reconciled.ResourceVersion)
+}
+
+func TestSvcExportTGLister(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+	ctx := context.TODO()
+
+	tgManager := deploy.NewMockTargetGroupManager(c)
+	l := newSvcExportTGLister(tgManager, time.Minute)
+	tgs := []*model.TargetGroup{
+		newSvcExportTG("cluster-1", "vpc-1", "default", "svc", 80, vpclattice.TargetGroupProtocolHttp, vpclattice.TargetGroupProtocolVersionHttp1),
+	}
+
+	// failures are not cached
+	tgManager.EXPECT().ListSvcExportTGs(ctx).Return(nil, errors.New("throttled"))
+	_, err := l.List(ctx)
+	assert.Error(t, err)
+
+	// target groups are listed once per interval
+	tgManager.EXPECT().ListSvcExportTGs(ctx).Return(tgs, nil)
+	for i := 0; i < 3; i++ {
+		listed, err := l.List(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, tgs, listed)
+	}
+
+	l.listedAt = time.Now().Add(-time.Minute)
+	tgManager.EXPECT().ListSvcExportTGs(ctx).Return(nil, nil)
+	listed, err := l.List(ctx)
+	assert.NoError(t, err)
+	assert.Empty(t, listed)
+
+	// a refresh lists them within the interval, and is shared with the next callers
+	tgManager.EXPECT().ListSvcExportTGs(ctx).Return(tgs, nil)
+	listed, err = l.Refresh(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, tgs, listed)
+	listed, err = l.List(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, tgs, listed)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"time"
 
+	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -14,6 +15,7 @@ import (
 	pkg_aws "github.com/aws/aws-application-networking-k8s/pkg/aws"
 	"github.com/aws/aws-application-networking-k8s/pkg/aws/services"
 	deploy "github.com/aws/aws-application-networking-k8s/pkg/deploy/lattice"
+	"github.com/aws/aws-application-networking-k8s/pkg/gateway"
 	"github.com/aws/aws-application-networking-k8s/pkg/k8s"
 	policy "github.com/aws/aws-application-networking-k8s/pkg/k8s/policyhelper"
 	"github.com/aws/aws-application-networking-k8s/pkg/utils"
@@ -96,7 +98,10 @@ func (c *vpcAssociationPolicyReconciler) upsert(ctx context.Context, k8sPolicy *
 	if err != nil {
 		return err
 	}
-	snName := string(k8sPolicy.Spec.TargetRef.Name)
+	snName, err := c.serviceNetworkName(ctx, k8sPolicy)
+	if err != nil {
+		return err
+	}
 	sgIds := utils.SliceMap(k8sPolicy.Spec.SecurityGroupIds, func(sg anv1alpha1.SecurityGroupId) *string {
 		str := string(sg)
 		return &str
@@ -113,8 +118,11 @@ func (c *vpcAssociationPolicyReconciler) upsert(ctx context.Context, k8sPolicy *
 }
 
 func (c *vpcAssociationPolicyReconciler) delete(ctx context.Context, k8sPolicy *anv1alpha1.VpcAssociationPolicy) error {
-	snName := string(k8sPolicy.Spec.TargetRef.Name)
-	err := c.manager.DeleteVpcAssociation(ctx, snName)
+	snName, err := c.serviceNetworkName(ctx, k8sPolicy)
+	if err != nil {
+		return err
+	}
+	err = c.manager.DeleteVpcAssociation(ctx, snName)
 	if err != nil {
 		return c.handleDeleteError(err)
 	}
@@ -125,6 +133,14 @@ func (c *vpcAssociationPolicyReconciler) delete(ctx context.Context, k8sPolicy *
 	return nil
 }
 
+// the policy targets a gateway in its namespace
+func (c *vpcAssociationPolicyReconciler) serviceNetworkName(ctx context.Context, k8sPolicy *anv1alpha1.VpcAssociationPolicy) (string, error) {
+	return gateway.ServiceNetworkName(ctx, c.client, types.NamespacedName{
+		Namespace: k8sPolicy.Namespace,
+		Name:      string(k8sPolicy.Spec.TargetRef.Name),
+	})
+}
+
 func (c *vpcAssociationPolicyReconciler) handleDeleteError(err error) error {
 	switch {
 	case services.IsNotFoundError(err):
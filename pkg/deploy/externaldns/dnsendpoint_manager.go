@@ -3,6 +3,9 @@ package externaldns
 import (
 	"context"
 	"reflect"
+	"sort"
+	"strconv"
+	"strings"
 
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
@@ -12,6 +15,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/external-dns/endpoint"
 
+	"github.com/aws/aws-application-networking-k8s/pkg/k8s"
 	"github.com/aws/aws-application-networking-k8s/pkg/model/core"
 	latticemodel "github.com/aws/aws-application-networking-k8s/pkg/model/lattice"
 	"github.com/aws/aws-application-networking-k8s/pkg/utils/gwlog"
@@ -19,8 +23,29 @@ import (
 
 //go:generate mockgen -destination dnsendpoint_manager_mock.go -package externaldns github.com/aws/aws-application-networking-k8s/pkg/deploy/externaldns DnsEndpointManager
 
+const (
+	// TTL in seconds of the DNS records, defaults to 300
+	DnsRecordTtlAnnotation = k8s.AnnotationPrefix + "dns-record-ttl"
+	// CNAME (default) or ALIAS, ALIAS records are only supported by some providers such as AWS Route53
+	DnsRecordTypeAnnotation = k8s.AnnotationPrefix + "dns-record-type"
+	// comma separated name=value provider specific properties of the DNS records, e.g. aws/evaluate-target-health=true
+	DnsRecordProviderSpecificAnnotation = k8s.AnnotationPrefix + "dns-record-provider-specific"
+	// comma separated key=value labels of the DNSEndpoint resource, e.g. to match the external-dns --label-filter
+	DnsEndpointLabelsAnnotation = k8s.AnnotationPrefix + "dns-endpoint-labels"
+
+	DnsRecordTypeCname = "CNAME"
+	DnsRecordTypeAlias = "ALIAS"
+
+	defaultDnsRecordTtl = 300
+	// external-dns property turning a CNAME record into an alias record
+	aliasProviderSpecificProperty = "alias"
+)
+
 type DnsEndpointManager interface {
-	Create(ctx context.Context, service *latticemodel.Service) error
+	// Reconcile creates or updates the DNSEndpoint of the service to match its domain names,
+	// and deletes it when the service has none
+	Reconcile(ctx context.Context, service *latticemodel.Service) error
+	Delete(ctx context.Context, service *latticemodel.Service) error
 }
 
 type defaultDnsEndpointManager struct {
@@ -35,14 +60,27 @@ func NewDnsEndpointManager(log gwlog.Logger, k8sClient client.Client) *defaultDn
 	}
 }
 
-func (s *defaultDnsEndpointManager) Create(ctx context.Context, service *latticemodel.Service) error {
-	namespacedName := types.NamespacedName{
-		Namespace: service.Spec.RouteNamespace,
-		Name:      service.Spec.RouteName + "-dns",
+// DNS record settings a route can set through annotations
+type dnsRecordConfig struct {
+	ttl              endpoint.TTL
+	alias            bool
+	providerSpecific endpoint.ProviderSpecific
+	labels           map[string]string
+}
+
+func dnsEndpointNamespacedName(service *latticemodel.Service) types.NamespacedName {
+	routeName, _ := service.Spec.DnsRoute()
+	return types.NamespacedName{
+		Namespace: routeName.Namespace,
+		Name:      routeName.Name + "-dns",
 	}
+}
+
+func (s *defaultDnsEndpointManager) Reconcile(ctx context.Context, service *latticemodel.Service) error {
+	namespacedName := dnsEndpointNamespacedName(service)
 	if service.Spec.CustomerDomainName == "" {
-		s.log.Debugf("Skipping creation of %s: detected no custom domain", namespacedName)
-		return nil
+		s.log.Debugf("Deleting %s if present: detected no custom domain", namespacedName)
+		return s.Delete(ctx, service)
 	}
 	if service.Status == nil || service.Status.Dns == "" {
 		s.log.Debugf("Skipping creation of %s: DNS target not ready in svc status", namespacedName)
@@ -53,13 +91,10 @@ func (s *defaultDnsEndpointManager) Create(ctx context.Context, service *lattice
 		route core.Route
 		err   error
 	)
-	routeNamespacedName := types.NamespacedName{
-		Namespace: service.Spec.RouteNamespace,
-		Name:      service.Spec.RouteName,
-	}
-	if service.Spec.RouteType == core.GrpcRouteType {
+	routeNamespacedName, routeType := service.Spec.DnsRoute()
+	if routeType == core.GrpcRouteType {
 		route, err = core.GetGRPCRoute(ctx, s.k8sClient, routeNamespacedName)
-	} else if service.Spec.RouteType == core.TlsRouteType {
+	} else if routeType == core.TlsRouteType {
 		route, err = core.GetTLSRoute(ctx, s.k8sClient, routeNamespacedName)
 	} else {
 		route, err = core.GetHTTPRoute(ctx, s.k8sClient, routeNamespacedName)
@@ -69,27 +104,21 @@ func (s *defaultDnsEndpointManager) Create(ctx context.Context, service *lattice
 		return nil
 	}
 
+	cfg := s.dnsRecordConfigFromAnnotations(route.K8sObject().GetAnnotations())
+
 	ep := &endpoint.DNSEndpoint{}
 	if err := s.k8sClient.Get(ctx, namespacedName, ep); err != nil {
 		if apierrors.IsNotFound(err) {
-			s.log.Debugf("Attempting creation of DNSEndpoint for %s - %s -> %s",
-				namespacedName.String(), service.Spec.CustomerDomainName, service.Status.Dns)
+			s.log.Debugf("Attempting creation of DNSEndpoint for %s - %s -> %s", namespacedName.String(),
+				service.Spec.CustomerDomainName, service.Status.Dns)
 			ep = &endpoint.DNSEndpoint{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      namespacedName.Name,
 					Namespace: namespacedName.Namespace,
+					Labels:    cfg.labels,
 				},
 				Spec: endpoint.DNSEndpointSpec{
-					Endpoints: []*endpoint.Endpoint{
-						{
-							DNSName: service.Spec.CustomerDomainName,
-							Targets: []string{
-								service.Status.Dns,
-							},
-							RecordType: "CNAME",
-							RecordTTL:  300,
-						},
-					},
+					Endpoints: buildEndpoints(service, cfg),
 				},
 			}
 			controllerutil.SetControllerReference(route.K8sObject(), ep, s.k8sClient.Scheme())
@@ -103,20 +132,12 @@ func (s *defaultDnsEndpointManager) Create(ctx context.Context, service *lattice
 			return err
 		}
 	} else {
-		s.log.Debugf("Attempting update of DNSEndpoint for %s - %s -> %s",
-			namespacedName.String(), service.Spec.CustomerDomainName, service.Status.Dns)
+		s.log.Debugf("Attempting update of DNSEndpoint for %s - %s -> %s", namespacedName.String(),
+			service.Spec.CustomerDomainName, service.Status.Dns)
 		old := ep.DeepCopy()
-		ep.Spec.Endpoints = []*endpoint.Endpoint{
-			{
-				DNSName: service.Spec.CustomerDomainName,
-				Targets: []string{
-					service.Status.Dns,
-				},
-				RecordType: "CNAME",
-				RecordTTL:  300,
-			},
-		}
-		if !reflect.DeepEqual(ep.Spec.Endpoints, old.Spec.Endpoints) {
+		ep.Spec.Endpoints = buildEndpoints(service, cfg)
+		ep.Labels = cfg.labels
+		if !reflect.DeepEqual(ep.Spec.Endpoints, old.Spec.Endpoints) || !reflect.DeepEqual(ep.Labels, old.Labels) {
 			if err = s.k8sClient.Patch(ctx, ep, client.MergeFrom(old)); err != nil {
 				return err
 			}
@@ -124,3 +145,118 @@ func (s *defaultDnsEndpointManager) Create(ctx context.Context, service *lattice
 	}
 	return nil
 }
+
+// Deletes the DNSEndpoint of the service, DNSEndpoints not owned by the service route are left untouched
+func (s *defaultDnsEndpointManager) Delete(ctx context.Context, service *latticemodel.Service) error {
+	routeName, _ := service.Spec.DnsRoute()
+	if routeName.Name == "" {
+		return nil
+	}
+	namespacedName := dnsEndpointNamespacedName(service)
+	ep := &endpoint.DNSEndpoint{}
+	if err := s.k8sClient.Get(ctx, namespacedName, ep); err != nil {
+		if apierrors.IsNotFound(err) || meta.IsNoMatchError(err) {
+			return nil
+		}
+		return err
+	}
+
+	owner := metav1.GetControllerOf(ep)
+	if owner == nil || owner.Name != routeName.Name {
+		s.log.Infof("Skipping deletion of DNSEndpoint %s: not owned by route %s",
+			namespacedName, routeName.Name)
+		return nil
+	}
+
+	s.log.Debugf("Deleting DNSEndpoint %s", namespacedName)
+	if err := s.k8sClient.Delete(ctx, ep); err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+// invalid annotation values are logged and ignored, defaults are used instead
+func (s *defaultDnsEndpointManager) dnsRecordConfigFromAnnotations(annotations map[string]string) dnsRecordConfig {
+	cfg := dnsRecordConfig{
+		ttl: defaultDnsRecordTtl,
+	}
+
+	if value, ok := annotations[DnsRecordTtlAnnotation]; ok {
+		ttl, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || ttl <= 0 {
+			s.log.Infof("failed to read Annotations/%s: %s, using default TTL %d",
+				DnsRecordTtlAnnotation, value, defaultDnsRecordTtl)
+		} else {
+			cfg.ttl = endpoint.TTL(ttl)
+		}
+	}
+
+	if value, ok := annotations[DnsRecordTypeAnnotation]; ok {
+		switch strings.ToUpper(value) {
+		case DnsRecordTypeCname:
+		case DnsRecordTypeAlias:
+			cfg.alias = true
+		default:
+			s.log.Infof("failed to read Annotations/%s: %s, using record type %s",
+				DnsRecordTypeAnnotation, value, DnsRecordTypeCname)
+		}
+	}
+
+	for name, value := range s.parseKeyValues(DnsRecordProviderSpecificAnnotation, annotations) {
+		cfg.providerSpecific = append(cfg.providerSpecific, endpoint.ProviderSpecificProperty{
+			Name:  name,
+			Value: value,
+		})
+	}
+	// map iteration order is random, keep the records stable
+	sort.Slice(cfg.providerSpecific, func(i, j int) bool {
+		return cfg.providerSpecific[i].Name < cfg.providerSpecific[j].Name
+	})
+
+	if labels := s.parseKeyValues(DnsEndpointLabelsAnnotation, annotations); len(labels) > 0 {
+		cfg.labels = labels
+	}
+	return cfg
+}
+
+func (s *defaultDnsEndpointManager) parseKeyValues(annotation string, annotations map[string]string) map[string]string {
+	value, ok := annotations[annotation]
+	if !ok {
+		return nil
+	}
+	keyValues := map[string]string{}
+	for _, pair := range strings.Split(value, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		k, v, found := strings.Cut(pair, "=")
+		if !found || strings.TrimSpace(k) == "" {
+			s.log.Infof("failed to read Annotations/%s: invalid entry %s", annotation, pair)
+			continue
+		}
+		keyValues[strings.TrimSpace(k)] = strings.TrimSpace(v)
+	}
+	return keyValues
+}
+
+// the record of the service custom domain name to the lattice service DNS. Other route hostnames are not
+// published, lattice only routes requests for the custom domain name to the service.
+func buildEndpoints(service *latticemodel.Service, cfg dnsRecordConfig) []*endpoint.Endpoint {
+	ep := &endpoint.Endpoint{
+		DNSName: service.Spec.CustomerDomainName,
+		Targets: []string{
+			service.Status.Dns,
+		},
+		RecordType: "CNAME",
+		RecordTTL:  cfg.ttl,
+	}
+	if cfg.alias {
+		ep.ProviderSpecific = append(ep.ProviderSpecific, endpoint.ProviderSpecificProperty{
+			Name:  aliasProviderSpecificProperty,
+			Value: "true",
+		})
+	}
+	ep.ProviderSpecific = append(ep.ProviderSpecific, cfg.providerSpecific...)
+	return []*endpoint.Endpoint{ep}
+}
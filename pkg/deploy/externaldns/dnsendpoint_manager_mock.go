@@ -35,16 +35,30 @@ func (m *MockDnsEndpointManager) EXPECT() *MockDnsEndpointManagerMockRecorder {
 	return m.recorder
 }
 
-// Create mocks base method.
-func (m *MockDnsEndpointManager) Create(arg0 context.Context, arg1 *lattice.Service) error {
+// Delete mocks base method.
+func (m *MockDnsEndpointManager) Delete(arg0 context.Context, arg1 *lattice.Service) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Create", arg0, arg1)
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// Create indicates an expected call of Create.
-func (mr *MockDnsEndpointManagerMockRecorder) Create(arg0, arg1 interface{}) *gomock.Call {
+// Delete indicates an expected call of Delete.
+func (mr *MockDnsEndpointManagerMockRecorder) Delete(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockDnsEndpointManager)(nil).Create), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockDnsEndpointManager)(nil).Delete), arg0, arg1)
+}
+
+// Reconcile mocks base method.
+func (m *MockDnsEndpointManager) Reconcile(arg0 context.Context, arg1 *lattice.Service) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reconcile", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Reconcile indicates an expected call of Reconcile.
+func (mr *MockDnsEndpointManagerMockRecorder) Reconcile(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reconcile", reflect.TypeOf((*MockDnsEndpointManager)(nil).Reconcile), arg0, arg1)
 }
@@ -9,17 +9,20 @@ import (
 	model "github.com/aws/aws-application-networking-k8s/pkg/model/lattice"
 	"github.com/aws/aws-application-networking-k8s/pkg/utils/gwlog"
 
+	"github.com/aws/aws-sdk-go/aws"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/external-dns/endpoint"
+	gwv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 )
 
-func TestCreateDnsEndpoint(t *testing.T) {
+func TestReconcileDnsEndpoint(t *testing.T) {
 	c := gomock.NewController(t)
 	defer c.Finish()
 
@@ -27,12 +30,14 @@ func TestCreateDnsEndpoint(t *testing.T) {
 		name             string
 		service          model.Service
 		existingEndpoint endpoint.DNSEndpoint
+		routeAnnotations map[string]string
 		routeGetErr      error
 		dnsGetErr        error
 		dnsCreateErr     error
 		dnsUpdateErr     error
 		created          bool
 		updated          bool
+		deleted          bool
 		errIsNil         bool
 	}{
 		{
@@ -49,6 +54,32 @@ func TestCreateDnsEndpoint(t *testing.T) {
 					Dns: "lattice-internal-domain",
 				},
 			},
+			dnsGetErr: apierrors.NewNotFound(schema.GroupResource{}, ""),
+			errIsNil:  true,
+		},
+		{
+			name: "No customer domain name - deletes DNSEndpoint owned by the route",
+			service: model.Service{
+				Spec: model.ServiceSpec{
+					ServiceTagFields: model.ServiceTagFields{
+						RouteName:      "service",
+						RouteNamespace: "default",
+					},
+					CustomerDomainName: "",
+				},
+				Status: &model.ServiceStatus{
+					Dns: "lattice-internal-domain",
+				},
+			},
+			existingEndpoint: ownedEndpoint("service", []*endpoint.Endpoint{
+				{
+					DNSName:    "custom-domain",
+					Targets:    []string{"lattice-internal-domain"},
+					RecordType: "CNAME",
+					RecordTTL:  300,
+				},
+			}),
+			deleted:  true,
 			errIsNil: true,
 		},
 		{
@@ -179,6 +210,106 @@ func TestCreateDnsEndpoint(t *testing.T) {
 			updated:  false,
 			errIsNil: true,
 		},
+		{
+			name: "Update DNSEndpoint removing records of hostnames the service does not serve",
+			service: model.Service{
+				Spec: model.ServiceSpec{
+					ServiceTagFields: model.ServiceTagFields{
+						RouteName:      "service",
+						RouteNamespace: "default",
+					},
+					CustomerDomainName: "custom-domain",
+				},
+				Status: &model.ServiceStatus{
+					Dns: "lattice-internal-domain",
+				},
+			},
+			existingEndpoint: endpoint.DNSEndpoint{
+				Spec: endpoint.DNSEndpointSpec{
+					Endpoints: []*endpoint.Endpoint{
+						{
+							DNSName:    "custom-domain",
+							Targets:    []string{"lattice-internal-domain"},
+							RecordType: "CNAME",
+							RecordTTL:  300,
+						},
+						{
+							DNSName:    "global-domain",
+							Targets:    []string{"lattice-internal-domain"},
+							RecordType: "CNAME",
+							RecordTTL:  300,
+						},
+					},
+				},
+			},
+			updated:  true,
+			errIsNil: true,
+		},
+		{
+			name: "Update DNSEndpoint when record annotations change",
+			service: model.Service{
+				Spec: model.ServiceSpec{
+					ServiceTagFields: model.ServiceTagFields{
+						RouteName:      "service",
+						RouteNamespace: "default",
+					},
+					CustomerDomainName: "custom-domain",
+				},
+				Status: &model.ServiceStatus{
+					Dns: "lattice-internal-domain",
+				},
+			},
+			routeAnnotations: map[string]string{
+				DnsRecordTtlAnnotation:  "60",
+				DnsRecordTypeAnnotation: "alias",
+			},
+			existingEndpoint: endpoint.DNSEndpoint{
+				Spec: endpoint.DNSEndpointSpec{
+					Endpoints: []*endpoint.Endpoint{
+						{
+							DNSName:    "custom-domain",
+							Targets:    []string{"lattice-internal-domain"},
+							RecordType: "CNAME",
+							RecordTTL:  300,
+						},
+					},
+				},
+			},
+			updated:  true,
+			errIsNil: true,
+		},
+		{
+			name: "Update DNSEndpoint when labels annotation changes",
+			service: model.Service{
+				Spec: model.ServiceSpec{
+					ServiceTagFields: model.ServiceTagFields{
+						RouteName:      "service",
+						RouteNamespace: "default",
+					},
+					CustomerDomainName: "custom-domain",
+				},
+				Status: &model.ServiceStatus{
+					Dns: "lattice-internal-domain",
+				},
+			},
+			routeAnnotations: map[string]string{
+				DnsEndpointLabelsAnnotation: "dns-zone=private",
+			},
+			existingEndpoint: endpoint.DNSEndpoint{
+				Spec: endpoint.DNSEndpointSpec{
+					Endpoints: []*endpoint.Endpoint{
+						{
+							DNSName:    "custom-domain",
+							Targets:    []string{"lattice-internal-domain"},
+							RecordType: "CNAME",
+							RecordTTL:  300,
+						},
+					},
+				},
+			},
+			updated:  true,
+			errIsNil: true,
+		},
 		{
 			name: "Return error on update failure",
 			service: model.Service{
@@ -258,7 +389,12 @@ func TestCreateDnsEndpoint(t *testing.T) {
 			mockClient.EXPECT().Get(gomock.Any(), gomock.Eq(types.NamespacedName{
 				Namespace: tt.service.Spec.RouteNamespace,
 				Name:      tt.service.Spec.RouteName,
-			}), gomock.Any()).Return(tt.routeGetErr).AnyTimes()
+			}), gomock.Any()).DoAndReturn(func(ctx context.Context, name types.NamespacedName, route *gwv1beta1.HTTPRoute, _ ...interface{}) error {
+				route.Name = name.Name
+				route.Namespace = name.Namespace
+				route.Annotations = tt.routeAnnotations
+				return tt.routeGetErr
+			}).AnyTimes()
 
 			mockClient.EXPECT().Get(gomock.Any(), gomock.Eq(types.NamespacedName{
 				Namespace: tt.service.Spec.RouteNamespace,
@@ -281,7 +417,14 @@ func TestCreateDnsEndpoint(t *testing.T) {
 				patchCall.Times(0)
 			}
 
-			err := mgr.Create(context.Background(), &tt.service)
+			deleteCall := mockClient.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(nil)
+			if tt.deleted {
+				deleteCall.Times(1)
+			} else {
+				deleteCall.Times(0)
+			}
+
+			err := mgr.Reconcile(context.Background(), &tt.service)
 			if tt.errIsNil {
 				assert.Nil(t, err)
 			} else {
@@ -290,3 +433,197 @@ func TestCreateDnsEndpoint(t *testing.T) {
 		})
 	}
 }
+
+func TestDeleteDnsEndpoint(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	service := model.Service{
+		Spec: model.ServiceSpec{
+			ServiceTagFields: model.ServiceTagFields{
+				RouteName:      "service",
+				RouteNamespace: "default",
+			},
+			CustomerDomainName: "custom-domain",
+		},
+	}
+
+	tests := []struct {
+		name             string
+		existingEndpoint endpoint.DNSEndpoint
+		dnsGetErr        error
+		dnsDeleteErr     error
+		deleted          bool
+		errIsNil         bool
+	}{
+		{
+			name:      "Nothing to delete when DNSEndpoint does not exist",
+			dnsGetErr: apierrors.NewNotFound(schema.GroupResource{}, ""),
+			errIsNil:  true,
+		},
+		{
+			name: "Nothing to delete when DNSEndpoint CRD is not found",
+			dnsGetErr: &meta.NoKindMatchError{
+				GroupKind:        schema.GroupKind{},
+				SearchedVersions: []string{},
+			},
+			errIsNil: true,
+		},
+		{
+			name:             "Delete DNSEndpoint owned by the route",
+			existingEndpoint: ownedEndpoint("service", nil),
+			deleted:          true,
+			errIsNil:         true,
+		},
+		{
+			name:             "Skip DNSEndpoint not owned by the route",
+			existingEndpoint: ownedEndpoint("another-route", nil),
+			errIsNil:         true,
+		},
+		{
+			name:     "Skip DNSEndpoint without owner",
+			errIsNil: true,
+		},
+		{
+			name:             "Return error on deletion failure",
+			existingEndpoint: ownedEndpoint("service", nil),
+			dnsDeleteErr:     errors.New("DNS deletion failed"),
+			deleted:          true,
+			errIsNil:         false,
+		},
+		{
+			name:      "Return error on unexpected lookup failure",
+			dnsGetErr: errors.New("Unhandled exception"),
+			errIsNil:  false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := mock_client.NewMockClient(c)
+			mgr := NewDnsEndpointManager(gwlog.FallbackLogger, mockClient)
+
+			mockClient.EXPECT().Get(gomock.Any(), gomock.Eq(types.NamespacedName{
+				Namespace: "default",
+				Name:      "service-dns",
+			}), gomock.Any()).DoAndReturn(func(ctx context.Context, name types.NamespacedName, ep *endpoint.DNSEndpoint, _ ...interface{}) error {
+				tt.existingEndpoint.DeepCopyInto(ep)
+				return tt.dnsGetErr
+			})
+
+			deleteCall := mockClient.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(tt.dnsDeleteErr)
+			if tt.deleted {
+				deleteCall.Times(1)
+			} else {
+				deleteCall.Times(0)
+			}
+
+			err := mgr.Delete(context.Background(), &service)
+			if tt.errIsNil {
+				assert.Nil(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestDnsRecordConfigFromAnnotations(t *testing.T) {
+	mgr := NewDnsEndpointManager(gwlog.FallbackLogger, nil)
+
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		want        dnsRecordConfig
+	}{
+		{
+			name: "Defaults without annotations",
+			want: dnsRecordConfig{ttl: 300},
+		},
+		{
+			name: "All annotations",
+			annotations: map[string]string{
+				DnsRecordTtlAnnotation:              "60",
+				DnsRecordTypeAnnotation:             "ALIAS",
+				DnsRecordProviderSpecificAnnotation: "aws/weight=10, aws/evaluate-target-health=true",
+				DnsEndpointLabelsAnnotation:         "dns-zone=private",
+			},
+			want: dnsRecordConfig{
+				ttl:   60,
+				alias: true,
+				providerSpecific: endpoint.ProviderSpecific{
+					{Name: "aws/evaluate-target-health", Value: "true"},
+					{Name: "aws/weight", Value: "10"},
+				},
+				labels: map[string]string{"dns-zone": "private"},
+			},
+		},
+		{
+			name: "Invalid values fall back to defaults",
+			annotations: map[string]string{
+				DnsRecordTtlAnnotation:              "-1",
+				DnsRecordTypeAnnotation:             "A",
+				DnsRecordProviderSpecificAnnotation: "no-value",
+				DnsEndpointLabelsAnnotation:         "=empty-key",
+			},
+			want: dnsRecordConfig{ttl: 300},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, mgr.dnsRecordConfigFromAnnotations(tt.annotations))
+		})
+	}
+}
+
+func TestBuildEndpoints(t *testing.T) {
+	service := &model.Service{
+		Spec: model.ServiceSpec{
+			CustomerDomainName: "regional.example.com",
+		},
+		Status: &model.ServiceStatus{
+			Dns: "lattice-internal-domain",
+		},
+	}
+
+	endpoints := buildEndpoints(service, dnsRecordConfig{ttl: 300})
+
+	assert.Len(t, endpoints, 1)
+	assert.Equal(t, "regional.example.com", endpoints[0].DNSName)
+	assert.Equal(t, []string{"lattice-internal-domain"}, []string(endpoints[0].Targets))
+	assert.Equal(t, "CNAME", endpoints[0].RecordType)
+	assert.Equal(t, endpoint.TTL(300), endpoints[0].RecordTTL)
+	assert.Empty(t, endpoints[0].ProviderSpecific)
+
+	endpoints = buildEndpoints(service, dnsRecordConfig{
+		ttl:              60,
+		alias:            true,
+		providerSpecific: endpoint.ProviderSpecific{{Name: "aws/evaluate-target-health", Value: "true"}},
+	})
+	for _, ep := range endpoints {
+		assert.Equal(t, endpoint.TTL(60), ep.RecordTTL)
+		assert.Equal(t, endpoint.ProviderSpecific{
+			{Name: "alias", Value: "true"},
+			{Name: "aws/evaluate-target-health", Value: "true"},
+		}, ep.ProviderSpecific)
+	}
+}
+
+func ownedEndpoint(routeName string, endpoints []*endpoint.Endpoint) endpoint.DNSEndpoint {
+	return endpoint.DNSEndpoint{
+		ObjectMeta: metav1.ObjectMeta{
+			OwnerReferences: []metav1.OwnerReference{
+				{
+					APIVersion: gwv1beta1.GroupVersion.String(),
+					Kind:       "HTTPRoute",
+					Name:       routeName,
+					Controller: aws.Bool(true),
+				},
+			},
+		},
+		Spec: endpoint.DNSEndpointSpec{
+			Endpoints: endpoints,
+		},
+	}
+}
@@ -8,7 +8,9 @@ import (
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"sigs.k8s.io/gateway-api/apis/v1alpha2"
 
 	mockclient "github.com/aws/aws-application-networking-k8s/mocks/controller-runtime/client"
@@ -25,6 +27,9 @@ func TestSynthesizeAccessLogSubscription(t *testing.T) {
 	mockManager := NewMockAccessLogSubscriptionManager(c)
 	k8sClient := mockclient.NewMockClient(c)
 	builder := gateway.NewAccessLogSubscriptionModelBuilder(gwlog.FallbackLogger, k8sClient)
+	// targeted gateways are not found, their service networks are named after them
+	k8sClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(apierrors.NewNotFound(schema.GroupResource{}, "")).AnyTimes()
 
 	t.Run("SpecIsCreated_CreatesAccessLogSubscription", func(t *testing.T) {
 		input := &anv1alpha1.AccessLogPolicy{
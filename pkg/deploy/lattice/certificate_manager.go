@@ -0,0 +1,183 @@
+package lattice
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/acm"
+	"golang.org/x/exp/slices"
+
+	pkg_aws "github.com/aws/aws-application-networking-k8s/pkg/aws"
+	"github.com/aws/aws-application-networking-k8s/pkg/aws/services"
+	model "github.com/aws/aws-application-networking-k8s/pkg/model/lattice"
+	"github.com/aws/aws-application-networking-k8s/pkg/utils/gwlog"
+)
+
+//go:generate mockgen -destination certificate_manager_mock.go -package lattice github.com/aws/aws-application-networking-k8s/pkg/deploy/lattice CertificateManager
+
+type CertificateManager interface {
+	// Upsert imports the certificate into ACM and returns its arn. When certArn is set, the certificate
+	// is re-imported into it if the secret has changed since the last import.
+	Upsert(ctx context.Context, certArn string, cert *model.Certificate) (string, error)
+	// Delete deletes the certificate, which fails while a lattice service still uses it
+	Delete(ctx context.Context, certArn string) error
+}
+
+type defaultCertificateManager struct {
+	log   gwlog.Logger
+	cloud pkg_aws.Cloud
+}
+
+func NewCertificateManager(log gwlog.Logger, cloud pkg_aws.Cloud) *defaultCertificateManager {
+	return &defaultCertificateManager{
+		log:   log,
+		cloud: cloud,
+	}
+}
+
+func (m *defaultCertificateManager) Upsert(ctx context.Context, certArn string, cert *model.Certificate) (string, error) {
+	if certArn != "" {
+		tagsResp, err := m.cloud.ACM().ListTagsForCertificateWithContext(ctx, &acm.ListTagsForCertificateInput{
+			CertificateArn: aws.String(certArn),
+		})
+		if err != nil && !services.IsACMNotFoundError(err) {
+			return "", err
+		}
+		if err == nil {
+			return certArn, m.reimport(ctx, certArn, services.FromACMTags(tagsResp.Tags), cert)
+		}
+		m.log.Infof("Certificate %s of secret %s/%s was deleted, importing it again",
+			certArn, cert.Spec.SecretNamespace, cert.Spec.SecretName)
+	}
+
+	// the arn is only recorded once imported, the certificate may have been imported without it being recorded
+	importedArn, tags, err := m.findImported(ctx, cert)
+	if err != nil {
+		return "", err
+	}
+	if importedArn != "" {
+		m.log.Infof("Found certificate %s of secret %s/%s imported before",
+			importedArn, cert.Spec.SecretNamespace, cert.Spec.SecretName)
+		return importedArn, m.reimport(ctx, importedArn, tags, cert)
+	}
+
+	resp, err := m.cloud.ACM().ImportCertificateWithContext(ctx, &acm.ImportCertificateInput{
+		Certificate:      cert.Certificate,
+		PrivateKey:       cert.PrivateKey,
+		CertificateChain: cert.CertificateChain,
+		Tags:             services.ToACMTags(m.cloud.DefaultTagsMergedWith(cert.Spec.ToTags())),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to import certificate of secret %s/%s: %w",
+			cert.Spec.SecretNamespace, cert.Spec.SecretName, err)
+	}
+	m.log.Infof("Imported certificate %s of secret %s/%s",
+		aws.StringValue(resp.CertificateArn), cert.Spec.SecretNamespace, cert.Spec.SecretName)
+	return aws.StringValue(resp.CertificateArn), nil
+}
+
+// finds the certificate the controller imported for the secret and gateway of the spec, by its tags. Tags are
+// only listed for the imported certificates with a domain name of the certificate, not for the whole account.
+func (m *defaultCertificateManager) findImported(ctx context.Context, cert *model.Certificate) (string, services.Tags, error) {
+	spec := cert.Spec
+	var certArns []*string
+	input := &acm.ListCertificatesInput{
+		// only RSA_1024 and RSA_2048 certificates are listed by default
+		Includes: &acm.Filters{KeyTypes: aws.StringSlice(acm.KeyAlgorithm_Values())},
+	}
+	err := m.cloud.ACM().ListCertificatesPagesWithContext(ctx, input, func(page *acm.ListCertificatesOutput, lastPage bool) bool {
+		for _, summary := range page.CertificateSummaryList {
+			if isImportCandidate(summary, cert) {
+				certArns = append(certArns, summary.CertificateArn)
+			}
+		}
+		return true
+	})
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to list certificates: %w", err)
+	}
+
+	for _, certArn := range certArns {
+		tagsResp, err := m.cloud.ACM().ListTagsForCertificateWithContext(ctx, &acm.ListTagsForCertificateInput{
+			CertificateArn: certArn,
+		})
+		if err != nil {
+			if services.IsACMNotFoundError(err) {
+				continue
+			}
+			return "", nil, err
+		}
+		tags := services.FromACMTags(tagsResp.Tags)
+		if !m.cloud.IsManagedByTags(tags) {
+			continue
+		}
+		found := model.CertificateSpecFromTags(tags)
+		found.Fingerprint = spec.Fingerprint
+		if found == spec {
+			return aws.StringValue(certArn), tags, nil
+		}
+	}
+	return "", nil, nil
+}
+
+// the certificate may have been imported under any of its domain names. The previous content of the secret
+// may have had other names, its certificate is then imported again rather than re-imported.
+func isImportCandidate(summary *acm.CertificateSummary, cert *model.Certificate) bool {
+	if summary.Type != nil && aws.StringValue(summary.Type) != acm.CertificateTypeImported {
+		return false
+	}
+	if len(cert.DomainNames) == 0 || summary.DomainName == nil {
+		return true
+	}
+	return slices.Contains(cert.DomainNames, strings.ToLower(aws.StringValue(summary.DomainName)))
+}
+
+// re-importing keeps the certificate arn, the lattice services using it do not need to be updated
+func (m *defaultCertificateManager) reimport(ctx context.Context, certArn string, tags services.Tags, cert *model.Certificate) error {
+	if !m.cloud.IsManagedByTags(tags) {
+		return services.NewConflictError("certificate", certArn, "certificate is not managed by the controller")
+	}
+	if model.CertificateSpecFromTags(tags).Fingerprint == cert.Spec.Fingerprint {
+		m.log.Debugf("Certificate %s is up to date", certArn)
+		return nil
+	}
+
+	_, err := m.cloud.ACM().ImportCertificateWithContext(ctx, &acm.ImportCertificateInput{
+		CertificateArn:   aws.String(certArn),
+		Certificate:      cert.Certificate,
+		PrivateKey:       cert.PrivateKey,
+		CertificateChain: cert.CertificateChain,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to re-import certificate %s of secret %s/%s: %w",
+			certArn, cert.Spec.SecretNamespace, cert.Spec.SecretName, err)
+	}
+	// tags cannot be set when re-importing
+	_, err = m.cloud.ACM().AddTagsToCertificateWithContext(ctx, &acm.AddTagsToCertificateInput{
+		CertificateArn: aws.String(certArn),
+		Tags: services.ToACMTags(services.Tags{
+			model.K8SCertificateFingerprintKey: aws.String(cert.Spec.Fingerprint),
+		}),
+	})
+	if err != nil {
+		return err
+	}
+	m.log.Infof("Re-imported certificate %s of secret %s/%s", certArn, cert.Spec.SecretNamespace, cert.Spec.SecretName)
+	return nil
+}
+
+func (m *defaultCertificateManager) Delete(ctx context.Context, certArn string) error {
+	_, err := m.cloud.ACM().DeleteCertificateWithContext(ctx, &acm.DeleteCertificateInput{
+		CertificateArn: aws.String(certArn),
+	})
+	if err != nil {
+		if services.IsACMNotFoundError(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to delete certificate %s: %w", certArn, err)
+	}
+	m.log.Infof("Deleted certificate %s", certArn)
+	return nil
+}
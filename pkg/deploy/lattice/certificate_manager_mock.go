@@ -0,0 +1,65 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/aws/aws-application-networking-k8s/pkg/deploy/lattice (interfaces: CertificateManager)
+
+// Package lattice is a generated GoMock package.
+package lattice
+
+import (
+	context "context"
+	reflect "reflect"
+
+	lattice "github.com/aws/aws-application-networking-k8s/pkg/model/lattice"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockCertificateManager is a mock of CertificateManager interface.
+type MockCertificateManager struct {
+	ctrl     *gomock.Controller
+	recorder *MockCertificateManagerMockRecorder
+}
+
+// MockCertificateManagerMockRecorder is the mock recorder for MockCertificateManager.
+type MockCertificateManagerMockRecorder struct {
+	mock *MockCertificateManager
+}
+
+// NewMockCertificateManager creates a new mock instance.
+func NewMockCertificateManager(ctrl *gomock.Controller) *MockCertificateManager {
+	mock := &MockCertificateManager{ctrl: ctrl}
+	mock.recorder = &MockCertificateManagerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCertificateManager) EXPECT() *MockCertificateManagerMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockCertificateManager) Delete(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockCertificateManagerMockRecorder) Delete(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockCertificateManager)(nil).Delete), arg0, arg1)
+}
+
+// Upsert mocks base method.
+func (m *MockCertificateManager) Upsert(arg0 context.Context, arg1 string, arg2 *lattice.Certificate) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Upsert", arg0, arg1, arg2)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Upsert indicates an expected call of Upsert.
+func (mr *MockCertificateManagerMockRecorder) Upsert(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upsert", reflect.TypeOf((*MockCertificateManager)(nil).Upsert), arg0, arg1, arg2)
+}
@@ -0,0 +1,205 @@
+package lattice
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/acm"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	pkg_aws "github.com/aws/aws-application-networking-k8s/pkg/aws"
+	"github.com/aws/aws-application-networking-k8s/pkg/aws/services"
+	model "github.com/aws/aws-application-networking-k8s/pkg/model/lattice"
+	"github.com/aws/aws-application-networking-k8s/pkg/utils/gwlog"
+)
+
+func Test_CertificateManager_Upsert(t *testing.T) {
+	cert := &model.Certificate{
+		Spec: model.CertificateSpec{
+			GatewayName:      "gw",
+			GatewayNamespace: "default",
+			SecretName:       "cert",
+			SecretNamespace:  "default",
+			Fingerprint:      "new",
+		},
+		Certificate: []byte("certificate"),
+		PrivateKey:  []byte("key"),
+		DomainNames: []string{"api.example.com", "www.example.com"},
+	}
+	certArn := "arn:aws:acm:region:account-id:certificate/cert-id"
+	tagsWithFingerprint := func(cloud pkg_aws.Cloud, fingerprint string) []*acm.Tag {
+		spec := cert.Spec
+		spec.Fingerprint = fingerprint
+		return services.ToACMTags(cloud.DefaultTagsMergedWith(spec.ToTags()))
+	}
+
+	// tags are only listed for the imported certificates of tagsByArn, other summaries are skipped
+	listCertificates := func(mockACM *services.MockACM, tagsByArn map[string][]*acm.Tag, skipped ...*acm.CertificateSummary) {
+		mockACM.EXPECT().ListCertificatesPagesWithContext(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, input *acm.ListCertificatesInput, fn func(*acm.ListCertificatesOutput, bool) bool, _ ...interface{}) error {
+				assert.Contains(t, aws.StringValueSlice(input.Includes.KeyTypes), acm.KeyAlgorithmEcPrime256v1)
+				summaries := skipped
+				for arn := range tagsByArn {
+					summaries = append(summaries, &acm.CertificateSummary{
+						CertificateArn: aws.String(arn),
+						DomainName:     aws.String("WWW.example.com"),
+						Type:           aws.String(acm.CertificateTypeImported),
+					})
+				}
+				fn(&acm.ListCertificatesOutput{CertificateSummaryList: summaries}, true)
+				return nil
+			})
+		for arn, tags := range tagsByArn {
+			mockACM.EXPECT().ListTagsForCertificateWithContext(gomock.Any(), &acm.ListTagsForCertificateInput{
+				CertificateArn: aws.String(arn),
+			}).Return(&acm.ListTagsForCertificateOutput{Tags: tags}, nil)
+		}
+	}
+
+	tests := []struct {
+		name        string
+		certArn     string
+		setup       func(mockACM *services.MockACM, cloud pkg_aws.Cloud)
+		expectedArn string
+		expectedErr bool
+	}{
+		{
+			name:    "certificate is imported",
+			certArn: "",
+			setup: func(mockACM *services.MockACM, cloud pkg_aws.Cloud) {
+				otherSecret := cert.Spec
+				otherSecret.SecretName = "other"
+				listCertificates(mockACM, map[string][]*acm.Tag{
+					"other-secret": services.ToACMTags(cloud.DefaultTagsMergedWith(otherSecret.ToTags())),
+					"not-managed":  services.ToACMTags(cert.Spec.ToTags()),
+				}, &acm.CertificateSummary{
+					CertificateArn: aws.String("other-domain"),
+					DomainName:     aws.String("other.example.com"),
+					Type:           aws.String(acm.CertificateTypeImported),
+				}, &acm.CertificateSummary{
+					CertificateArn: aws.String("issued-by-acm"),
+					DomainName:     aws.String("api.example.com"),
+					Type:           aws.String(acm.CertificateTypeAmazonIssued),
+				})
+				mockACM.EXPECT().ImportCertificateWithContext(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, input *acm.ImportCertificateInput, _ ...interface{}) (*acm.ImportCertificateOutput, error) {
+						assert.Nil(t, input.CertificateArn)
+						assert.Equal(t, cert.Certificate, input.Certificate)
+						assert.Equal(t, cert.PrivateKey, input.PrivateKey)
+						assert.Nil(t, input.CertificateChain)
+						assert.Equal(t, services.FromACMTags(tagsWithFingerprint(cloud, "new")), services.FromACMTags(input.Tags))
+						return &acm.ImportCertificateOutput{CertificateArn: aws.String(certArn)}, nil
+					})
+			},
+			expectedArn: certArn,
+		},
+		{
+			name:    "certificate imported before is re-imported",
+			certArn: "",
+			setup: func(mockACM *services.MockACM, cloud pkg_aws.Cloud) {
+				listCertificates(mockACM, map[string][]*acm.Tag{certArn: tagsWithFingerprint(cloud, "old")})
+				mockACM.EXPECT().ImportCertificateWithContext(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, input *acm.ImportCertificateInput, _ ...interface{}) (*acm.ImportCertificateOutput, error) {
+						assert.Equal(t, certArn, aws.StringValue(input.CertificateArn))
+						return &acm.ImportCertificateOutput{CertificateArn: aws.String(certArn)}, nil
+					})
+				mockACM.EXPECT().AddTagsToCertificateWithContext(gomock.Any(), gomock.Any()).
+					Return(&acm.AddTagsToCertificateOutput{}, nil)
+			},
+			expectedArn: certArn,
+		},
+		{
+			name:    "unchanged certificate is not re-imported",
+			certArn: certArn,
+			setup: func(mockACM *services.MockACM, cloud pkg_aws.Cloud) {
+				mockACM.EXPECT().ListTagsForCertificateWithContext(gomock.Any(), gomock.Any()).
+					Return(&acm.ListTagsForCertificateOutput{Tags: tagsWithFingerprint(cloud, "new")}, nil)
+			},
+			expectedArn: certArn,
+		},
+		{
+			name:    "changed certificate is re-imported",
+			certArn: certArn,
+			setup: func(mockACM *services.MockACM, cloud pkg_aws.Cloud) {
+				mockACM.EXPECT().ListTagsForCertificateWithContext(gomock.Any(), gomock.Any()).
+					Return(&acm.ListTagsForCertificateOutput{Tags: tagsWithFingerprint(cloud, "old")}, nil)
+				mockACM.EXPECT().ImportCertificateWithContext(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, input *acm.ImportCertificateInput, _ ...interface{}) (*acm.ImportCertificateOutput, error) {
+						assert.Equal(t, certArn, aws.StringValue(input.CertificateArn))
+						assert.Nil(t, input.Tags)
+						return &acm.ImportCertificateOutput{CertificateArn: aws.String(certArn)}, nil
+					})
+				mockACM.EXPECT().AddTagsToCertificateWithContext(gomock.Any(), &acm.AddTagsToCertificateInput{
+					CertificateArn: aws.String(certArn),
+					Tags:           []*acm.Tag{{Key: aws.String(model.K8SCertificateFingerprintKey), Value: aws.String("new")}},
+				}).Return(&acm.AddTagsToCertificateOutput{}, nil)
+			},
+			expectedArn: certArn,
+		},
+		{
+			name:    "deleted certificate is imported again",
+			certArn: certArn,
+			setup: func(mockACM *services.MockACM, cloud pkg_aws.Cloud) {
+				mockACM.EXPECT().ListTagsForCertificateWithContext(gomock.Any(), gomock.Any()).
+					Return(nil, awserr.New(acm.ErrCodeResourceNotFoundException, "not found", nil))
+				listCertificates(mockACM, nil)
+				mockACM.EXPECT().ImportCertificateWithContext(gomock.Any(), gomock.Any()).
+					Return(&acm.ImportCertificateOutput{CertificateArn: aws.String("new-arn")}, nil)
+			},
+			expectedArn: "new-arn",
+		},
+		{
+			name:    "certificate not managed by the controller",
+			certArn: certArn,
+			setup: func(mockACM *services.MockACM, cloud pkg_aws.Cloud) {
+				mockACM.EXPECT().ListTagsForCertificateWithContext(gomock.Any(), gomock.Any()).
+					Return(&acm.ListTagsForCertificateOutput{}, nil)
+			},
+			expectedErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+			mockACM := services.NewMockACM(c)
+			cloud := pkg_aws.NewDefaultCloudWithACM(services.NewMockLattice(c), mockACM, TestCloudConfig)
+			tt.setup(mockACM, cloud)
+
+			certManager := NewCertificateManager(gwlog.FallbackLogger, cloud)
+			arn, err := certManager.Upsert(context.TODO(), tt.certArn, cert)
+			if tt.expectedErr {
+				assert.True(t, services.IsConflictError(err))
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedArn, arn)
+		})
+	}
+}
+
+func Test_CertificateManager_Delete(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+	ctx := context.TODO()
+	mockACM := services.NewMockACM(c)
+	cloud := pkg_aws.NewDefaultCloudWithACM(services.NewMockLattice(c), mockACM, TestCloudConfig)
+	certManager := NewCertificateManager(gwlog.FallbackLogger, cloud)
+
+	mockACM.EXPECT().DeleteCertificateWithContext(ctx, &acm.DeleteCertificateInput{CertificateArn: aws.String("deleted")}).
+		Return(nil, awserr.New(acm.ErrCodeResourceNotFoundException, "not found", nil))
+	assert.NoError(t, certManager.Delete(ctx, "deleted"))
+
+	mockACM.EXPECT().DeleteCertificateWithContext(ctx, &acm.DeleteCertificateInput{CertificateArn: aws.String("in-use")}).
+		Return(nil, awserr.New(acm.ErrCodeResourceInUseException, "in use", nil))
+	err := certManager.Delete(ctx, "in-use")
+	assert.True(t, services.IsACMInUseError(err))
+
+	mockACM.EXPECT().DeleteCertificateWithContext(ctx, &acm.DeleteCertificateInput{CertificateArn: aws.String("arn")}).
+		Return(&acm.DeleteCertificateOutput{}, nil)
+	assert.NoError(t, certManager.Delete(ctx, "arn"))
+}
@@ -75,12 +75,8 @@ func (d *defaultListenerManager) Upsert(
 		Id:          aws.StringValue(latticeListenerSummary.Id),
 		ServiceId:   latticeSvcId,
 	}
-	if modelListener.Spec.Protocol != vpclattice.ListenerProtocolTlsPassthrough {
-		// The only mutable field for lattice listener is defaultAction, for non-TLS_PASSTHROUGH listener, the defaultAction is always the FixedResponse 404. Don't need to update.
-		return existingListenerStatus, nil
-	}
-
-	// For TLS_PASSTHROUGH listener, check whether it needs to update defaultAction
+	// The only mutable field for lattice listener is defaultAction, it is also checked for non-TLS_PASSTHROUGH
+	// listeners to revert changes made outside the controller
 	needToUpdateDefaultAction, err := d.needToUpdateDefaultAction(ctx, latticeSvcId, *latticeListenerSummary.Id, defaultAction)
 	if err != nil {
 		return model.ListenerStatus{}, err
@@ -153,9 +149,14 @@ func (d *defaultListenerManager) getLatticeListenerDefaultAction(stackListener *
 	if !hasValidTargetGroup {
 		if stackListener.Spec.Protocol == vpclattice.ListenerProtocolTlsPassthrough {
 			return nil, fmt.Errorf("TLSRoute %s/%s must have at least one valid backendRef target group", stackListener.Spec.K8SRouteNamespace, stackListener.Spec.K8SRouteName)
-		} else {
-			return nil, fmt.Errorf("unreachable code, since the defaultAction for non-TLS_PASSTHROUGH listener is always the FixedResponse 404")
 		}
+		// same as a rule without valid target groups
+		d.log.Debugf("There are no valid default action target groups, defaulting to 404 Fixed response")
+		return &vpclattice.RuleAction{
+			FixedResponse: &vpclattice.FixedResponseAction{
+				StatusCode: aws.Int64(model.DefaultActionFixedResponseStatusCode),
+			},
+		}, nil
 	}
 
 	var latticeTGs []*vpclattice.WeightedTargetGroup
@@ -143,7 +143,12 @@ func Test_UpsertListener_DoNotNeedToUpdateExistingHTTPAndHTTPSListener(t *testin
 					},
 				}}, nil)
 
-			mockLattice.EXPECT().GetListenerWithContext(ctx, gomock.Any()).Times(0)
+			mockLattice.EXPECT().GetListenerWithContext(ctx, gomock.Any()).Return(
+				&vpclattice.GetListenerOutput{
+					DefaultAction: &vpclattice.RuleAction{
+						FixedResponse: &vpclattice.FixedResponseAction{StatusCode: aws.Int64(404)},
+					},
+				}, nil)
 			mockLattice.EXPECT().UpdateListenerWithContext(ctx, gomock.Any()).Times(0)
 
 			lm := NewListenerManager(gwlog.FallbackLogger, cloud)
@@ -157,6 +162,54 @@ func Test_UpsertListener_DoNotNeedToUpdateExistingHTTPAndHTTPSListener(t *testin
 		})
 	}
 }
+func Test_UpsertListener_RevertDefaultActionChangedOutsideController(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+	ctx := context.TODO()
+	ms := &model.Service{
+		Status: &model.ServiceStatus{Id: "svc-id"},
+	}
+	ml := &model.Listener{
+		Spec: model.ListenerSpec{
+			Protocol: vpclattice.ListenerProtocolHttp,
+			Port:     8181,
+			DefaultAction: &model.DefaultAction{
+				FixedResponseStatusCode: aws.Int64(404),
+			},
+		},
+	}
+
+	mockLattice := mocks.NewMockLattice(c)
+	cloud := pkg_aws.NewDefaultCloud(mockLattice, TestCloudConfig)
+	mockLattice.EXPECT().ListListenersWithContext(ctx, gomock.Any()).Return(
+		&vpclattice.ListListenersOutput{Items: []*vpclattice.ListenerSummary{
+			{
+				Arn:  aws.String("existing-arn"),
+				Id:   aws.String("existing-listener-id"),
+				Name: aws.String("existing-name"),
+				Port: aws.Int64(8181),
+			},
+		}}, nil)
+	mockLattice.EXPECT().GetListenerWithContext(ctx, gomock.Any()).Return(
+		&vpclattice.GetListenerOutput{
+			DefaultAction: &vpclattice.RuleAction{
+				FixedResponse: &vpclattice.FixedResponseAction{StatusCode: aws.Int64(503)},
+			},
+		}, nil)
+	mockLattice.EXPECT().UpdateListenerWithContext(ctx, &vpclattice.UpdateListenerInput{
+		DefaultAction: &vpclattice.RuleAction{
+			FixedResponse: &vpclattice.FixedResponseAction{StatusCode: aws.Int64(404)},
+		},
+		ListenerIdentifier: aws.String("existing-listener-id"),
+		ServiceIdentifier:  aws.String("svc-id"),
+	}).Return(&vpclattice.UpdateListenerOutput{}, nil)
+
+	lm := NewListenerManager(gwlog.FallbackLogger, cloud)
+	status, err := lm.Upsert(ctx, ml, ms)
+	assert.Nil(t, err)
+	assert.Equal(t, "existing-listener-id", status.Id)
+}
+
 func Test_UpsertListener_Update_TLS_PASSTHROUGHListener(t *testing.T) {
 	tests := []struct {
 		name                            string
@@ -485,6 +538,41 @@ func Test_defaultListenerManager_getLatticeListenerDefaultAction_HTTP_HTTPS_List
 			listenerProtocol:   vpclattice.ListenerProtocolHttps,
 			want:               latticeFixResponseAction404,
 		},
+		{
+			name:               "HTTP protocol Listener has a fixed response modelListenerDefaultAction from a catch-all rule",
+			modelDefaultAction: &model.DefaultAction{FixedResponseStatusCode: aws.Int64(301)},
+			listenerProtocol:   vpclattice.ListenerProtocolHttp,
+			want: &vpclattice.RuleAction{
+				FixedResponse: &vpclattice.FixedResponseAction{StatusCode: aws.Int64(301)},
+			},
+		},
+		{
+			name: "HTTP protocol Listener has a forward modelListenerDefaultAction, return lattice forward DefaultAction",
+			modelDefaultAction: &model.DefaultAction{Forward: &model.RuleAction{
+				TargetGroups: []*model.RuleTargetGroup{
+					{LatticeTgId: "lattice-tg-id-1", StackTargetGroupId: "stack-tg-id-1", Weight: 1},
+					{LatticeTgId: model.InvalidBackendRefTgId, StackTargetGroupId: model.InvalidBackendRefTgId, Weight: 1},
+				},
+			}},
+			listenerProtocol: vpclattice.ListenerProtocolHttp,
+			want: &vpclattice.RuleAction{
+				Forward: &vpclattice.ForwardAction{
+					TargetGroups: []*vpclattice.WeightedTargetGroup{
+						{TargetGroupIdentifier: aws.String("lattice-tg-id-1"), Weight: aws.Int64(1)},
+					},
+				},
+			},
+		},
+		{
+			name: "HTTPS protocol Listener forwards to invalid target groups only, return lattice fixed response 404 DefaultAction",
+			modelDefaultAction: &model.DefaultAction{Forward: &model.RuleAction{
+				TargetGroups: []*model.RuleTargetGroup{
+					{LatticeTgId: model.InvalidBackendRefTgId, StackTargetGroupId: model.InvalidBackendRefTgId, Weight: 1},
+				},
+			}},
+			listenerProtocol: vpclattice.ListenerProtocolHttps,
+			want:             latticeFixResponseAction404,
+		},
 	}
 
 	c := gomock.NewController(t)
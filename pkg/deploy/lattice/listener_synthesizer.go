@@ -52,7 +52,7 @@ func (l *listenerSynthesizer) Synthesize(ctx context.Context) error {
 		if listener.Spec.DefaultAction.Forward != nil {
 			// Fill the listener forward action target group ids
 			if err := l.tgManager.ResolveRuleTgIds(ctx, listener.Spec.DefaultAction.Forward, l.stack); err != nil {
-				return fmt.Errorf("failed to resolve rule tg ids, err = %v", err)
+				return fmt.Errorf("failed to resolve rule tg ids, err = %w", err)
 			}
 		}
 
@@ -0,0 +1,462 @@
+package lattice
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/vpclattice"
+
+	pkg_aws "github.com/aws/aws-application-networking-k8s/pkg/aws"
+	"github.com/aws/aws-application-networking-k8s/pkg/aws/services"
+	"github.com/aws/aws-application-networking-k8s/pkg/model/core"
+	model "github.com/aws/aws-application-networking-k8s/pkg/model/lattice"
+	"github.com/aws/aws-application-networking-k8s/pkg/utils/gwlog"
+)
+
+type ChangeAction string
+
+const (
+	ChangeActionCreate ChangeAction = "create"
+	ChangeActionUpdate ChangeAction = "update"
+	ChangeActionDelete ChangeAction = "delete"
+)
+
+const (
+	ChangeResourceService     = "service"
+	ChangeResourceListener    = "listener"
+	ChangeResourceRule        = "rule"
+	ChangeResourceTargetGroup = "targetgroup"
+	ChangeResourceTargets     = "targets"
+)
+
+// Change is a single VPC Lattice change deploying a stack would make
+type Change struct {
+	Action       ChangeAction `json:"action"`
+	ResourceType string       `json:"resourceType"`
+	Name         string       `json:"name"`
+	Detail       string       `json:"detail,omitempty"`
+}
+
+func (c Change) String() string {
+	s := fmt.Sprintf("%s %s %s", c.Action, c.ResourceType, c.Name)
+	if c.Detail != "" {
+		s += " (" + c.Detail + ")"
+	}
+	return s
+}
+
+type ChangeSet []Change
+
+func (cs ChangeSet) count(action ChangeAction) int {
+	n := 0
+	for _, c := range cs {
+		if c.Action == action {
+			n++
+		}
+	}
+	return n
+}
+
+// Summary counts the changes per action, e.g. "2 to create, 1 to update, 0 to delete"
+func (cs ChangeSet) Summary() string {
+	return fmt.Sprintf("%d to create, %d to update, %d to delete",
+		cs.count(ChangeActionCreate), cs.count(ChangeActionUpdate), cs.count(ChangeActionDelete))
+}
+
+func (cs ChangeSet) String() string {
+	lines := []string{cs.Summary()}
+	for _, c := range cs {
+		lines = append(lines, c.String())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Planner compares the desired state of a stack with the live VPC Lattice state and returns
+// the changes the synthesizers would make. It only uses read APIs, nothing is mutated in
+// VPC Lattice. Resources that do not exist yet have no status, so their children are all
+// planned for creation.
+type Planner struct {
+	log             gwlog.Logger
+	cloud           pkg_aws.Cloud
+	serviceManager  *defaultServiceManager
+	listenerManager *defaultListenerManager
+	ruleManager     *defaultRuleManager
+	tgManager       *defaultTargetGroupManager
+	targetsManager  *defaultTargetsManager
+	stack           core.Stack
+}
+
+func NewPlanner(log gwlog.Logger, cloud pkg_aws.Cloud, stack core.Stack) *Planner {
+	return &Planner{
+		log:             log,
+		cloud:           cloud,
+		serviceManager:  NewServiceManager(log, cloud),
+		listenerManager: NewListenerManager(log, cloud),
+		ruleManager:     NewRuleManager(log, cloud),
+		tgManager:       NewTargetGroupManager(log, cloud),
+		targetsManager:  NewTargetsManager(log, cloud),
+		stack:           stack,
+	}
+}
+
+// Plan follows the order of the stack deployer, statuses of existing resources are filled
+// in the stack so later steps can look up their children
+func (p *Planner) Plan(ctx context.Context) (ChangeSet, error) {
+	var changes ChangeSet
+	steps := []func(context.Context) (ChangeSet, error){
+		p.planTargetGroups,
+		p.planTargets,
+		p.planServices,
+		p.planListeners,
+		p.planRules,
+	}
+	for _, step := range steps {
+		stepChanges, err := step(ctx)
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, stepChanges...)
+	}
+	return changes, nil
+}
+
+func (p *Planner) planTargetGroups(ctx context.Context) (ChangeSet, error) {
+	var stackTgs []*model.TargetGroup
+	if err := p.stack.ListResources(&stackTgs); err != nil {
+		return nil, err
+	}
+
+	var changes ChangeSet
+	for _, tg := range stackTgs {
+		name := model.GenerateTgName(tg.Spec)
+		latticeTg, err := p.tgManager.findTargetGroup(ctx, tg)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find target group %s due to %w", name, err)
+		}
+
+		if tg.IsDeleted {
+			if latticeTg != nil {
+				changes = append(changes, Change{Action: ChangeActionDelete, ResourceType: ChangeResourceTargetGroup,
+					Name: aws.StringValue(latticeTg.Name)})
+			}
+			continue
+		}
+
+		if latticeTg == nil {
+			changes = append(changes, Change{Action: ChangeActionCreate, ResourceType: ChangeResourceTargetGroup,
+				Name: name, Detail: fmt.Sprintf("%s:%d", tg.Spec.Protocol, tg.Spec.Port)})
+			continue
+		}
+
+		tg.Status = &model.TargetGroupStatus{
+			Name: aws.StringValue(latticeTg.Name),
+			Arn:  aws.StringValue(latticeTg.Arn),
+			Id:   aws.StringValue(latticeTg.Id),
+		}
+		healthCheckConfig := &vpclattice.HealthCheckConfig{}
+		if tg.Spec.HealthCheckConfig != nil {
+			healthCheckConfig = tg.Spec.HealthCheckConfig
+		}
+		p.tgManager.fillDefaultHealthCheckConfig(healthCheckConfig, tg.Spec.Protocol, tg.Spec.ProtocolVersion)
+		if !reflect.DeepEqual(healthCheckConfig, latticeTg.Config.HealthCheck) {
+			changes = append(changes, Change{Action: ChangeActionUpdate, ResourceType: ChangeResourceTargetGroup,
+				Name: tg.Status.Name, Detail: "health check"})
+		}
+	}
+	return changes, nil
+}
+
+func (p *Planner) planTargets(ctx context.Context) (ChangeSet, error) {
+	var stackTargets []*model.Targets
+	if err := p.stack.ListResources(&stackTargets); err != nil {
+		return nil, err
+	}
+
+	var changes ChangeSet
+	for _, targets := range stackTargets {
+		tg := &model.TargetGroup{}
+		if err := p.stack.GetResource(targets.Spec.StackTargetGroupId, tg); err != nil {
+			return nil, err
+		}
+		if tg.IsDeleted {
+			continue
+		}
+
+		if tg.Status == nil {
+			if len(targets.Spec.TargetList) > 0 {
+				changes = append(changes, Change{Action: ChangeActionCreate, ResourceType: ChangeResourceTargets,
+					Name: model.GenerateTgName(tg.Spec), Detail: fmt.Sprintf("register %d", len(targets.Spec.TargetList))})
+			}
+			continue
+		}
+
+		latticeTargets, err := p.targetsManager.List(ctx, tg)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list targets of %s due to %w", tg.Status.Name, err)
+		}
+		registered := make(map[model.Target]bool)
+		for _, t := range latticeTargets {
+			registered[model.Target{TargetIP: aws.StringValue(t.Id), Port: aws.Int64Value(t.Port)}] = true
+		}
+		toRegister := 0
+		for _, t := range targets.Spec.TargetList {
+			if !registered[model.Target{TargetIP: t.TargetIP, Port: t.Port}] {
+				toRegister++
+			}
+		}
+		toDeregister := len(p.targetsManager.findStaleTargets(targets, latticeTargets))
+		if toRegister > 0 || toDeregister > 0 {
+			changes = append(changes, Change{Action: ChangeActionUpdate, ResourceType: ChangeResourceTargets,
+				Name: tg.Status.Name, Detail: fmt.Sprintf("register %d, deregister %d", toRegister, toDeregister)})
+		}
+	}
+	return changes, nil
+}
+
+func (p *Planner) planServices(ctx context.Context) (ChangeSet, error) {
+	var stackSvcs []*model.Service
+	if err := p.stack.ListResources(&stackSvcs); err != nil {
+		return nil, err
+	}
+
+	var changes ChangeSet
+	for _, svc := range stackSvcs {
+		name := svc.LatticeServiceName()
+		svcSum, err := p.cloud.Lattice().FindService(ctx, name)
+		if err != nil && !services.IsNotFoundError(err) {
+			return nil, fmt.Errorf("failed to find service %s due to %w", name, err)
+		}
+
+		if svc.IsDeleted {
+			if svcSum != nil {
+				changes = append(changes, Change{Action: ChangeActionDelete, ResourceType: ChangeResourceService, Name: name})
+			}
+			continue
+		}
+
+		if svcSum == nil {
+			changes = append(changes, Change{Action: ChangeActionCreate, ResourceType: ChangeResourceService, Name: name,
+				Detail: "service networks " + strings.Join(svc.Spec.ServiceNetworkNames, ",")})
+			continue
+		}
+
+		svc.Status = &model.ServiceStatus{
+			Arn: aws.StringValue(svcSum.Arn),
+			Id:  aws.StringValue(svcSum.Id),
+		}
+		assocs, err := p.serviceManager.getAllAssociations(ctx, svcSum)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list associations of service %s due to %w", name, err)
+		}
+		toCreate, toDelete, err := associationsDiff(svc, assocs)
+		if err != nil {
+			return nil, err
+		}
+		var details []string
+		if len(toCreate) > 0 {
+			sort.Strings(toCreate)
+			details = append(details, "associate "+strings.Join(toCreate, ","))
+		}
+		if len(toDelete) > 0 {
+			snNames := make([]string, 0, len(toDelete))
+			for _, assoc := range toDelete {
+				snNames = append(snNames, aws.StringValue(assoc.ServiceNetworkName))
+			}
+			sort.Strings(snNames)
+			details = append(details, "disassociate "+strings.Join(snNames, ","))
+		}
+		if len(details) > 0 {
+			changes = append(changes, Change{Action: ChangeActionUpdate, ResourceType: ChangeResourceService, Name: name,
+				Detail: strings.Join(details, ", ")})
+		}
+	}
+	return changes, nil
+}
+
+func (p *Planner) planListeners(ctx context.Context) (ChangeSet, error) {
+	var stackListeners []*model.Listener
+	if err := p.stack.ListResources(&stackListeners); err != nil {
+		return nil, err
+	}
+
+	var changes ChangeSet
+	for _, listener := range stackListeners {
+		svc := &model.Service{}
+		if err := p.stack.GetResource(listener.Spec.StackServiceId, svc); err != nil {
+			return nil, err
+		}
+		name := k8sLatticeListenerName(listener)
+		if svc.Status == nil {
+			changes = append(changes, Change{Action: ChangeActionCreate, ResourceType: ChangeResourceListener, Name: name})
+			continue
+		}
+
+		latticeListener, err := p.listenerManager.findListenerByPort(ctx, svc.Status.Id, listener.Spec.Port)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find listener %s due to %w", name, err)
+		}
+		if latticeListener == nil {
+			changes = append(changes, Change{Action: ChangeActionCreate, ResourceType: ChangeResourceListener, Name: name})
+			continue
+		}
+		listener.Status = &model.ListenerStatus{
+			Name:        aws.StringValue(latticeListener.Name),
+			ListenerArn: aws.StringValue(latticeListener.Arn),
+			Id:          aws.StringValue(latticeListener.Id),
+			ServiceId:   svc.Status.Id,
+		}
+
+		if listener.Spec.DefaultAction.Forward != nil {
+			p.resolvePlannedTgIds(ctx, listener.Spec.DefaultAction.Forward)
+		}
+		defaultAction, err := p.listenerManager.getLatticeListenerDefaultAction(listener)
+		if err != nil {
+			return nil, err
+		}
+		needToUpdate, err := p.listenerManager.needToUpdateDefaultAction(ctx, svc.Status.Id, listener.Status.Id, defaultAction)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get listener %s due to %w", name, err)
+		}
+		if needToUpdate {
+			changes = append(changes, Change{Action: ChangeActionUpdate, ResourceType: ChangeResourceListener,
+				Name: listener.Status.Name, Detail: "default action"})
+		}
+	}
+
+	// listeners of existing services the stack no longer has
+	var stackSvcs []*model.Service
+	if err := p.stack.ListResources(&stackSvcs); err != nil {
+		return nil, err
+	}
+	for _, svc := range stackSvcs {
+		if svc.IsDeleted || svc.Status == nil {
+			continue
+		}
+		latticeListeners, err := p.listenerManager.List(ctx, svc.Status.Id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list listeners of service %s due to %w", svc.LatticeServiceName(), err)
+		}
+		for _, latticeListener := range latticeListeners {
+			stale := true
+			for _, listener := range stackListeners {
+				if listener.Spec.StackServiceId == svc.ID() &&
+					listener.Spec.Port == aws.Int64Value(latticeListener.Port) &&
+					listener.Spec.Protocol == aws.StringValue(latticeListener.Protocol) {
+					stale = false
+					break
+				}
+			}
+			if stale {
+				changes = append(changes, Change{Action: ChangeActionDelete, ResourceType: ChangeResourceListener,
+					Name: aws.StringValue(latticeListener.Name)})
+			}
+		}
+	}
+	return changes, nil
+}
+
+func (p *Planner) planRules(ctx context.Context) (ChangeSet, error) {
+	var stackRules []*model.Rule
+	if err := p.stack.ListResources(&stackRules); err != nil {
+		return nil, err
+	}
+
+	// lattice rules matched by stack rules, per listener
+	matched := make(map[string]map[string]bool)
+	latticeRulesByListener := make(map[string][]*vpclattice.GetRuleOutput)
+
+	var changes ChangeSet
+	for _, rule := range stackRules {
+		listener := &model.Listener{}
+		if err := p.stack.GetResource(rule.Spec.StackListenerId, listener); err != nil {
+			return nil, err
+		}
+		p.resolvePlannedTgIds(ctx, &rule.Spec.Action)
+		latticeRule, err := p.ruleManager.buildLatticeRule(rule)
+		if err != nil {
+			return nil, err
+		}
+		// lattice rule names contain their creation time, use a name that is stable across plans
+		name := fmt.Sprintf("%s-rule-%d", k8sLatticeListenerName(listener), rule.Spec.Priority)
+		if listener.Status == nil {
+			changes = append(changes, Change{Action: ChangeActionCreate, ResourceType: ChangeResourceRule, Name: name})
+			continue
+		}
+
+		latticeRules, ok := latticeRulesByListener[listener.Status.Id]
+		if !ok {
+			latticeRules, err = p.cloud.Lattice().GetRulesAsList(ctx, &vpclattice.ListRulesInput{
+				ServiceIdentifier:  aws.String(listener.Status.ServiceId),
+				ListenerIdentifier: aws.String(listener.Status.Id),
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to list rules of listener %s due to %w", listener.Status.Name, err)
+			}
+			latticeRulesByListener[listener.Status.Id] = latticeRules
+			matched[listener.Status.Id] = make(map[string]bool)
+		}
+
+		var matchingRule *vpclattice.GetRuleOutput
+		for _, lr := range latticeRules {
+			if isMatchEqual(latticeRule, lr) {
+				matchingRule = lr
+				break
+			}
+		}
+		if matchingRule == nil {
+			changes = append(changes, Change{Action: ChangeActionCreate, ResourceType: ChangeResourceRule, Name: name})
+			continue
+		}
+
+		matched[listener.Status.Id][aws.StringValue(matchingRule.Id)] = true
+		var details []string
+		if !reflect.DeepEqual(latticeRule.Action, matchingRule.Action) {
+			details = append(details, "action")
+		}
+		if aws.Int64Value(matchingRule.Priority) != rule.Spec.Priority {
+			details = append(details, fmt.Sprintf("priority %d -> %d", aws.Int64Value(matchingRule.Priority), rule.Spec.Priority))
+		}
+		if len(details) > 0 {
+			changes = append(changes, Change{Action: ChangeActionUpdate, ResourceType: ChangeResourceRule,
+				Name: aws.StringValue(matchingRule.Name), Detail: strings.Join(details, ", ")})
+		}
+	}
+
+	// map iteration order is random, keep the plan stable
+	listenerIds := make([]string, 0, len(latticeRulesByListener))
+	for listenerId := range latticeRulesByListener {
+		listenerIds = append(listenerIds, listenerId)
+	}
+	sort.Strings(listenerIds)
+	for _, listenerId := range listenerIds {
+		for _, lr := range latticeRulesByListener[listenerId] {
+			if aws.BoolValue(lr.IsDefault) || matched[listenerId][aws.StringValue(lr.Id)] {
+				continue
+			}
+			changes = append(changes, Change{Action: ChangeActionDelete, ResourceType: ChangeResourceRule,
+				Name: aws.StringValue(lr.Name)})
+		}
+	}
+	return changes, nil
+}
+
+// target groups planned for creation have no id yet, rules forwarding to them are reported as changed
+func (p *Planner) resolvePlannedTgIds(ctx context.Context, action *model.RuleAction) {
+	for _, ruleTg := range action.TargetGroups {
+		if ruleTg.LatticeTgId != "" || ruleTg.StackTargetGroupId == "" ||
+			ruleTg.StackTargetGroupId == model.InvalidBackendRefTgId {
+			continue
+		}
+		stackTg := &model.TargetGroup{}
+		if err := p.stack.GetResource(ruleTg.StackTargetGroupId, stackTg); err == nil && stackTg.Status == nil {
+			ruleTg.LatticeTgId = "(new)"
+		}
+	}
+	if err := p.tgManager.ResolveRuleTgIds(ctx, action, p.stack); err != nil {
+		p.log.Debugf("Could not resolve all rule target groups: %s", err)
+	}
+}
@@ -0,0 +1,153 @@
+package lattice
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/vpclattice"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	pkg_aws "github.com/aws/aws-application-networking-k8s/pkg/aws"
+	mocks "github.com/aws/aws-application-networking-k8s/pkg/aws/services"
+	"github.com/aws/aws-application-networking-k8s/pkg/model/core"
+	model "github.com/aws/aws-application-networking-k8s/pkg/model/lattice"
+	"github.com/aws/aws-application-networking-k8s/pkg/utils/gwlog"
+)
+
+func planTestStack(t *testing.T) (core.Stack, *model.Service) {
+	stack := core.NewDefaultStack(core.StackID{Name: "route", Namespace: "ns"})
+
+	svc, err := model.NewLatticeService(stack, model.ServiceSpec{
+		ServiceTagFields: model.ServiceTagFields{
+			RouteName:      "route",
+			RouteNamespace: "ns",
+		},
+		ServiceNetworkNames: []string{"sn-new"},
+	})
+	assert.NoError(t, err)
+
+	listener, err := model.NewListener(stack, model.ListenerSpec{
+		StackServiceId:    svc.ID(),
+		K8SRouteName:      "route",
+		K8SRouteNamespace: "ns",
+		Port:              80,
+		Protocol:          vpclattice.ListenerProtocolHttp,
+		DefaultAction: &model.DefaultAction{
+			FixedResponseStatusCode: aws.Int64(404),
+		},
+	})
+	assert.NoError(t, err)
+
+	_, err = model.NewRule(stack, model.RuleSpec{
+		StackListenerId: listener.ID(),
+		PathMatchPrefix: true,
+		PathMatchValue:  "/",
+		Priority:        1,
+		Action: model.RuleAction{
+			FixedResponseStatusCode: aws.Int64(404),
+		},
+	})
+	assert.NoError(t, err)
+	return stack, svc
+}
+
+func Test_Plan_NewRoute(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+	ctx := context.TODO()
+	mockLattice := mocks.NewMockLattice(c)
+	cloud := pkg_aws.NewDefaultCloud(mockLattice, TestCloudConfig)
+
+	stack, _ := planTestStack(t)
+	mockLattice.EXPECT().FindService(ctx, "route-ns").Return(nil, mocks.NewNotFoundError("Service", "route-ns"))
+
+	changes, err := NewPlanner(gwlog.FallbackLogger, cloud, stack).Plan(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "3 to create, 0 to update, 0 to delete", changes.Summary())
+	assert.Equal(t, ChangeSet{
+		{Action: ChangeActionCreate, ResourceType: ChangeResourceService, Name: "route-ns", Detail: "service networks sn-new"},
+		{Action: ChangeActionCreate, ResourceType: ChangeResourceListener, Name: "route-ns-80-http"},
+		{Action: ChangeActionCreate, ResourceType: ChangeResourceRule, Name: "route-ns-80-http-rule-1"},
+	}, changes)
+}
+
+func Test_Plan_ExistingRoute(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+	ctx := context.TODO()
+	mockLattice := mocks.NewMockLattice(c)
+	cloud := pkg_aws.NewDefaultCloud(mockLattice, TestCloudConfig)
+
+	stack, _ := planTestStack(t)
+	mockLattice.EXPECT().FindService(ctx, "route-ns").Return(&vpclattice.ServiceSummary{
+		Arn: aws.String("svc-arn"),
+		Id:  aws.String("svc-id"),
+	}, nil)
+	mockLattice.EXPECT().ListServiceNetworkServiceAssociationsAsList(ctx, gomock.Any()).Return(
+		[]*vpclattice.ServiceNetworkServiceAssociationSummary{
+			{Arn: aws.String("assoc-arn"), ServiceNetworkName: aws.String("sn-old")},
+		}, nil)
+	mockLattice.EXPECT().ListListenersWithContext(ctx, gomock.Any()).Return(&vpclattice.ListListenersOutput{
+		Items: []*vpclattice.ListenerSummary{
+			{Arn: aws.String("listener-arn"), Id: aws.String("listener-id"), Name: aws.String("route-ns-80-http"), Port: aws.Int64(80),
+				Protocol: aws.String(vpclattice.ListenerProtocolHttp)},
+			{Arn: aws.String("stale-listener-arn"), Id: aws.String("stale-listener-id"), Name: aws.String("route-ns-443-https"), Port: aws.Int64(443),
+				Protocol: aws.String(vpclattice.ListenerProtocolHttps)},
+		},
+	}, nil).Times(2)
+	// default action changed outside the controller
+	mockLattice.EXPECT().GetListenerWithContext(ctx, &vpclattice.GetListenerInput{
+		ServiceIdentifier:  aws.String("svc-id"),
+		ListenerIdentifier: aws.String("listener-id"),
+	}).Return(&vpclattice.GetListenerOutput{
+		DefaultAction: &vpclattice.RuleAction{FixedResponse: &vpclattice.FixedResponseAction{StatusCode: aws.Int64(503)}},
+	}, nil)
+	mockLattice.EXPECT().GetRulesAsList(ctx, gomock.Any()).Return([]*vpclattice.GetRuleOutput{
+		{
+			Id:        aws.String("default-rule-id"),
+			IsDefault: aws.Bool(true),
+		},
+		{
+			Id:       aws.String("rule-id"),
+			Name:     aws.String("k8s-rule"),
+			Priority: aws.Int64(2),
+			Match: &vpclattice.RuleMatch{HttpMatch: &vpclattice.HttpMatch{
+				PathMatch: &vpclattice.PathMatch{
+					Match:         &vpclattice.PathMatchType{Prefix: aws.String("/")},
+					CaseSensitive: aws.Bool(true),
+				},
+			}},
+			Action: &vpclattice.RuleAction{FixedResponse: &vpclattice.FixedResponseAction{StatusCode: aws.Int64(404)}},
+		},
+		{
+			Id:       aws.String("stale-rule-id"),
+			Name:     aws.String("k8s-stale-rule"),
+			Priority: aws.Int64(3),
+		},
+	}, nil)
+
+	// only read APIs are expected, any mutating call fails the test
+	changes, err := NewPlanner(gwlog.FallbackLogger, cloud, stack).Plan(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "0 to create, 3 to update, 2 to delete", changes.Summary())
+	assert.Equal(t, ChangeSet{
+		{Action: ChangeActionUpdate, ResourceType: ChangeResourceService, Name: "route-ns",
+			Detail: "associate sn-new, disassociate sn-old"},
+		{Action: ChangeActionUpdate, ResourceType: ChangeResourceListener, Name: "route-ns-80-http", Detail: "default action"},
+		{Action: ChangeActionDelete, ResourceType: ChangeResourceListener, Name: "route-ns-443-https"},
+		{Action: ChangeActionUpdate, ResourceType: ChangeResourceRule, Name: "k8s-rule", Detail: "priority 2 -> 1"},
+		{Action: ChangeActionDelete, ResourceType: ChangeResourceRule, Name: "k8s-stale-rule"},
+	}, changes)
+}
+
+func Test_ChangeSetString(t *testing.T) {
+	changes := ChangeSet{
+		{Action: ChangeActionCreate, ResourceType: ChangeResourceTargetGroup, Name: "k8s-tg", Detail: "HTTP:80"},
+		{Action: ChangeActionDelete, ResourceType: ChangeResourceRule, Name: "k8s-rule"},
+	}
+	assert.Equal(t, "1 to create, 0 to update, 1 to delete\n"+
+		"create targetgroup k8s-tg (HTTP:80)\n"+
+		"delete rule k8s-rule", changes.String())
+}
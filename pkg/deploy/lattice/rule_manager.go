@@ -108,7 +108,13 @@ func (r *defaultRuleManager) buildLatticeRule(modelRule *model.Rule) (*vpclattic
 		}
 	}
 
-	if hasValidTargetGroup {
+	if modelRule.Spec.Action.FixedResponseStatusCode != nil {
+		gro.Action = &vpclattice.RuleAction{
+			FixedResponse: &vpclattice.FixedResponseAction{
+				StatusCode: modelRule.Spec.Action.FixedResponseStatusCode,
+			},
+		}
+	} else if hasValidTargetGroup {
 		var latticeTGs []*vpclattice.WeightedTargetGroup
 		for _, ruleTg := range modelRule.Spec.Action.TargetGroups {
 			// skip any invalid TGs - eventually VPC Lattice may support weighted fixed response
@@ -236,7 +242,7 @@ func (r *defaultRuleManager) updateIfNeeded(
 
 	_, err := r.cloud.Lattice().UpdateRuleWithContext(ctx, &uri)
 	if err != nil {
-		return model.RuleStatus{}, fmt.Errorf("failed UpdateRule %d for %s, %s due to %s",
+		return model.RuleStatus{}, fmt.Errorf("failed UpdateRule %d for %s, %s due to %w",
 			ruleToUpdate.Priority, latticeListenerId, latticeSvcId, err)
 	}
 
@@ -273,7 +279,7 @@ func (r *defaultRuleManager) create(
 
 	res, err := r.cloud.Lattice().CreateRuleWithContext(ctx, &cri)
 	if err != nil {
-		return model.RuleStatus{}, fmt.Errorf("failed CreateRule %s, %s due to %s", latticeListenerId, latticeSvcId, err)
+		return model.RuleStatus{}, fmt.Errorf("failed CreateRule %s, %s due to %w", latticeListenerId, latticeSvcId, err)
 	}
 
 	r.log.Infof("Success CreateRule %s, %s", aws.StringValue(res.Name), aws.StringValue(res.Id))
@@ -315,6 +321,10 @@ func updateMatchFromRule(httpMatch *vpclattice.HttpMatch, modelRule *model.Rule)
 			Name:          modelRule.Spec.MatchedHeaders[i].Name,
 			CaseSensitive: aws.Bool(false), // see HTTPHeaderMatch.HTTPHeaderName in gw spec
 		}
+		// regular expression matches carry their own case sensitivity
+		if modelRule.Spec.MatchedHeaders[i].CaseSensitive != nil {
+			headerMatch.CaseSensitive = modelRule.Spec.MatchedHeaders[i].CaseSensitive
+		}
 		httpMatch.HeaderMatches = append(httpMatch.HeaderMatches, &headerMatch)
 	}
 }
@@ -126,6 +126,17 @@ func Test_Create(t *testing.T) {
 		},
 	}
 
+	rFixedResponse := &model.Rule{
+		Spec: model.RuleSpec{
+			Priority: 1,
+			Action: model.RuleAction{
+				FixedResponseStatusCode: aws.Int64(301),
+			},
+			PathMatchPrefix: true,
+			PathMatchValue:  "/old",
+		},
+	}
+
 	t.Run("test create", func(t *testing.T) {
 		mockLattice.EXPECT().GetRulesAsList(ctx, gomock.Any()).Return(
 			[]*vpclattice.GetRuleOutput{}, nil)
@@ -271,6 +282,28 @@ func Test_Create(t *testing.T) {
 		assert.Equal(t, "arn", ruleStatus.Arn)
 	})
 
+	t.Run("test create - fixed response action", func(t *testing.T) {
+		mockLattice.EXPECT().GetRulesAsList(ctx, gomock.Any()).Return(
+			[]*vpclattice.GetRuleOutput{}, nil)
+
+		mockLattice.EXPECT().CreateRuleWithContext(ctx, gomock.Any()).DoAndReturn(
+			func(ctx context.Context, input *vpclattice.CreateRuleInput, i ...interface{}) (*vpclattice.CreateRuleOutput, error) {
+				assert.Nil(t, input.Action.Forward)
+				assert.Equal(t, int64(301), aws.Int64Value(input.Action.FixedResponse.StatusCode))
+
+				return &vpclattice.CreateRuleOutput{
+					Arn:  aws.String("arn"),
+					Id:   aws.String("id"),
+					Name: aws.String("name"),
+				}, nil
+			})
+
+		rm := NewRuleManager(gwlog.FallbackLogger, cloud)
+		ruleStatus, err := rm.Upsert(ctx, rFixedResponse, l, svc)
+		assert.Nil(t, err)
+		assert.Equal(t, "arn", ruleStatus.Arn)
+	})
+
 	t.Run("test create - one valid backendRef, two invalid", func(t *testing.T) {
 		mockLattice.EXPECT().GetRulesAsList(ctx, gomock.Any()).Return(
 			[]*vpclattice.GetRuleOutput{}, nil)
@@ -4,8 +4,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sort"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/vpclattice"
 
 	"github.com/aws/aws-application-networking-k8s/pkg/model/core"
 	model "github.com/aws/aws-application-networking-k8s/pkg/model/lattice"
@@ -50,7 +52,10 @@ func (r *ruleSynthesizer) Synthesize(ctx context.Context) error {
 	}
 
 	// svc id -> listener id -> rule id
-	snlStackRules := make(map[snlKey]ruleIdMap)
+	snlStackRules, err := r.listenersWithRules()
+	if err != nil {
+		return err
+	}
 
 	for _, rule := range resRule {
 		// this will also populate our map with rules for each service+listener
@@ -75,6 +80,42 @@ func (r *ruleSynthesizer) Synthesize(ctx context.Context) error {
 	return nil
 }
 
+// returns an empty rule map for every deployed listener in the stack, so stale lattice rules are
+// cleaned up even when all rules of a listener were dropped from the stack
+func (r *ruleSynthesizer) listenersWithRules() (map[snlKey]ruleIdMap, error) {
+	snlRules := make(map[snlKey]ruleIdMap)
+
+	var stackListeners []*model.Listener
+	err := r.stack.ListResources(&stackListeners)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, listener := range stackListeners {
+		// TLS_PASSTHROUGH listeners only have a default action
+		if listener.Spec.Protocol == vpclattice.ListenerProtocolTlsPassthrough || listener.Status == nil {
+			continue
+		}
+
+		svc := &model.Service{}
+		err = r.stack.GetResource(listener.Spec.StackServiceId, svc)
+		if err != nil {
+			return nil, err
+		}
+		if svc.Status == nil {
+			continue
+		}
+
+		key := snlKey{
+			SvcId:      svc.Status.Id,
+			ListenerId: listener.Status.Id,
+		}
+		snlRules[key] = make(ruleIdMap)
+	}
+
+	return snlRules, nil
+}
+
 func (r *ruleSynthesizer) createOrUpdateRules(ctx context.Context, rule *model.Rule, snlRules map[snlKey]ruleIdMap) error {
 	stackListener, stackSvc, err := r.getStackObjects(rule)
 	if err != nil {
@@ -104,6 +145,10 @@ func (r *ruleSynthesizer) createOrUpdateRules(ctx context.Context, rule *model.R
 		snlRules[key] = ruleMap
 	}
 
+	// a route rule with several matches is built as several stack rules, each must land on its own lattice rule
+	if existing, ok := ruleMap[rule.Status.Id]; ok && existing != rule {
+		return fmt.Errorf("rules %s and %s resolve to the same lattice rule %s", existing.ID(), rule.ID(), rule.Status.Id)
+	}
 	ruleMap[rule.Status.Id] = rule
 	return nil
 }
@@ -137,28 +182,36 @@ func (r *ruleSynthesizer) deleteStaleLatticeRules(ctx context.Context, snlRules
 	return delErr
 }
 
+// Only rules whose lattice priority differs from the stack priority are updated, as one batch per
+// listener. Stack priorities are unique per listener, so the rules keeping their priority never
+// conflict with the updated ones.
 func (r *ruleSynthesizer) adjustPriorities(ctx context.Context, snlStackRules map[snlKey]ruleIdMap, resRule []*model.Rule) error {
 	var updateErr error
 	for snl := range snlStackRules {
-		activeRules := snlStackRules[snl]
-		for _, rule := range activeRules {
+		var rulesToUpdate []*model.Rule
+		for _, rule := range snlStackRules[snl] {
 			if rule.Spec.Priority != rule.Status.Priority {
-				// *any* mismatch in priority prompts a batch update of ALL priorities
-				r.log.Debugf("Found rule priority mismatch, update required")
-
-				var rulesToUpdate []*model.Rule
-				for _, snlRule := range activeRules {
-					rulesToUpdate = append(rulesToUpdate, snlRule)
-				}
-
-				err := r.ruleManager.UpdatePriorities(ctx, snl.SvcId, snl.ListenerId, rulesToUpdate)
-				if err != nil {
-					updateErr = errors.Join(updateErr,
-						fmt.Errorf("failed RuleManager.UpdatePriorities for rules %+v due to %s", resRule, err))
-				}
-				break
+				rulesToUpdate = append(rulesToUpdate, rule)
 			}
 		}
+		if len(rulesToUpdate) == 0 {
+			continue
+		}
+
+		// map iteration order is random, keep the batch stable
+		sort.Slice(rulesToUpdate, func(i, j int) bool {
+			return rulesToUpdate[i].Spec.Priority < rulesToUpdate[j].Spec.Priority
+		})
+		r.log.Debugf("Found %d rule priority mismatches on listener %s, update required", len(rulesToUpdate), snl.ListenerId)
+		err := r.ruleManager.UpdatePriorities(ctx, snl.SvcId, snl.ListenerId, rulesToUpdate)
+		if err != nil {
+			updateErr = errors.Join(updateErr,
+				fmt.Errorf("failed RuleManager.UpdatePriorities for rules %+v due to %s", resRule, err))
+			continue
+		}
+		for _, rule := range rulesToUpdate {
+			rule.Status.Priority = rule.Spec.Priority
+		}
 	}
 
 	return updateErr
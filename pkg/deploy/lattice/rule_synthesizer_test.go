@@ -134,4 +134,93 @@ func Test_SynthesizeRule(t *testing.T) {
 		rs := NewRuleSynthesizer(gwlog.FallbackLogger, mockRuleMgr, mockTgMgr, stack)
 		rs.Synthesize(ctx)
 	})
+
+	// rules fanned out from a route rule with multiple matches are synthesized individually,
+	// only the rules whose priority changed are updated
+	t.Run("multiple rules on a listener", func(t *testing.T) {
+		r2 := &model.Rule{
+			ResourceMeta: core.NewResourceMeta(stack, "AWS:VPCServiceNetwork::Rule", "rule-id-2"),
+			Spec: model.RuleSpec{
+				StackListenerId: l.ID(),
+				Priority:        2,
+				CreateTime:      time.Time{},
+				Action:          model.RuleAction{},
+			},
+			Status: nil,
+		}
+		assert.NoError(t, stack.AddResource(r2))
+
+		mockRuleMgr.EXPECT().Upsert(ctx, r, l, svc).Return(model.RuleStatus{
+			Id:       "rule-id",
+			Priority: 1,
+		}, nil)
+		mockRuleMgr.EXPECT().Upsert(ctx, r2, l, svc).Return(model.RuleStatus{
+			Id:       "rule-id-2",
+			Priority: 3, // <-- this should trigger an update of this rule only
+		}, nil)
+
+		mockRuleMgr.EXPECT().List(ctx, "svc-id", "listener-id").Return(
+			[]*vpclattice.RuleSummary{
+				{
+					Id:        aws.String("default-id"),
+					IsDefault: aws.Bool(true),
+				},
+				{
+					Id: aws.String("rule-id"),
+				},
+				{
+					Id: aws.String("rule-id-2"),
+				},
+			}, nil)
+
+		mockRuleMgr.EXPECT().UpdatePriorities(ctx, "svc-id", "listener-id", gomock.Any()).DoAndReturn(
+			func(ctx context.Context, svcId string, listenerId string, rules []*model.Rule) error {
+				assert.Equal(t, 1, len(rules))
+				assert.Equal(t, "rule-id-2", rules[0].Status.Id)
+				return nil
+			})
+		mockTgMgr.EXPECT().ResolveRuleTgIds(ctx, gomock.Any(), stack).Return(nil).Times(2)
+
+		rs := NewRuleSynthesizer(gwlog.FallbackLogger, mockRuleMgr, mockTgMgr, stack)
+		assert.NoError(t, rs.Synthesize(ctx))
+	})
+}
+
+func Test_SynthesizeRule_DeletesRulesOfListenerWithoutStackRules(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+	ctx := context.TODO()
+	mockRuleMgr := NewMockRuleManager(c)
+	mockTgMgr := NewMockTargetGroupManager(c)
+
+	stack := core.NewDefaultStack(core.StackID{Name: "foo", Namespace: "bar"})
+
+	svc := &model.Service{
+		ResourceMeta: core.NewResourceMeta(stack, "AWS:VPCServiceNetwork::Service", "svc-id"),
+		Status:       &model.ServiceStatus{Id: "svc-id"},
+	}
+	assert.NoError(t, stack.AddResource(svc))
+
+	l := &model.Listener{
+		ResourceMeta: core.NewResourceMeta(stack, "AWS:VPCServiceNetwork::Listener", "listener-id"),
+		Spec:         model.ListenerSpec{StackServiceId: svc.ID(), Protocol: vpclattice.ListenerProtocolHttp},
+		Status:       &model.ListenerStatus{Id: "listener-id"},
+	}
+	assert.NoError(t, stack.AddResource(l))
+
+	// all rules of the route were dropped, so the listener has no rules in the stack
+	mockRuleMgr.EXPECT().List(ctx, "svc-id", "listener-id").Return(
+		[]*vpclattice.RuleSummary{
+			{
+				Id:        aws.String("default-id"),
+				IsDefault: aws.Bool(true),
+			},
+			{
+				Id: aws.String("dropped-rule-id"), // <-- should delete this rule
+			},
+		}, nil)
+	mockRuleMgr.EXPECT().Delete(ctx, "dropped-rule-id", "svc-id", "listener-id").Return(nil)
+
+	rs := NewRuleSynthesizer(gwlog.FallbackLogger, mockRuleMgr, mockTgMgr, stack)
+	assert.NoError(t, rs.Synthesize(ctx))
 }
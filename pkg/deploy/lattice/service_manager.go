@@ -137,13 +137,13 @@ func (m *defaultServiceManager) checkAndUpdateTags(ctx context.Context, svc *Ser
 		return err
 	}
 	if !owned {
-		return services.NewConflictError("service", svc.Spec.RouteNamespace+"/"+svc.Spec.RouteName,
+		return services.NewConflictError("service", svc.Spec.OwnerName(),
 			fmt.Sprintf("Found existing resource not owned by controller: %s", *svcSum.Arn))
 	}
 
 	tagFields := model.ServiceTagFieldsFromTags(tagsResp.Tags)
 	switch {
-	case tagFields.RouteName == "" && tagFields.RouteNamespace == "":
+	case tagFields.RouteName == "" && tagFields.RouteNamespace == "" && !tagFields.IsMerged():
 		// backwards compatibility: If the service has no identification tags, consider this controller has
 		// correct information and add tags
 		_, err = m.cloud.Lattice().TagResourceWithContext(ctx, &vpclattice.TagResourceInput{
@@ -155,7 +155,8 @@ func (m *defaultServiceManager) checkAndUpdateTags(ctx context.Context, svc *Ser
 		// Considering these scenarios:
 		// - two services with same namespace-name but different routeType
 		// - two services with conflict edge case such as my-namespace/service & my/namespace-service
-		return services.NewConflictError("service", svc.Spec.RouteName+"/"+svc.Spec.RouteNamespace,
+		// - a route service and a merged service
+		return services.NewConflictError("service", svc.Spec.OwnerName(),
 			fmt.Sprintf("Found existing resource with conflicting service name: %s", *svcSum.Arn))
 	}
 	return nil
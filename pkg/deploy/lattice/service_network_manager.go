@@ -26,6 +26,9 @@ type ServiceNetworkManager interface {
 	DeleteVpcAssociation(ctx context.Context, snName string) error
 
 	CreateOrUpdate(ctx context.Context, serviceNetwork *model.ServiceNetwork) (model.ServiceNetworkStatus, error)
+	// Delete deletes the service network and its association to the cluster VPC. Service networks without
+	// the managed-by tag of the controller were not created by it and are left untouched.
+	Delete(ctx context.Context, snName string) error
 }
 
 func NewDefaultServiceNetworkManager(log gwlog.Logger, cloud pkg_aws.Cloud) *defaultServiceNetworkManager {
@@ -167,7 +170,7 @@ func (m *defaultServiceNetworkManager) getActiveVpcAssociation(ctx context.Conte
 	}
 }
 
-// The controller does not manage service network anymore, just having to upsert SN and SNVA for default SN setup.
+// Upserts SN and SNVA for the default SN setup, and for gateways managing their service network.
 // This function does not care about the association status, the caller is not supposed to wait for it.
 func (m *defaultServiceNetworkManager) CreateOrUpdate(ctx context.Context, serviceNetwork *model.ServiceNetwork) (model.ServiceNetworkStatus, error) {
 	// check if exists
@@ -185,7 +188,10 @@ func (m *defaultServiceNetworkManager) CreateOrUpdate(ctx context.Context, servi
 
 		serviceNetworkInput := vpclattice.CreateServiceNetworkInput{
 			Name: &serviceNetwork.Spec.Name,
-			Tags: m.cloud.DefaultTags(),
+			Tags: m.cloud.DefaultTagsMergedWith(aws.StringMap(serviceNetwork.Spec.Tags)),
+		}
+		if serviceNetwork.Spec.AuthType != "" {
+			serviceNetworkInput.AuthType = &serviceNetwork.Spec.AuthType
 		}
 		resp, err := vpcLatticeSess.CreateServiceNetworkWithContext(ctx, &serviceNetworkInput)
 		if err != nil {
@@ -214,7 +220,8 @@ func (m *defaultServiceNetworkManager) CreateOrUpdate(ctx context.Context, servi
 	createServiceNetworkVpcAssociationInput := vpclattice.CreateServiceNetworkVpcAssociationInput{
 		ServiceNetworkIdentifier: &serviceNetworkId,
 		VpcIdentifier:            &config.VpcID,
-		Tags:                     m.cloud.DefaultTags(),
+		SecurityGroupIds:         serviceNetwork.Spec.SecurityGroupIds,
+		Tags:                     m.cloud.DefaultTagsMergedWith(aws.StringMap(serviceNetwork.Spec.Tags)),
 	}
 	_, err = vpcLatticeSess.CreateServiceNetworkVpcAssociationWithContext(ctx, &createServiceNetworkVpcAssociationInput)
 	if err != nil {
@@ -223,6 +230,37 @@ func (m *defaultServiceNetworkManager) CreateOrUpdate(ctx context.Context, servi
 	return model.ServiceNetworkStatus{ServiceNetworkARN: serviceNetworkArn, ServiceNetworkID: serviceNetworkId}, nil
 }
 
+func (m *defaultServiceNetworkManager) Delete(ctx context.Context, snName string) error {
+	sn, err := m.cloud.Lattice().FindServiceNetwork(ctx, snName)
+	if err != nil {
+		if services.IsNotFoundError(err) {
+			return nil
+		}
+		return err
+	}
+	// tags of service networks shared by another account are not listed, they are never owned
+	if !m.cloud.IsManagedByTags(sn.Tags) {
+		m.log.Infof("ServiceNetwork %s is not managed by controller, skipping deletion", snName)
+		return nil
+	}
+
+	// retried until the association is deleted, a service network cannot be deleted before
+	if err := m.DeleteVpcAssociation(ctx, snName); err != nil {
+		return err
+	}
+	_, err = m.cloud.Lattice().DeleteServiceNetworkWithContext(ctx, &vpclattice.DeleteServiceNetworkInput{
+		ServiceNetworkIdentifier: sn.SvcNetwork.Id,
+	})
+	if err != nil {
+		if services.IsLatticeAPINotFoundErr(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to delete service network %s: %w", snName, err)
+	}
+	m.log.Infof("Deleted ServiceNetwork %s", snName)
+	return nil
+}
+
 func (m *defaultServiceNetworkManager) updateServiceNetworkVpcAssociation(ctx context.Context, existingSN *vpclattice.ServiceNetworkSummary, sgIds []*string, existingSnvaId *string) (model.ServiceNetworkStatus, error) {
 	snva, err := m.cloud.Lattice().GetServiceNetworkVpcAssociationWithContext(ctx, &vpclattice.GetServiceNetworkVpcAssociationInput{
 		ServiceNetworkVpcAssociationIdentifier: existingSnvaId,
@@ -50,6 +50,20 @@ func (mr *MockServiceNetworkManagerMockRecorder) CreateOrUpdate(arg0, arg1 inter
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrUpdate", reflect.TypeOf((*MockServiceNetworkManager)(nil).CreateOrUpdate), arg0, arg1)
 }
 
+// Delete mocks base method.
+func (m *MockServiceNetworkManager) Delete(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockServiceNetworkManagerMockRecorder) Delete(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockServiceNetworkManager)(nil).Delete), arg0, arg1)
+}
+
 // DeleteVpcAssociation mocks base method.
 func (m *MockServiceNetworkManager) DeleteVpcAssociation(arg0 context.Context, arg1 string) error {
 	m.ctrl.T.Helper()
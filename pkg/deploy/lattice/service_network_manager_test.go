@@ -74,6 +74,45 @@ func Test_CreateOrUpdateServiceNetwork_SnNotExist_NeedToAssociate(t *testing.T)
 }
 
 // List and find sn does not work.
+func Test_CreateOrUpdateServiceNetwork_SnNotExist_WithAuthTypeTagsAndSecurityGroups(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+	ctx := context.TODO()
+	mockLattice := mocks.NewMockLattice(c)
+	cloud := pkg_aws.NewDefaultCloud(mockLattice, TestCloudConfig)
+
+	sgIds := []*string{aws.String("sg-1")}
+	sn := model.ServiceNetwork{
+		Spec: model.ServiceNetworkSpec{
+			Name:             "test",
+			AuthType:         vpclattice.AuthTypeAwsIam,
+			Tags:             map[string]string{"team": "a"},
+			SecurityGroupIds: sgIds,
+		},
+	}
+	tags := cloud.DefaultTagsMergedWith(mocks.Tags{"team": aws.String("a")})
+
+	mockLattice.EXPECT().FindServiceNetwork(ctx, "test").Return(nil, mocks.NewNotFoundError("ServiceNetwork", "test"))
+	mockLattice.EXPECT().CreateServiceNetworkWithContext(ctx, &vpclattice.CreateServiceNetworkInput{
+		Name:     aws.String("test"),
+		AuthType: aws.String(vpclattice.AuthTypeAwsIam),
+		Tags:     tags,
+	}).Return(&vpclattice.CreateServiceNetworkOutput{Arn: aws.String("sn-arn"), Id: aws.String("sn-id")}, nil)
+	mockLattice.EXPECT().CreateServiceNetworkVpcAssociationWithContext(ctx, &vpclattice.CreateServiceNetworkVpcAssociationInput{
+		ServiceNetworkIdentifier: aws.String("sn-id"),
+		VpcIdentifier:            &config.VpcID,
+		SecurityGroupIds:         sgIds,
+		Tags:                     tags,
+	}).Return(&vpclattice.CreateServiceNetworkVpcAssociationOutput{}, nil)
+
+	snMgr := NewDefaultServiceNetworkManager(gwlog.FallbackLogger, cloud)
+	resp, err := snMgr.CreateOrUpdate(ctx, &sn)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "sn-arn", resp.ServiceNetworkARN)
+	assert.Equal(t, "sn-id", resp.ServiceNetworkID)
+}
+
 func Test_CreateOrUpdateServiceNetwork_ListFailed(t *testing.T) {
 	c := gomock.NewController(t)
 	defer c.Finish()
@@ -686,3 +725,78 @@ func Test_defaultServiceNetworkManager_CreateOrUpdate_SnExists_SnvaExists_Cannot
 
 	assert.Equal(t, err, updateSNVAError)
 }
+
+func Test_DeleteServiceNetwork(t *testing.T) {
+	snId := "sn-id"
+	snArn := "sn-arn"
+	snvaArn := "snva-arn"
+	snvaId := "snva-id"
+	activeStatus := vpclattice.ServiceNetworkVpcAssociationStatusActive
+	otherManagedBy := "other-account/other-cluster/other-vpc"
+
+	tests := []struct {
+		name        string
+		setup       func(mockLattice *mocks.MockLattice, cloud pkg_aws.Cloud)
+		expectedErr error
+	}{
+		{
+			name: "service network not found",
+			setup: func(mockLattice *mocks.MockLattice, cloud pkg_aws.Cloud) {
+				mockLattice.EXPECT().FindServiceNetwork(gomock.Any(), "test").
+					Return(nil, mocks.NewNotFoundError("Service network", "test"))
+			},
+		},
+		{
+			name: "service network not managed by controller is kept",
+			setup: func(mockLattice *mocks.MockLattice, cloud pkg_aws.Cloud) {
+				mockLattice.EXPECT().FindServiceNetwork(gomock.Any(), "test").Return(&mocks.ServiceNetworkInfo{
+					SvcNetwork: vpclattice.ServiceNetworkSummary{Arn: &snArn, Id: &snId},
+					Tags:       mocks.Tags{pkg_aws.TagManagedBy: &otherManagedBy},
+				}, nil)
+			},
+		},
+		{
+			name: "managed service network without vpc association is deleted",
+			setup: func(mockLattice *mocks.MockLattice, cloud pkg_aws.Cloud) {
+				mockLattice.EXPECT().FindServiceNetwork(gomock.Any(), "test").Return(&mocks.ServiceNetworkInfo{
+					SvcNetwork: vpclattice.ServiceNetworkSummary{Arn: &snArn, Id: &snId},
+					Tags:       cloud.DefaultTags(),
+				}, nil).Times(2)
+				mockLattice.EXPECT().ListServiceNetworkVpcAssociationsAsList(gomock.Any(), gomock.Any()).Return(nil, nil)
+				mockLattice.EXPECT().DeleteServiceNetworkWithContext(gomock.Any(), &vpclattice.DeleteServiceNetworkInput{
+					ServiceNetworkIdentifier: &snId,
+				}).Return(&vpclattice.DeleteServiceNetworkOutput{}, nil)
+			},
+		},
+		{
+			name: "vpc association is deleted first",
+			setup: func(mockLattice *mocks.MockLattice, cloud pkg_aws.Cloud) {
+				mockLattice.EXPECT().FindServiceNetwork(gomock.Any(), "test").Return(&mocks.ServiceNetworkInfo{
+					SvcNetwork: vpclattice.ServiceNetworkSummary{Arn: &snArn, Id: &snId},
+					Tags:       cloud.DefaultTags(),
+				}, nil).Times(2)
+				mockLattice.EXPECT().ListServiceNetworkVpcAssociationsAsList(gomock.Any(), gomock.Any()).Return(
+					[]*vpclattice.ServiceNetworkVpcAssociationSummary{{Arn: &snvaArn, Id: &snvaId, Status: &activeStatus}}, nil)
+				mockLattice.EXPECT().ListTagsForResourceWithContext(gomock.Any(), gomock.Any()).
+					Return(&vpclattice.ListTagsForResourceOutput{Tags: cloud.DefaultTags()}, nil)
+				mockLattice.EXPECT().DeleteServiceNetworkVpcAssociationWithContext(gomock.Any(), gomock.Any()).
+					Return(&vpclattice.DeleteServiceNetworkVpcAssociationOutput{}, nil)
+			},
+			expectedErr: errors.New(LATTICE_RETRY),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+			mockLattice := mocks.NewMockLattice(c)
+			cloud := pkg_aws.NewDefaultCloud(mockLattice, TestCloudConfig)
+			tt.setup(mockLattice, cloud)
+
+			snMgr := NewDefaultServiceNetworkManager(gwlog.FallbackLogger, cloud)
+			err := snMgr.Delete(context.TODO(), "test")
+			assert.Equal(t, tt.expectedErr, err)
+		})
+	}
+}
@@ -5,8 +5,6 @@ import (
 	"errors"
 	"fmt"
 
-	"github.com/aws/aws-application-networking-k8s/pkg/utils"
-
 	"github.com/aws/aws-application-networking-k8s/pkg/deploy/externaldns"
 	"github.com/aws/aws-application-networking-k8s/pkg/model/core"
 	model "github.com/aws/aws-application-networking-k8s/pkg/model/lattice"
@@ -40,7 +38,7 @@ func (s *serviceSynthesizer) Synthesize(ctx context.Context) error {
 
 	var svcErr error
 	for _, resService := range resServices {
-		svcName := utils.LatticeServiceName(resService.Spec.RouteName, resService.Spec.RouteNamespace)
+		svcName := resService.LatticeServiceName()
 		s.log.Debugf("Synthesizing service: %s", svcName)
 		if resService.IsDeleted {
 			err := s.serviceManager.Delete(ctx, resService)
@@ -49,6 +47,13 @@ func (s *serviceSynthesizer) Synthesize(ctx context.Context) error {
 					fmt.Errorf("failed ServiceManager.Delete %s due to %w", svcName, err))
 				continue
 			}
+
+			err = s.dnsEndpointManager.Delete(ctx, resService)
+			if err != nil {
+				svcErr = errors.Join(svcErr,
+					fmt.Errorf("failed DnsEndpointManager.Delete %s due to %w", svcName, err))
+				continue
+			}
 		} else {
 			serviceStatus, err := s.serviceManager.Upsert(ctx, resService)
 			if err != nil {
@@ -58,10 +63,10 @@ func (s *serviceSynthesizer) Synthesize(ctx context.Context) error {
 			}
 
 			resService.Status = &serviceStatus
-			err = s.dnsEndpointManager.Create(ctx, resService)
+			err = s.dnsEndpointManager.Reconcile(ctx, resService)
 			if err != nil {
 				svcErr = errors.Join(svcErr,
-					fmt.Errorf("failed DnsEndpointManager.Create %s due to %w", svcName, err))
+					fmt.Errorf("failed DnsEndpointManager.Reconcile %s due to %w", svcName, err))
 				continue
 			}
 		}
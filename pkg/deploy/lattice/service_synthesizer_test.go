@@ -146,6 +146,31 @@ func Test_SynthesizeService(t *testing.T) {
 			wantIsDeleted: false,
 			wantErrIsNil:  false,
 		},
+		{
+			name: "Delete LatticeService, getting error deleting DNS",
+
+			httpRoute: &gwv1beta1.HTTPRoute{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              "service5",
+					Finalizers:        []string{"gateway.k8s.aws/resources"},
+					DeletionTimestamp: &now,
+				},
+				Spec: gwv1beta1.HTTPRouteSpec{
+					CommonRouteSpec: gwv1beta1.CommonRouteSpec{
+						ParentRefs: []gwv1beta1.ParentReference{
+							{
+								Name: "gateway2",
+							},
+						},
+					},
+				},
+			},
+			serviceARN:    "arn1234",
+			serviceID:     "56789",
+			dnsErr:        errors.New("Failed deleting DNS"),
+			wantIsDeleted: true,
+			wantErrIsNil:  false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -176,8 +201,12 @@ func Test_SynthesizeService(t *testing.T) {
 				mockSvcManager.EXPECT().Upsert(ctx, latticeService).Return(model.ServiceStatus{Arn: tt.serviceARN, Id: tt.serviceID}, tt.mgrErr)
 			}
 
-			if !latticeService.IsDeleted && tt.mgrErr == nil {
-				mockDnsManager.EXPECT().Create(ctx, gomock.Any()).Return(tt.dnsErr)
+			if tt.mgrErr == nil {
+				if latticeService.IsDeleted {
+					mockDnsManager.EXPECT().Delete(ctx, gomock.Any()).Return(tt.dnsErr)
+				} else {
+					mockDnsManager.EXPECT().Reconcile(ctx, gomock.Any()).Return(tt.dnsErr)
+				}
 			}
 
 			synthesizer := NewServiceSynthesizer(gwlog.FallbackLogger, mockSvcManager, mockDnsManager, stack)
@@ -4,9 +4,11 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strconv"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/vpclattice"
+	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
 
 	"github.com/aws/aws-application-networking-k8s/pkg/aws/services"
 	"github.com/aws/aws-application-networking-k8s/pkg/model/core"
@@ -25,6 +27,7 @@ type TargetGroupManager interface {
 	Upsert(ctx context.Context, modelTg *model.TargetGroup) (model.TargetGroupStatus, error)
 	Delete(ctx context.Context, modelTg *model.TargetGroup) error
 	List(ctx context.Context) ([]tgListOutput, error)
+	ListSvcExportTGs(ctx context.Context) ([]*model.TargetGroup, error)
 	IsTargetGroupMatch(ctx context.Context, modelTg *model.TargetGroup, latticeTg *vpclattice.TargetGroupSummary,
 		latticeTags *model.TargetGroupTagFields) (bool, error)
 	ResolveRuleTgIds(ctx context.Context, modelRuleAction *model.RuleAction, stack core.Stack) error
@@ -98,6 +101,9 @@ func (s *defaultTargetGroupManager) create(ctx context.Context, modelTg *model.T
 		createInput.Tags[model.K8SRouteNameKey] = &modelTg.Spec.K8SRouteName
 		createInput.Tags[model.K8SRouteNamespaceKey] = &modelTg.Spec.K8SRouteNamespace
 	}
+	if modelTg.Spec.K8SServicePort != 0 {
+		createInput.Tags[model.K8SServicePortKey] = aws.String(strconv.Itoa(int(modelTg.Spec.K8SServicePort)))
+	}
 
 	lattice := s.cloud.Lattice()
 	resp, err := lattice.CreateTargetGroupWithContext(ctx, &createInput)
@@ -293,8 +299,17 @@ func (s *defaultTargetGroupManager) findTargetGroup(
 	if len(arns) == 0 {
 		return nil, nil
 	}
+	// tags of the model absent from the search match any value, the target groups of the ports of a service export
+	// must not be found for the target group exported without a port
+	arnToTags, err := s.cloud.Tagging().GetTagsForArns(ctx, arns)
+	if err != nil {
+		return nil, err
+	}
 
 	for _, arn := range arns {
+		if model.TGTagFieldsFromTags(arnToTags[arn]).K8SServicePort != modelTargetGroup.Spec.K8SServicePort {
+			continue
+		}
 		latticeTg, err := s.cloud.Lattice().GetTargetGroupWithContext(ctx, &vpclattice.GetTargetGroupInput{
 			TargetGroupIdentifier: &arn,
 		})
@@ -319,7 +334,7 @@ func (s *defaultTargetGroupManager) findTargetGroup(
 			IpAddressType: latticeTg.Config.IpAddressType,
 			Type:          latticeTg.Type,
 			VpcIdentifier: latticeTg.Config.VpcIdentifier,
-		}, nil) // we already know that tags match
+		}, nil) // we already know that tags match, the port tag was compared above
 		if err != nil {
 			return nil, err
 		}
@@ -437,22 +452,77 @@ func (s *defaultTargetGroupManager) fillDefaultHealthCheckConfig(hc *vpclattice.
 	}
 }
 
-func (s *defaultTargetGroupManager) findSvcExportTG(ctx context.Context, svcImportTg model.SvcImportTargetGroup) (string, error) {
+// ListSvcExportTGs returns the target groups of the ServiceExports of all clusters, built from their
+// summary and tags
+func (s *defaultTargetGroupManager) ListSvcExportTGs(ctx context.Context) ([]*model.TargetGroup, error) {
 	tgs, err := s.List(ctx)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
+	var svcExportTgs []*model.TargetGroup
 	for _, tg := range tgs {
 		tgTags := model.TGTagFieldsFromTags(tg.tags)
-		svcMatch := tgTags.IsSourceTypeServiceExport() && (tgTags.K8SServiceName == svcImportTg.K8SServiceName) &&
-			(tgTags.K8SServiceNamespace == svcImportTg.K8SServiceNamespace)
-		clusterMatch := (svcImportTg.K8SClusterName == "") || (tgTags.K8SClusterName == svcImportTg.K8SClusterName)
-		vpcMatch := (svcImportTg.VpcId == "") || (svcImportTg.VpcId == aws.StringValue(tg.tgSummary.VpcIdentifier))
-		if svcMatch && clusterMatch && vpcMatch {
-			return *tg.tgSummary.Id, nil
+		if !tgTags.IsSourceTypeServiceExport() {
+			continue
 		}
+		svcExportTgs = append(svcExportTgs, &model.TargetGroup{
+			Spec: model.TargetGroupSpec{
+				VpcId:                aws.StringValue(tg.tgSummary.VpcIdentifier),
+				Type:                 model.TargetGroupType(aws.StringValue(tg.tgSummary.Type)),
+				Port:                 int32(aws.Int64Value(tg.tgSummary.Port)),
+				Protocol:             aws.StringValue(tg.tgSummary.Protocol),
+				ProtocolVersion:      tgTags.K8SProtocolVersion,
+				IpAddressType:        aws.StringValue(tg.tgSummary.IpAddressType),
+				TargetGroupTagFields: tgTags,
+			},
+			Status: &model.TargetGroupStatus{
+				Name: aws.StringValue(tg.tgSummary.Name),
+				Arn:  aws.StringValue(tg.tgSummary.Arn),
+				Id:   aws.StringValue(tg.tgSummary.Id),
+			},
+		})
+	}
+	return svcExportTgs, nil
+}
+
+// ServiceImportError is returned when the target group of a ServiceImport backendRef cannot be found or used,
+// the route controller reports it as the ResolvedRefs condition of the route.
+type ServiceImportError struct {
+	Reason  gwv1.RouteConditionReason
+	Message string
+}
+
+func (e *ServiceImportError) Error() string {
+	return e.Message
+}
+
+func (s *defaultTargetGroupManager) findSvcExportTG(ctx context.Context, svcImportTg model.SvcImportTargetGroup) (string, error) {
+	tgs, err := s.ListSvcExportTGs(ctx)
+	if err != nil {
+		return "", err
+	}
+	// a target group exported for the selected port is preferred to one exported without a port, a target group
+	// exported for another port is never used
+	var unportedTgId string
+	for _, tg := range tgs {
+		if !svcImportTg.Matches(tg) {
+			continue
+		}
+		if tg.Spec.K8SServicePort == svcImportTg.K8SServicePort {
+			return tg.Status.Id, nil
+		}
+		if tg.Spec.K8SServicePort == 0 && unportedTgId == "" {
+			unportedTgId = tg.Status.Id
+		}
+	}
+	if unportedTgId != "" {
+		return unportedTgId, nil
+	}
+	return "", &ServiceImportError{
+		Reason: gwv1.RouteReasonBackendNotFound,
+		Message: fmt.Sprintf("no target group of service %s/%s is exported for port %d",
+			svcImportTg.K8SServiceNamespace, svcImportTg.K8SServiceName, svcImportTg.K8SServicePort),
 	}
-	return "", errors.New("target group for service import could not be found")
 }
 
 // ResolveRuleTgIds populates all target group ids in the rule's actions
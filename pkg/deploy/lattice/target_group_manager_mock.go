@@ -81,6 +81,21 @@ func (mr *MockTargetGroupManagerMockRecorder) List(arg0 interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockTargetGroupManager)(nil).List), arg0)
 }
 
+// ListSvcExportTGs mocks base method.
+func (m *MockTargetGroupManager) ListSvcExportTGs(arg0 context.Context) ([]*lattice0.TargetGroup, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSvcExportTGs", arg0)
+	ret0, _ := ret[0].([]*lattice0.TargetGroup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSvcExportTGs indicates an expected call of ListSvcExportTGs.
+func (mr *MockTargetGroupManagerMockRecorder) ListSvcExportTGs(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSvcExportTGs", reflect.TypeOf((*MockTargetGroupManager)(nil).ListSvcExportTGs), arg0)
+}
+
 // ResolveRuleTgIds mocks base method.
 func (m *MockTargetGroupManager) ResolveRuleTgIds(arg0 context.Context, arg1 *lattice0.RuleAction, arg2 core.Stack) error {
 	m.ctrl.T.Helper()
@@ -11,6 +11,7 @@ import (
 	"github.com/aws/aws-sdk-go/service/vpclattice"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
+	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
 
 	pkg_aws "github.com/aws/aws-application-networking-k8s/pkg/aws"
 	mocks "github.com/aws/aws-application-networking-k8s/pkg/aws/services"
@@ -154,6 +155,7 @@ func Test_CreateTargetGroup_TGFailed_Active(t *testing.T) {
 	}
 
 	mockTagging.EXPECT().FindResourcesByTags(ctx, gomock.Any(), gomock.Any()).Return([]string{arn}, nil)
+	mockTagging.EXPECT().GetTagsForArns(ctx, gomock.Any()).Return(nil, nil)
 	mockLattice.EXPECT().GetTargetGroupWithContext(ctx, gomock.Any()).Return(&tgOutput, nil)
 
 	mockLattice.EXPECT().CreateTargetGroupWithContext(ctx, gomock.Any()).Return(tgCreateOutput, nil)
@@ -165,6 +167,39 @@ func Test_CreateTargetGroup_TGFailed_Active(t *testing.T) {
 	assert.Equal(t, id, resp.Id)
 }
 
+// the target group of a declared port is not reused for the one exported without a port
+func Test_CreateTargetGroup_PortTargetGroupNotReused(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+	ctx := context.TODO()
+
+	mockLattice := mocks.NewMockLattice(c)
+	mockTagging := mocks.NewMockTagging(c)
+	cloud := pkg_aws.NewDefaultCloudWithTagging(mockLattice, mockTagging, TestCloudConfig)
+
+	tgSpec := model.TargetGroupSpec{}
+	tgSpec.K8SSourceType = model.SourceTypeSvcExport
+	tgSpec.K8SServiceName = "svc"
+	tgSpec.K8SServiceNamespace = "ns"
+	tgCreateInput := model.TargetGroup{Spec: tgSpec}
+
+	mockTagging.EXPECT().FindResourcesByTags(ctx, gomock.Any(), gomock.Any()).Return([]string{"port-tg-arn"}, nil)
+	mockTagging.EXPECT().GetTagsForArns(ctx, []string{"port-tg-arn"}).Return(map[string]mocks.Tags{
+		"port-tg-arn": {model.K8SServicePortKey: aws.String("80")},
+	}, nil)
+	mockLattice.EXPECT().CreateTargetGroupWithContext(ctx, gomock.Any()).Return(&vpclattice.CreateTargetGroupOutput{
+		Arn:    aws.String("tg-arn"),
+		Id:     aws.String("tg-id"),
+		Status: aws.String(vpclattice.TargetGroupStatusActive),
+	}, nil)
+
+	tgManager := NewTargetGroupManager(gwlog.FallbackLogger, cloud)
+	resp, err := tgManager.Upsert(ctx, &tgCreateInput)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "tg-arn", resp.Arn)
+}
+
 // target group status is active before creation, no need to recreate
 func Test_CreateTargetGroup_TGActive_UpdateHealthCheck(t *testing.T) {
 	tests := []struct {
@@ -229,6 +264,7 @@ func Test_CreateTargetGroup_TGActive_UpdateHealthCheck(t *testing.T) {
 			}
 
 			mockTagging.EXPECT().FindResourcesByTags(ctx, gomock.Any(), gomock.Any()).Return([]string{arn}, nil)
+			mockTagging.EXPECT().GetTagsForArns(ctx, gomock.Any()).Return(nil, nil)
 			mockLattice.EXPECT().GetTargetGroupWithContext(ctx, gomock.Any()).Return(&tgOutput, nil)
 
 			if tt.wantErr {
@@ -298,6 +334,7 @@ func Test_CreateTargetGroup_TGActive_HealthCheckSame(t *testing.T) {
 	}
 
 	mockTagging.EXPECT().FindResourcesByTags(ctx, gomock.Any(), gomock.Any()).Return([]string{"arn"}, nil)
+	mockTagging.EXPECT().GetTagsForArns(ctx, gomock.Any()).Return(nil, nil)
 	mockLattice.EXPECT().GetTargetGroupWithContext(ctx, gomock.Any()).Return(&tgOutput, nil)
 	mockLattice.EXPECT().UpdateTargetGroupWithContext(ctx, gomock.Any()).Times(0)
 
@@ -351,6 +388,7 @@ func Test_CreateTargetGroup_ExistingTG_Status_Retry(t *testing.T) {
 			}
 
 			mockTagging.EXPECT().FindResourcesByTags(ctx, gomock.Any(), gomock.Any()).Return([]string{arn}, nil)
+			mockTagging.EXPECT().GetTagsForArns(ctx, gomock.Any()).Return(nil, nil)
 			mockLattice.EXPECT().GetTargetGroupWithContext(ctx, gomock.Any()).Return(&tgOutput, nil)
 
 			tgManager := NewTargetGroupManager(gwlog.FallbackLogger, cloud)
@@ -566,6 +604,7 @@ func Test_DeleteTG_WithExistingTG(t *testing.T) {
 	var listTargetsOutput []*vpclattice.TargetSummary
 
 	mockTagging.EXPECT().FindResourcesByTags(ctx, gomock.Any(), gomock.Any()).Return([]string{*tgOutput.Arn}, nil)
+	mockTagging.EXPECT().GetTagsForArns(ctx, gomock.Any()).Return(nil, nil)
 	mockLattice.EXPECT().GetTargetGroupWithContext(ctx, gomock.Any()).Return(&tgOutput, nil)
 
 	mockLattice.EXPECT().ListTargetsAsList(ctx, gomock.Any()).Return(listTargetsOutput, nil)
@@ -847,6 +886,73 @@ func Test_ListTG_NoTG(t *testing.T) {
 	assert.Equal(t, tgList, expectTgList)
 }
 
+func Test_ListSvcExportTGs(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+	ctx := context.TODO()
+
+	svcExportTg := &vpclattice.TargetGroupSummary{
+		Arn:           aws.String("export-arn"),
+		Id:            aws.String("export-id"),
+		Name:          aws.String("k8s-ns-svc-http"),
+		VpcIdentifier: aws.String("vpc-1"),
+		Type:          aws.String(vpclattice.TargetGroupTypeIp),
+		Port:          aws.Int64(80),
+		Protocol:      aws.String(vpclattice.TargetGroupProtocolHttp),
+		IpAddressType: aws.String(vpclattice.IpAddressTypeIpv4),
+	}
+	routeTg := &vpclattice.TargetGroupSummary{
+		Arn:           aws.String("route-arn"),
+		Id:            aws.String("route-id"),
+		Name:          aws.String("k8s-ns-svc-route"),
+		VpcIdentifier: aws.String("vpc-1"),
+	}
+
+	mockLattice := mocks.NewMockLattice(c)
+	mockLattice.EXPECT().ListTargetGroupsAsList(ctx, gomock.Any()).
+		Return([]*vpclattice.TargetGroupSummary{svcExportTg, routeTg}, nil)
+	mockTagging := mocks.NewMockTagging(c)
+	mockTagging.EXPECT().GetTagsForArns(ctx, gomock.Any()).Return(map[string]mocks.Tags{
+		"export-arn": {
+			model.K8SClusterNameKey:      aws.String("cluster-1"),
+			model.K8SServiceNameKey:      aws.String("svc"),
+			model.K8SServiceNamespaceKey: aws.String("ns"),
+			model.K8SSourceTypeKey:       aws.String(string(model.SourceTypeSvcExport)),
+			model.K8SProtocolVersionKey:  aws.String(vpclattice.TargetGroupProtocolVersionHttp1),
+		},
+		"route-arn": {
+			model.K8SServiceNameKey:      aws.String("svc"),
+			model.K8SServiceNamespaceKey: aws.String("ns"),
+			model.K8SSourceTypeKey:       aws.String(string(model.SourceTypeHTTPRoute)),
+		},
+	}, nil)
+	cloud := pkg_aws.NewDefaultCloudWithTagging(mockLattice, mockTagging, TestCloudConfig)
+
+	tgManager := NewTargetGroupManager(gwlog.FallbackLogger, cloud)
+	tgs, err := tgManager.ListSvcExportTGs(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, []*model.TargetGroup{
+		{
+			Spec: model.TargetGroupSpec{
+				VpcId:           "vpc-1",
+				Type:            model.TargetGroupTypeIP,
+				Port:            80,
+				Protocol:        vpclattice.TargetGroupProtocolHttp,
+				ProtocolVersion: vpclattice.TargetGroupProtocolVersionHttp1,
+				IpAddressType:   vpclattice.IpAddressTypeIpv4,
+				TargetGroupTagFields: model.TargetGroupTagFields{
+					K8SClusterName:      "cluster-1",
+					K8SSourceType:       model.SourceTypeSvcExport,
+					K8SServiceName:      "svc",
+					K8SServiceNamespace: "ns",
+					K8SProtocolVersion:  vpclattice.TargetGroupProtocolVersionHttp1,
+				},
+			},
+			Status: &model.TargetGroupStatus{Name: "k8s-ns-svc-http", Arn: "export-arn", Id: "export-id"},
+		},
+	}, tgs)
+}
+
 func Test_defaultTargetGroupManager_getDefaultHealthCheckConfig(t *testing.T) {
 	var (
 		defaultMatcher = &vpclattice.Matcher{
@@ -1153,3 +1259,113 @@ func Test_ResolveRuleTgIds(t *testing.T) {
 	assert.Equal(t, "tg-id", stackRule.Spec.Action.TargetGroups[1].LatticeTgId)
 	assert.Equal(t, model.InvalidBackendRefTgId, stackRule.Spec.Action.TargetGroups[2].LatticeTgId)
 }
+
+func Test_ResolveRuleTgIds_ServiceImportPort(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+	ctx := context.TODO()
+	mockLattice := mocks.NewMockLattice(c)
+	mockTagging := mocks.NewMockTagging(c)
+	mockCloud := pkg_aws.NewMockCloud(c)
+	mockCloud.EXPECT().Lattice().Return(mockLattice).AnyTimes()
+	mockCloud.EXPECT().Tagging().Return(mockTagging).AnyTimes()
+
+	svcExportTags := func(port string) map[string]*string {
+		tags := map[string]*string{
+			model.K8SServiceNameKey:      aws.String("svc-name"),
+			model.K8SServiceNamespaceKey: aws.String("ns"),
+			model.K8SClusterNameKey:      aws.String("cluster-name"),
+			model.K8SSourceTypeKey:       aws.String(string(model.SourceTypeSvcExport)),
+		}
+		if port != "" {
+			tags[model.K8SServicePortKey] = aws.String(port)
+		}
+		return tags
+	}
+	mockTagging.EXPECT().GetTagsForArns(ctx, gomock.Any()).Return(
+		map[string]map[string]*string{
+			"http-tg-arn":      svcExportTags("80"),
+			"grpc-tg-arn":      svcExportTags("50051"),
+			"annotated-tg-arn": svcExportTags(""),
+		}, nil).AnyTimes()
+	mockLattice.EXPECT().ListTargetGroupsAsList(ctx, gomock.Any()).Return(
+		[]*vpclattice.TargetGroupSummary{
+			{Arn: aws.String("http-tg-arn"), Id: aws.String("http-tg-id")},
+			{Arn: aws.String("grpc-tg-arn"), Id: aws.String("grpc-tg-id")},
+			{Arn: aws.String("annotated-tg-arn"), Id: aws.String("annotated-tg-id")},
+		}, nil).AnyTimes()
+
+	tests := []struct {
+		port       int32
+		expectedId string
+	}{
+		{port: 50051, expectedId: "grpc-tg-id"},
+		{port: 80, expectedId: "http-tg-id"},
+		// target groups exported without a port are used for any other port
+		{port: 9090, expectedId: "annotated-tg-id"},
+		{port: 0, expectedId: "annotated-tg-id"},
+	}
+	s := NewTargetGroupManager(gwlog.FallbackLogger, mockCloud)
+	for _, tt := range tests {
+		ruleAction := &model.RuleAction{
+			TargetGroups: []*model.RuleTargetGroup{
+				{
+					SvcImportTG: &model.SvcImportTargetGroup{
+						K8SServiceName:      "svc-name",
+						K8SServiceNamespace: "ns",
+						K8SServicePort:      tt.port,
+					},
+				},
+			},
+		}
+		assert.NoError(t, s.ResolveRuleTgIds(ctx, ruleAction, nil))
+		assert.Equal(t, tt.expectedId, ruleAction.TargetGroups[0].LatticeTgId)
+	}
+}
+
+func Test_ResolveRuleTgIds_ServiceImportPortNotExported(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+	ctx := context.TODO()
+	mockLattice := mocks.NewMockLattice(c)
+	mockTagging := mocks.NewMockTagging(c)
+	mockCloud := pkg_aws.NewMockCloud(c)
+	mockCloud.EXPECT().Lattice().Return(mockLattice).AnyTimes()
+	mockCloud.EXPECT().Tagging().Return(mockTagging).AnyTimes()
+
+	mockTagging.EXPECT().GetTagsForArns(ctx, gomock.Any()).Return(
+		map[string]map[string]*string{
+			"http-tg-arn": {
+				model.K8SServiceNameKey:      aws.String("svc-name"),
+				model.K8SServiceNamespaceKey: aws.String("ns"),
+				model.K8SClusterNameKey:      aws.String("cluster-name"),
+				model.K8SSourceTypeKey:       aws.String(string(model.SourceTypeSvcExport)),
+				model.K8SServicePortKey:      aws.String("80"),
+			},
+		}, nil).AnyTimes()
+	mockLattice.EXPECT().ListTargetGroupsAsList(ctx, gomock.Any()).Return(
+		[]*vpclattice.TargetGroupSummary{
+			{Arn: aws.String("http-tg-arn"), Id: aws.String("http-tg-id")},
+		}, nil).AnyTimes()
+
+	// without a target group exported without a port, the target group of another port is never used
+	s := NewTargetGroupManager(gwlog.FallbackLogger, mockCloud)
+	for _, port := range []int32{9090, 0} {
+		ruleAction := &model.RuleAction{
+			TargetGroups: []*model.RuleTargetGroup{
+				{
+					SvcImportTG: &model.SvcImportTargetGroup{
+						K8SServiceName:      "svc-name",
+						K8SServiceNamespace: "ns",
+						K8SServicePort:      port,
+					},
+				},
+			},
+		}
+		err := s.ResolveRuleTgIds(ctx, ruleAction, nil)
+		var svcImportErr *ServiceImportError
+		assert.ErrorAs(t, err, &svcImportErr)
+		assert.Equal(t, gwv1.RouteReasonBackendNotFound, svcImportErr.Reason)
+		assert.Empty(t, ruleAction.TargetGroups[0].LatticeTgId)
+	}
+}
@@ -7,7 +7,10 @@ import (
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/vpclattice"
+	"golang.org/x/exp/slices"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 
 	anv1alpha1 "github.com/aws/aws-application-networking-k8s/pkg/apis/applicationnetworking/v1alpha1"
 	"github.com/aws/aws-application-networking-k8s/pkg/gateway"
@@ -214,6 +217,11 @@ func (t *TargetGroupSynthesizer) shouldDeleteSvcExportTg(
 	err := t.client.Get(ctx, svcExportName, svcExport)
 	if err != nil {
 		if apierrors.IsNotFound(err) {
+			if t.hasLegacyServiceExport(ctx, svcExportName) {
+				t.log.Infof("TargetGroup %s (%s) is kept until ServiceExport %s is migrated to group %s",
+					*latticeTg.tgSummary.Arn, *latticeTg.tgSummary.Name, svcExportName, anv1alpha1.MulticlusterGroupName)
+				return false
+			}
 			// if the service export does not exist, we can safely delete
 			t.log.Infof("Will delete TargetGroup %s (%s) - ServiceExport is not found",
 				*latticeTg.tgSummary.Arn, *latticeTg.tgSummary.Name)
@@ -235,7 +243,7 @@ func (t *TargetGroupSynthesizer) shouldDeleteSvcExportTg(
 	// now we get to the tricky business of seeing if our unused target group actually matches
 	// the current state of the service and service export - the most correct way to do this is to
 	// reconstruct the target group spec from the service export itself, then compare fields
-	modelTg, err := t.svcExportTgBuilder.BuildTargetGroup(ctx, svcExport)
+	modelTgs, err := t.svcExportTgBuilder.BuildTargetGroups(ctx, svcExport)
 	if err != nil {
 		t.log.Infof("Received error building svc export target group model %s", err)
 		return false
@@ -243,11 +251,14 @@ func (t *TargetGroupSynthesizer) shouldDeleteSvcExportTg(
 
 	// the main identifiers are validated, just need to check the other essentials.
 	// protocolVersion is not in TG summary so we are bringing it from tags.
-	if int64(modelTg.Spec.Port) != aws.Int64Value(latticeTg.tgSummary.Port) ||
-		modelTg.Spec.Protocol != aws.StringValue(latticeTg.tgSummary.Protocol) ||
-		modelTg.Spec.ProtocolVersion != tagFields.K8SProtocolVersion ||
-		modelTg.Spec.IpAddressType != aws.StringValue(latticeTg.tgSummary.IpAddressType) {
-
+	matchesModel := func(modelTg *model.TargetGroup) bool {
+		return int64(modelTg.Spec.Port) == aws.Int64Value(latticeTg.tgSummary.Port) &&
+			modelTg.Spec.Protocol == aws.StringValue(latticeTg.tgSummary.Protocol) &&
+			modelTg.Spec.ProtocolVersion == tagFields.K8SProtocolVersion &&
+			modelTg.Spec.IpAddressType == aws.StringValue(latticeTg.tgSummary.IpAddressType) &&
+			modelTg.Spec.K8SServicePort == tagFields.K8SServicePort
+	}
+	if !slices.ContainsFunc(modelTgs, matchesModel) {
 		// one or more immutable fields differ from the source, so the TG is out of date
 		t.log.Infof("Will delete TargetGroup %s (%s) - fields differ from source service/service export",
 			*latticeTg.tgSummary.Arn, *latticeTg.tgSummary.Name)
@@ -260,6 +271,25 @@ func (t *TargetGroupSynthesizer) shouldDeleteSvcExportTg(
 	return false
 }
 
+// hasLegacyServiceExport tells whether the service is still exported with the application-networking.k8s.aws group
+// while the controller handles the multicluster.x-k8s.io one. Such exports are migrated once the controller is
+// elected leader, or stay until their spec.ports are removed.
+func (t *TargetGroupSynthesizer) hasLegacyServiceExport(ctx context.Context, svcExportName types.NamespacedName) bool {
+	if config.MulticlusterAPIGroup != config.MulticlusterAPIGroupUpstream {
+		return false
+	}
+	legacy := &unstructured.Unstructured{}
+	legacy.SetGroupVersionKind(anv1alpha1.SchemeGroupVersion.WithKind("ServiceExport"))
+	if err := t.client.Get(ctx, svcExportName, legacy); err != nil {
+		if apierrors.IsNotFound(err) || meta.IsNoMatchError(err) {
+			return false
+		}
+		t.log.Infof("Received unexpected API error getting %s service export %s", anv1alpha1.GroupName, err)
+		return true
+	}
+	return legacy.GetDeletionTimestamp().IsZero()
+}
+
 func (t *TargetGroupSynthesizer) shouldDeleteRouteTg(
 	ctx context.Context, latticeTg tgListOutput, tagFields model.TargetGroupTagFields) bool {
 
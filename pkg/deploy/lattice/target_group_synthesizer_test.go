@@ -10,10 +10,12 @@ import (
 	"github.com/aws/aws-sdk-go/service/vpclattice"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	mock_client "github.com/aws/aws-application-networking-k8s/mocks/controller-runtime/client"
+	anv1alpha1 "github.com/aws/aws-application-networking-k8s/pkg/apis/applicationnetworking/v1alpha1"
 	"github.com/aws/aws-application-networking-k8s/pkg/config"
 	"github.com/aws/aws-application-networking-k8s/pkg/gateway"
 	"github.com/aws/aws-application-networking-k8s/pkg/model/core"
@@ -242,7 +244,7 @@ func Test_DoNotDeleteCases(t *testing.T) {
 	svcExportModelTg := baseModelTg
 	svcExportModelTg.Spec.TargetGroupTagFields.K8SSourceType = model.SourceTypeSvcExport
 
-	mockSvcExportTgBuilder.EXPECT().BuildTargetGroup(ctx, gomock.Any()).Return(&svcExportModelTg, nil)
+	mockSvcExportTgBuilder.EXPECT().BuildTargetGroups(ctx, gomock.Any()).Return([]*model.TargetGroup{&svcExportModelTg}, nil)
 
 	stack := core.NewDefaultStack(core.StackID{Name: "foo", Namespace: "bar"})
 	svcModelTg := baseModelTg
@@ -303,6 +305,31 @@ func Test_DeleteServiceExport_DeleteCases(t *testing.T) {
 		assert.Nil(t, err)
 	})
 
+	t.Run("Service Export not migrated to the upstream group", func(t *testing.T) {
+		multiclusterAPIGroup := config.MulticlusterAPIGroup
+		defer func() { config.MulticlusterAPIGroup = multiclusterAPIGroup }()
+		config.MulticlusterAPIGroup = config.MulticlusterAPIGroupUpstream
+
+		mockTGManager.EXPECT().List(ctx).Return(deleteTgs, nil)
+
+		mockClient.EXPECT().Get(ctx, gomock.Any(), gomock.AssignableToTypeOf(&anv1alpha1.ServiceExport{})).Return(
+			&apierrors.StatusError{
+				ErrStatus: metav1.Status{
+					Code:   http.StatusNotFound,
+					Reason: metav1.StatusReasonNotFound,
+				},
+			})
+		// the export of the application-networking.k8s.aws group still exists
+		mockClient.EXPECT().Get(ctx, gomock.Any(), gomock.AssignableToTypeOf(&unstructured.Unstructured{})).Return(nil)
+
+		synthesizer := NewTargetGroupSynthesizer(
+			gwlog.FallbackLogger, nil, mockClient, mockTGManager, mockSvcExportTgBuilder, nil, nil)
+
+		results, err := synthesizer.SynthesizeUnusedDelete(ctx)
+		assert.Nil(t, err)
+		assert.Empty(t, results)
+	})
+
 	t.Run("Service Export deleted", func(t *testing.T) {
 		mockTGManager.EXPECT().List(ctx).Return(deleteTgs, nil)
 
@@ -351,7 +378,50 @@ func Test_DeleteServiceExport_DeleteCases(t *testing.T) {
 			},
 		)
 
-		mockSvcExportTgBuilder.EXPECT().BuildTargetGroup(ctx, gomock.Any()).Return(&modelTg, nil)
+		mockSvcExportTgBuilder.EXPECT().BuildTargetGroups(ctx, gomock.Any()).Return([]*model.TargetGroup{&modelTg}, nil)
+
+		mockTGManager.EXPECT().List(ctx).Return(deleteTgs, nil)
+		mockTGManager.EXPECT().Delete(ctx, gomock.Any()).Return(nil)
+
+		synthesizer := NewTargetGroupSynthesizer(
+			gwlog.FallbackLogger, nil, mockClient, mockTGManager, mockSvcExportTgBuilder, nil, nil)
+
+		_, err := synthesizer.SynthesizeUnusedDelete(ctx)
+		assert.Nil(t, err)
+	})
+
+	t.Run("Service Export ports declared in spec", func(t *testing.T) {
+		newModelTg := func(port int32) *model.TargetGroup {
+			return &model.TargetGroup{
+				Spec: model.TargetGroupSpec{
+					VpcId:           "vpc-id",
+					Type:            "IP",
+					Port:            port,
+					Protocol:        "HTTP",
+					ProtocolVersion: "HTTP1",
+					IpAddressType:   "IPV4",
+					TargetGroupTagFields: model.TargetGroupTagFields{
+						K8SClusterName:      "cluster-name",
+						K8SServiceName:      "svc",
+						K8SServiceNamespace: "ns",
+						K8SSourceType:       model.SourceTypeSvcExport,
+						K8SServicePort:      port,
+					},
+				},
+			}
+		}
+
+		mockClient.EXPECT().Get(ctx, gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, name types.NamespacedName, svcExport client.Object, _ ...interface{}) error {
+				svcExport.SetName("svc")
+				svcExport.SetNamespace("ns")
+				return nil
+			},
+		)
+
+		// the port 80 target group is not tagged with the port, so it was created for the annotation
+		mockSvcExportTgBuilder.EXPECT().BuildTargetGroups(ctx, gomock.Any()).Return(
+			[]*model.TargetGroup{newModelTg(80), newModelTg(8080)}, nil)
 
 		mockTGManager.EXPECT().List(ctx).Return(deleteTgs, nil)
 		mockTGManager.EXPECT().Delete(ctx, gomock.Any()).Return(nil)
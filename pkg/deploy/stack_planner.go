@@ -0,0 +1,31 @@
+package deploy
+
+import (
+	"context"
+
+	pkg_aws "github.com/aws/aws-application-networking-k8s/pkg/aws"
+	"github.com/aws/aws-application-networking-k8s/pkg/deploy/lattice"
+	"github.com/aws/aws-application-networking-k8s/pkg/model/core"
+	"github.com/aws/aws-application-networking-k8s/pkg/utils/gwlog"
+)
+
+// StackPlanner computes the VPC Lattice changes deploying a stack would make, without making them
+type StackPlanner interface {
+	Plan(ctx context.Context, stack core.Stack) (lattice.ChangeSet, error)
+}
+
+type latticeServiceStackPlanner struct {
+	log   gwlog.Logger
+	cloud pkg_aws.Cloud
+}
+
+func NewLatticeServiceStackPlanner(log gwlog.Logger, cloud pkg_aws.Cloud) *latticeServiceStackPlanner {
+	return &latticeServiceStackPlanner{
+		log:   log,
+		cloud: cloud,
+	}
+}
+
+func (p *latticeServiceStackPlanner) Plan(ctx context.Context, stack core.Stack) (lattice.ChangeSet, error) {
+	return lattice.NewPlanner(p.log, p.cloud, stack).Plan(ctx)
+}
@@ -0,0 +1,143 @@
+package gateway
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"text/template"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	gwv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	anv1alpha1 "github.com/aws/aws-application-networking-k8s/pkg/apis/applicationnetworking/v1alpha1"
+	pkg_aws "github.com/aws/aws-application-networking-k8s/pkg/aws"
+	"github.com/aws/aws-application-networking-k8s/pkg/config"
+)
+
+// InvalidGatewayClassConfigError is returned when the parametersRef of a GatewayClass does not reference a valid
+// LatticeGatewayClassConfig. The GatewayClass is not accepted until it is fixed.
+type InvalidGatewayClassConfigError struct {
+	Message string
+}
+
+func (e *InvalidGatewayClassConfigError) Error() string {
+	return e.Message
+}
+
+// fields available to a ServiceNetworkNameTemplate
+type serviceNetworkNameData struct {
+	Name      string
+	Namespace string
+	ClassName string
+}
+
+// GetGatewayClassConfig returns the LatticeGatewayClassConfig referenced by the parametersRef of a GatewayClass,
+// nil when the class has no parametersRef.
+func GetGatewayClassConfig(ctx context.Context, c client.Client, gwClass *gwv1beta1.GatewayClass) (
+	*anv1alpha1.LatticeGatewayClassConfig, error,
+) {
+	ref := gwClass.Spec.ParametersRef
+	if ref == nil {
+		return nil, nil
+	}
+	if string(ref.Group) != anv1alpha1.GroupName || string(ref.Kind) != anv1alpha1.LatticeGatewayClassConfigKind {
+		return nil, &InvalidGatewayClassConfigError{Message: fmt.Sprintf("parametersRef must reference a %s of group %s, got %s of group %s",
+			anv1alpha1.LatticeGatewayClassConfigKind, anv1alpha1.GroupName, ref.Kind, ref.Group)}
+	}
+	if ref.Namespace != nil {
+		return nil, &InvalidGatewayClassConfigError{Message: fmt.Sprintf("parametersRef must not have a namespace, %s is cluster-scoped",
+			anv1alpha1.LatticeGatewayClassConfigKind)}
+	}
+
+	classConfig := &anv1alpha1.LatticeGatewayClassConfig{}
+	if err := c.Get(ctx, types.NamespacedName{Name: ref.Name}, classConfig); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, &InvalidGatewayClassConfigError{Message: fmt.Sprintf("%s %s not found",
+				anv1alpha1.LatticeGatewayClassConfigKind, ref.Name)}
+		}
+		return nil, err
+	}
+	if err := validateGatewayClassConfig(classConfig); err != nil {
+		return nil, &InvalidGatewayClassConfigError{Message: fmt.Sprintf("%s %s is invalid: %s",
+			anv1alpha1.LatticeGatewayClassConfigKind, ref.Name, err)}
+	}
+	return classConfig, nil
+}
+
+// GetGatewayClassConfigOfGateway returns the LatticeGatewayClassConfig of the class of a gateway, nil when the
+// class is not found, is not managed by this controller, or has no parametersRef.
+func GetGatewayClassConfigOfGateway(ctx context.Context, c client.Client, gw *gwv1beta1.Gateway) (
+	*anv1alpha1.LatticeGatewayClassConfig, error,
+) {
+	gwClass := &gwv1beta1.GatewayClass{}
+	if err := c.Get(ctx, types.NamespacedName{Name: string(gw.Spec.GatewayClassName)}, gwClass); err != nil {
+		return nil, client.IgnoreNotFound(err)
+	}
+	if gwClass.Spec.ControllerName != config.LatticeGatewayControllerName {
+		return nil, nil
+	}
+	return GetGatewayClassConfig(ctx, c, gwClass)
+}
+
+// ServiceNetworkNameOfGateway returns the name of the service network of a gateway, given by the
+// ServiceNetworkNameTemplate of its class config and defaulting to the gateway name.
+func ServiceNetworkNameOfGateway(gw *gwv1beta1.Gateway, classConfig *anv1alpha1.LatticeGatewayClassConfig) (string, error) {
+	if classConfig == nil || classConfig.Spec.ServiceNetworkNameTemplate == "" {
+		return gw.Name, nil
+	}
+	return renderServiceNetworkName(classConfig.Spec.ServiceNetworkNameTemplate, serviceNetworkNameData{
+		Name:      gw.Name,
+		Namespace: gw.Namespace,
+		ClassName: string(gw.Spec.GatewayClassName),
+	})
+}
+
+// ServiceNetworkName returns the name of the service network of the named gateway. A gateway not found maps
+// to the service network named after it, e.g. when its routes or policies are deleted after it.
+func ServiceNetworkName(ctx context.Context, c client.Client, gwName types.NamespacedName) (string, error) {
+	gw := &gwv1beta1.Gateway{}
+	if err := c.Get(ctx, gwName, gw); err != nil {
+		if apierrors.IsNotFound(err) {
+			return gwName.Name, nil
+		}
+		return "", err
+	}
+	classConfig, err := GetGatewayClassConfigOfGateway(ctx, c, gw)
+	if err != nil {
+		return "", err
+	}
+	return ServiceNetworkNameOfGateway(gw, classConfig)
+}
+
+func renderServiceNetworkName(text string, data serviceNetworkNameData) (string, error) {
+	tmpl, err := template.New("serviceNetworkName").Parse(text)
+	if err != nil {
+		return "", err
+	}
+	var name bytes.Buffer
+	if err := tmpl.Execute(&name, data); err != nil {
+		return "", err
+	}
+	if name.Len() == 0 {
+		return "", fmt.Errorf("serviceNetworkNameTemplate %q renders an empty name", text)
+	}
+	return name.String(), nil
+}
+
+func validateGatewayClassConfig(classConfig *anv1alpha1.LatticeGatewayClassConfig) error {
+	for key := range classConfig.Spec.Tags {
+		if strings.HasPrefix(key, pkg_aws.TagBase) {
+			return fmt.Errorf("tag %s uses the reserved prefix %s", key, pkg_aws.TagBase)
+		}
+	}
+	if text := classConfig.Spec.ServiceNetworkNameTemplate; text != "" {
+		_, err := renderServiceNetworkName(text, serviceNetworkNameData{Name: "name", Namespace: "namespace", ClassName: "class"})
+		if err != nil {
+			return fmt.Errorf("invalid serviceNetworkNameTemplate: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,209 @@
+package gateway
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gwv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	anv1alpha1 "github.com/aws/aws-application-networking-k8s/pkg/apis/applicationnetworking/v1alpha1"
+	"github.com/aws/aws-application-networking-k8s/pkg/config"
+)
+
+func Test_GetGatewayClassConfig(t *testing.T) {
+	otherNamespace := gwv1beta1.Namespace("other")
+	configs := []*anv1alpha1.LatticeGatewayClassConfig{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "valid"},
+			Spec: anv1alpha1.LatticeGatewayClassConfigSpec{
+				DefaultServiceNetwork:      "team-sn",
+				Tags:                       map[string]string{"team": "a"},
+				ServiceNetworkNameTemplate: "{{.Namespace}}-{{.Name}}",
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "reserved-tag"},
+			Spec: anv1alpha1.LatticeGatewayClassConfigSpec{
+				Tags: map[string]string{"application-networking.k8s.aws/ManagedBy": "me"},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "unknown-field"},
+			Spec:       anv1alpha1.LatticeGatewayClassConfigSpec{ServiceNetworkNameTemplate: "{{.Cluster}}"},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "empty-name"},
+			Spec:       anv1alpha1.LatticeGatewayClassConfigSpec{ServiceNetworkNameTemplate: "{{/* nothing */}}"},
+		},
+	}
+	configRef := func(name string) *gwv1beta1.ParametersReference {
+		return &gwv1beta1.ParametersReference{
+			Group: anv1alpha1.GroupName,
+			Kind:  anv1alpha1.LatticeGatewayClassConfigKind,
+			Name:  name,
+		}
+	}
+
+	tests := []struct {
+		name           string
+		parametersRef  *gwv1beta1.ParametersReference
+		expectedConfig string
+		expectedErr    string
+	}{
+		{
+			name: "class without parametersRef",
+		},
+		{
+			name:           "valid config",
+			parametersRef:  configRef("valid"),
+			expectedConfig: "valid",
+		},
+		{
+			name:          "reference to another kind",
+			parametersRef: &gwv1beta1.ParametersReference{Group: "", Kind: "ConfigMap", Name: "valid"},
+			expectedErr:   "parametersRef must reference a LatticeGatewayClassConfig of group application-networking.k8s.aws, got ConfigMap of group ",
+		},
+		{
+			name: "namespaced reference",
+			parametersRef: &gwv1beta1.ParametersReference{
+				Group:     anv1alpha1.GroupName,
+				Kind:      anv1alpha1.LatticeGatewayClassConfigKind,
+				Name:      "valid",
+				Namespace: &otherNamespace,
+			},
+			expectedErr: "parametersRef must not have a namespace, LatticeGatewayClassConfig is cluster-scoped",
+		},
+		{
+			name:          "config not found",
+			parametersRef: configRef("missing"),
+			expectedErr:   "LatticeGatewayClassConfig missing not found",
+		},
+		{
+			name:          "reserved tag",
+			parametersRef: configRef("reserved-tag"),
+			expectedErr:   "LatticeGatewayClassConfig reserved-tag is invalid: tag application-networking.k8s.aws/ManagedBy uses the reserved prefix application-networking.k8s.aws/",
+		},
+		{
+			name:          "template with an unknown field",
+			parametersRef: configRef("unknown-field"),
+			expectedErr:   "LatticeGatewayClassConfig unknown-field is invalid: invalid serviceNetworkNameTemplate",
+		},
+		{
+			name:          "template rendering an empty name",
+			parametersRef: configRef("empty-name"),
+			expectedErr:   "renders an empty name",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.TODO()
+			k8sScheme := runtime.NewScheme()
+			clientgoscheme.AddToScheme(k8sScheme)
+			gwv1beta1.AddToScheme(k8sScheme)
+			anv1alpha1.AddToScheme(k8sScheme)
+			k8sClient := testclient.NewClientBuilder().WithScheme(k8sScheme).Build()
+			for _, classConfig := range configs {
+				assert.NoError(t, k8sClient.Create(ctx, classConfig.DeepCopy()))
+			}
+
+			gwClass := &gwv1beta1.GatewayClass{
+				ObjectMeta: metav1.ObjectMeta{Name: "class"},
+				Spec: gwv1beta1.GatewayClassSpec{
+					ControllerName: config.LatticeGatewayControllerName,
+					ParametersRef:  tt.parametersRef,
+				},
+			}
+			classConfig, err := GetGatewayClassConfig(ctx, k8sClient, gwClass)
+			if tt.expectedErr != "" {
+				var invalidErr *InvalidGatewayClassConfigError
+				assert.ErrorAs(t, err, &invalidErr)
+				assert.ErrorContains(t, err, tt.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+			if tt.expectedConfig == "" {
+				assert.Nil(t, classConfig)
+			} else {
+				assert.Equal(t, tt.expectedConfig, classConfig.Name)
+			}
+		})
+	}
+}
+
+func Test_ServiceNetworkName(t *testing.T) {
+	ctx := context.TODO()
+	k8sScheme := runtime.NewScheme()
+	clientgoscheme.AddToScheme(k8sScheme)
+	gwv1beta1.AddToScheme(k8sScheme)
+	anv1alpha1.AddToScheme(k8sScheme)
+	k8sClient := testclient.NewClientBuilder().WithScheme(k8sScheme).WithObjects(
+		&gwv1beta1.GatewayClass{
+			ObjectMeta: metav1.ObjectMeta{Name: "amazon-vpc-lattice"},
+			Spec:       gwv1beta1.GatewayClassSpec{ControllerName: config.LatticeGatewayControllerName},
+		},
+		&gwv1beta1.GatewayClass{
+			ObjectMeta: metav1.ObjectMeta{Name: "team-class"},
+			Spec: gwv1beta1.GatewayClassSpec{
+				ControllerName: config.LatticeGatewayControllerName,
+				ParametersRef: &gwv1beta1.ParametersReference{
+					Group: anv1alpha1.GroupName,
+					Kind:  anv1alpha1.LatticeGatewayClassConfigKind,
+					Name:  "team-config",
+				},
+			},
+		},
+		&gwv1beta1.GatewayClass{
+			ObjectMeta: metav1.ObjectMeta{Name: "other-class"},
+			Spec: gwv1beta1.GatewayClassSpec{
+				ControllerName: "example.com/other",
+				ParametersRef: &gwv1beta1.ParametersReference{
+					Group: "example.com",
+					Kind:  "Config",
+					Name:  "other-config",
+				},
+			},
+		},
+		&anv1alpha1.LatticeGatewayClassConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: "team-config"},
+			Spec: anv1alpha1.LatticeGatewayClassConfigSpec{
+				ServiceNetworkNameTemplate: "{{.ClassName}}-{{.Namespace}}-{{.Name}}",
+			},
+		},
+		&gwv1beta1.Gateway{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "gw"},
+			Spec:       gwv1beta1.GatewaySpec{GatewayClassName: "amazon-vpc-lattice"},
+		},
+		&gwv1beta1.Gateway{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "team-a", Name: "gw"},
+			Spec:       gwv1beta1.GatewaySpec{GatewayClassName: "team-class"},
+		},
+		&gwv1beta1.Gateway{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "other-gw"},
+			Spec:       gwv1beta1.GatewaySpec{GatewayClassName: "other-class"},
+		},
+	).Build()
+
+	tests := []struct {
+		gwName   types.NamespacedName
+		expected string
+	}{
+		{gwName: types.NamespacedName{Namespace: "default", Name: "gw"}, expected: "gw"},
+		{gwName: types.NamespacedName{Namespace: "team-a", Name: "gw"}, expected: "team-class-team-a-gw"},
+		{gwName: types.NamespacedName{Namespace: "default", Name: "other-gw"}, expected: "other-gw"},
+		{gwName: types.NamespacedName{Namespace: "default", Name: "deleted-gw"}, expected: "deleted-gw"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.gwName.String(), func(t *testing.T) {
+			snName, err := ServiceNetworkName(ctx, k8sClient, tt.gwName)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, snName)
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	anv1alpha1 "github.com/aws/aws-application-networking-k8s/pkg/apis/applicationnetworking/v1alpha1"
@@ -39,6 +40,7 @@ func (b *accessLogSubscriptionModelBuilder) Build(
 
 	task := accessLogSubscriptionModelBuildTask{
 		log:             b.log,
+		client:          b.client,
 		stack:           stack,
 		accessLogPolicy: accessLogPolicy,
 	}
@@ -52,6 +54,7 @@ func (b *accessLogSubscriptionModelBuilder) Build(
 
 type accessLogSubscriptionModelBuildTask struct {
 	log                   gwlog.Logger
+	client                client.Client
 	stack                 core.Stack
 	accessLogPolicy       *anv1alpha1.AccessLogPolicy
 	accessLogSubscription *model.AccessLogSubscription
@@ -74,6 +77,20 @@ func (t *accessLogSubscriptionModelBuildTask) run(ctx context.Context) error {
 	if err != nil && eventType != core.DeleteEvent {
 		return err
 	}
+	if sourceType == model.ServiceNetworkSourceType {
+		// the service network of the gateway is not necessarily named after it
+		gwName := types.NamespacedName{
+			Namespace: t.accessLogPolicy.Namespace,
+			Name:      string(t.accessLogPolicy.Spec.TargetRef.Name),
+		}
+		if t.accessLogPolicy.Spec.TargetRef.Namespace != nil {
+			gwName.Namespace = string(*t.accessLogPolicy.Spec.TargetRef.Namespace)
+		}
+		sourceName, err = ServiceNetworkName(ctx, t.client, gwName)
+		if err != nil {
+			return err
+		}
+	}
 
 	destinationArn := t.accessLogPolicy.Spec.DestinationArn
 	if destinationArn == nil {
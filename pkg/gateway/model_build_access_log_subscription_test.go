@@ -14,8 +14,10 @@ import (
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
 	gwv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	gwv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 
 	anv1alpha1 "github.com/aws/aws-application-networking-k8s/pkg/apis/applicationnetworking/v1alpha1"
+	"github.com/aws/aws-application-networking-k8s/pkg/config"
 	"github.com/aws/aws-application-networking-k8s/pkg/model/core"
 	"github.com/aws/aws-application-networking-k8s/pkg/model/lattice"
 	"github.com/aws/aws-application-networking-k8s/pkg/utils/gwlog"
@@ -36,7 +38,29 @@ func Test_BuildAccessLogSubscription(t *testing.T) {
 	ctx := context.TODO()
 	scheme := runtime.NewScheme()
 	clientgoscheme.AddToScheme(scheme)
-	client := testclient.NewClientBuilder().WithScheme(scheme).Build()
+	gwv1beta1.AddToScheme(scheme)
+	anv1alpha1.AddToScheme(scheme)
+	client := testclient.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&gwv1beta1.GatewayClass{
+			ObjectMeta: apimachineryv1.ObjectMeta{Name: "team-class"},
+			Spec: gwv1beta1.GatewayClassSpec{
+				ControllerName: config.LatticeGatewayControllerName,
+				ParametersRef: &gwv1beta1.ParametersReference{
+					Group: anv1alpha1.GroupName,
+					Kind:  anv1alpha1.LatticeGatewayClassConfigKind,
+					Name:  "team-config",
+				},
+			},
+		},
+		&anv1alpha1.LatticeGatewayClassConfig{
+			ObjectMeta: apimachineryv1.ObjectMeta{Name: "team-config"},
+			Spec:       anv1alpha1.LatticeGatewayClassConfigSpec{ServiceNetworkNameTemplate: "team-{{.Name}}"},
+		},
+		&gwv1beta1.Gateway{
+			ObjectMeta: apimachineryv1.ObjectMeta{Namespace: namespace, Name: "team-gw"},
+			Spec:       gwv1beta1.GatewaySpec{GatewayClassName: "team-class"},
+		},
+	).Build()
 	modelBuilder := NewAccessLogSubscriptionModelBuilder(gwlog.FallbackLogger, client)
 	expectedNamespacedName := types.NamespacedName{
 		Namespace: namespace,
@@ -105,6 +129,33 @@ func Test_BuildAccessLogSubscription(t *testing.T) {
 			onlyCompareSpecs: true,
 			expectedError:    nil,
 		},
+		{
+			description: "Policy on Gateway maps to ALS on the Service Network named by the template of its class",
+			input: &anv1alpha1.AccessLogPolicy{
+				ObjectMeta: apimachineryv1.ObjectMeta{
+					Namespace: namespace,
+					Name:      name,
+				},
+				Spec: anv1alpha1.AccessLogPolicySpec{
+					DestinationArn: aws.String(s3DestinationArn),
+					TargetRef: &gwv1alpha2.PolicyTargetReference{
+						Kind: gatewayKind,
+						Name: "team-gw",
+					},
+				},
+			},
+			expectedOutput: &lattice.AccessLogSubscription{
+				Spec: lattice.AccessLogSubscriptionSpec{
+					SourceType:        lattice.ServiceNetworkSourceType,
+					SourceName:        "team-team-gw",
+					DestinationArn:    s3DestinationArn,
+					ALPNamespacedName: expectedNamespacedName,
+					EventType:         core.CreateEvent,
+				},
+			},
+			onlyCompareSpecs: true,
+			expectedError:    nil,
+		},
 		{
 			description: "Policy on HTTPRoute without namespace maps to ALS on Service with HTTPRoute name + Policy's namespace",
 			input: &anv1alpha1.AccessLogPolicy{
@@ -0,0 +1,137 @@
+package gateway
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"golang.org/x/exp/slices"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/types"
+	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	anv1alpha1 "github.com/aws/aws-application-networking-k8s/pkg/apis/applicationnetworking/v1alpha1"
+	"github.com/aws/aws-application-networking-k8s/pkg/model/core"
+	model "github.com/aws/aws-application-networking-k8s/pkg/model/lattice"
+)
+
+const (
+	LATTICE_UNSUPPORTED_FILTER   = "LATTICE_UNSUPPORTED_FILTER"
+	LATTICE_INCOMPATIBLE_FILTERS = "LATTICE_INCOMPATIBLE_FILTERS"
+
+	// default status code of a RequestRedirect filter according to the gw spec
+	defaultRedirectStatusCode = 302
+	// status code of rules with an ExtensionRef filter which cannot be resolved
+	unresolvedFilterStatusCode = 500
+)
+
+// the only status codes of VPC Lattice fixed responses
+var supportedFixedResponseStatusCodes = []int64{404, 500}
+
+// Processes the filters of a route rule. Filters VPC Lattice can express, which are only ExtensionRef filters
+// referencing a FixedResponse, are mapped onto the returned rule action, a nil action means the rule forwards
+// to its backendRefs as usual. Any other filter fails the model build with an UnsupportedRouteError naming the filter, so
+// the route is never deployed with a filter silently dropped.
+func (t *latticeServiceModelBuildTask) buildRuleFilterAction(ctx context.Context, rule core.RouteRule) (*model.RuleAction, error) {
+	switch r := rule.(type) {
+	case *core.HTTPRouteRule:
+		return t.buildHttpRuleFilterAction(ctx, r)
+	case *core.GRPCRouteRule:
+		// none of the gRPC filters can be expressed in VPC Lattice
+		if len(r.Filters()) > 0 {
+			return nil, newUnsupportedValueError(LATTICE_UNSUPPORTED_FILTER,
+				"filter %s is not supported", r.Filters()[0].Type)
+		}
+		for _, backendRef := range r.BackendRefs() {
+			if br, ok := backendRef.(*core.GRPCBackendRef); ok && len(br.Filters()) > 0 {
+				return nil, newUnsupportedValueError(LATTICE_UNSUPPORTED_FILTER,
+					"backendRef filter %s is not supported", br.Filters()[0].Type)
+			}
+		}
+	}
+	return nil, nil
+}
+
+func (t *latticeServiceModelBuildTask) buildHttpRuleFilterAction(ctx context.Context, rule *core.HTTPRouteRule) (*model.RuleAction, error) {
+	var action *model.RuleAction
+	for _, filter := range rule.Filters() {
+		switch filter.Type {
+		case gwv1.HTTPRouteFilterRequestRedirect:
+			// a fixed response cannot set the redirect location, nor answer with a redirect status code
+			statusCode := int64(defaultRedirectStatusCode)
+			if filter.RequestRedirect != nil && filter.RequestRedirect.StatusCode != nil {
+				statusCode = int64(*filter.RequestRedirect.StatusCode)
+			}
+			return nil, newUnsupportedValueError(LATTICE_UNSUPPORTED_FILTER,
+				"filter %s is not supported, VPC Lattice fixed responses cannot return status code %d",
+				filter.Type, statusCode)
+		case gwv1.HTTPRouteFilterExtensionRef:
+			if action != nil {
+				return nil, newIncompatibleFiltersError()
+			}
+
+			var err error
+			action, err = t.buildFixedResponseAction(ctx, filter.ExtensionRef)
+			if err != nil {
+				return nil, err
+			}
+		default:
+			return nil, newUnsupportedValueError(LATTICE_UNSUPPORTED_FILTER,
+				"filter %s is not supported", filter.Type)
+		}
+	}
+
+	for _, backendRef := range rule.BackendRefs() {
+		if br, ok := backendRef.(*core.HTTPBackendRef); ok && len(br.Filters()) > 0 {
+			return nil, newUnsupportedValueError(LATTICE_UNSUPPORTED_FILTER,
+				"backendRef filter %s is not supported", br.Filters()[0].Type)
+		}
+	}
+
+	return action, nil
+}
+
+// each filter sets the response of the rule, so they cannot be combined
+func newIncompatibleFiltersError() *UnsupportedRouteError {
+	return newUnsupportedRouteError(gwv1.RouteReasonIncompatibleFilters, LATTICE_INCOMPATIBLE_FILTERS,
+		"only one FixedResponse filter is allowed per rule")
+}
+
+// maps an ExtensionRef filter referencing a FixedResponse in the route namespace to a fixed response
+func (t *latticeServiceModelBuildTask) buildFixedResponseAction(ctx context.Context, ref *gwv1.LocalObjectReference) (
+	*model.RuleAction, error,
+) {
+	if ref == nil {
+		return nil, newUnsupportedValueError(LATTICE_UNSUPPORTED_FILTER, "ExtensionRef filter requires an extensionRef")
+	}
+	if string(ref.Group) != anv1alpha1.GroupName || string(ref.Kind) != anv1alpha1.FixedResponseKind {
+		return nil, newUnsupportedValueError(LATTICE_UNSUPPORTED_FILTER,
+			"ExtensionRef filter of kind %s in group %s is not supported", ref.Kind, ref.Group)
+	}
+
+	fixedResponse := &anv1alpha1.FixedResponse{}
+	key := types.NamespacedName{Namespace: t.route.Namespace(), Name: string(ref.Name)}
+	if err := t.client.Get(ctx, key, fixedResponse); err != nil {
+		if !apierrors.IsNotFound(err) && !meta.IsNoMatchError(err) {
+			return nil, err
+		}
+		// the gw spec requires an error response rather than skipping a filter which cannot be resolved
+		t.log.Infof("FixedResponse %s of route %s-%s not found, responding with %d",
+			key, t.route.Name(), t.route.Namespace(), unresolvedFilterStatusCode)
+		return &model.RuleAction{FixedResponseStatusCode: aws.Int64(unresolvedFilterStatusCode)}, nil
+	}
+
+	// the CRD only accepts supported codes, but objects created with an earlier version of it may not
+	statusCode := int64(fixedResponse.Spec.StatusCode)
+	if !slices.Contains(supportedFixedResponseStatusCodes, statusCode) {
+		return nil, newUnsupportedValueError(LATTICE_UNSUPPORTED_FILTER,
+			"FixedResponse %s has status code %d, VPC Lattice fixed responses only support %v",
+			key, statusCode, supportedFixedResponseStatusCodes)
+	}
+
+	t.log.Debugf("Mapping FixedResponse %s of route %s-%s to fixed response %d",
+		key, t.route.Name(), t.route.Namespace(), statusCode)
+	return &model.RuleAction{
+		FixedResponseStatusCode: aws.Int64(statusCode),
+	}, nil
+}
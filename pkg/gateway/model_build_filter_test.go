@@ -0,0 +1,225 @@
+package gateway
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+	apimachineryv1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gwv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	gwv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	anv1alpha1 "github.com/aws/aws-application-networking-k8s/pkg/apis/applicationnetworking/v1alpha1"
+	"github.com/aws/aws-application-networking-k8s/pkg/model/core"
+	model "github.com/aws/aws-application-networking-k8s/pkg/model/lattice"
+	"github.com/aws/aws-application-networking-k8s/pkg/utils/gwlog"
+)
+
+func Test_BuildRuleFilterAction(t *testing.T) {
+	var serviceKind gwv1beta1.Kind = "Service"
+	var backendRef = gwv1beta1.BackendRef{
+		BackendObjectReference: gwv1beta1.BackendObjectReference{
+			Name: "targetgroup1",
+			Kind: &serviceKind,
+		},
+	}
+	redirect301 := 301
+	fixedResponseFilter := func(group, kind, name string) gwv1beta1.HTTPRouteFilter {
+		return gwv1beta1.HTTPRouteFilter{
+			Type: gwv1.HTTPRouteFilterExtensionRef,
+			ExtensionRef: &gwv1.LocalObjectReference{
+				Group: gwv1.Group(group),
+				Kind:  gwv1.Kind(kind),
+				Name:  gwv1.ObjectName(name),
+			},
+		}
+	}
+	fixedResponseRoute := func(filters ...gwv1beta1.HTTPRouteFilter) core.Route {
+		return core.NewHTTPRoute(gwv1beta1.HTTPRoute{
+			ObjectMeta: apimachineryv1.ObjectMeta{Name: "service1", Namespace: "default"},
+			Spec: gwv1beta1.HTTPRouteSpec{
+				Rules: []gwv1beta1.HTTPRouteRule{{Filters: filters}},
+			},
+		})
+	}
+
+	k8sScheme := runtime.NewScheme()
+	anv1alpha1.AddToScheme(k8sScheme)
+	k8sClient := testclient.NewClientBuilder().WithScheme(k8sScheme).WithObjects(
+		&anv1alpha1.FixedResponse{
+			ObjectMeta: apimachineryv1.ObjectMeta{Name: "maintenance", Namespace: "default"},
+			Spec:       anv1alpha1.FixedResponseSpec{StatusCode: 404},
+		},
+		// created before the CRD restricted the status code
+		&anv1alpha1.FixedResponse{
+			ObjectMeta: apimachineryv1.ObjectMeta{Name: "unavailable", Namespace: "default"},
+			Spec:       anv1alpha1.FixedResponseSpec{StatusCode: 503},
+		},
+	).Build()
+
+	tests := []struct {
+		name           string
+		route          core.Route
+		expectedAction *model.RuleAction
+		expectedReason gwv1.RouteConditionReason
+	}{
+		{
+			name: "no filters",
+			route: core.NewHTTPRoute(gwv1beta1.HTTPRoute{
+				Spec: gwv1beta1.HTTPRouteSpec{
+					Rules: []gwv1beta1.HTTPRouteRule{
+						{
+							BackendRefs: []gwv1beta1.HTTPBackendRef{{BackendRef: backendRef}},
+						},
+					},
+				},
+			}),
+			expectedAction: nil,
+		},
+		{
+			name: "redirect is not supported",
+			route: core.NewHTTPRoute(gwv1beta1.HTTPRoute{
+				Spec: gwv1beta1.HTTPRouteSpec{
+					Rules: []gwv1beta1.HTTPRouteRule{
+						{
+							Filters: []gwv1beta1.HTTPRouteFilter{
+								{
+									Type:            gwv1.HTTPRouteFilterRequestRedirect,
+									RequestRedirect: &gwv1.HTTPRequestRedirectFilter{},
+								},
+							},
+						},
+					},
+				},
+			}),
+			expectedReason: gwv1.RouteReasonUnsupportedValue,
+		},
+		{
+			name: "redirect with status code is not supported",
+			route: core.NewHTTPRoute(gwv1beta1.HTTPRoute{
+				Spec: gwv1beta1.HTTPRouteSpec{
+					Rules: []gwv1beta1.HTTPRouteRule{
+						{
+							Filters: []gwv1beta1.HTTPRouteFilter{
+								{
+									Type: gwv1.HTTPRouteFilterRequestRedirect,
+									RequestRedirect: &gwv1.HTTPRequestRedirectFilter{
+										StatusCode: &redirect301,
+									},
+								},
+							},
+						},
+					},
+				},
+			}),
+			expectedReason: gwv1.RouteReasonUnsupportedValue,
+		},
+		{
+			name: "two FixedResponses are incompatible",
+			route: fixedResponseRoute(
+				fixedResponseFilter(anv1alpha1.GroupName, "FixedResponse", "maintenance"),
+				fixedResponseFilter(anv1alpha1.GroupName, "FixedResponse", "maintenance")),
+			expectedReason: gwv1.RouteReasonIncompatibleFilters,
+		},
+		{
+			name:           "FixedResponse extensionRef",
+			route:          fixedResponseRoute(fixedResponseFilter(anv1alpha1.GroupName, "FixedResponse", "maintenance")),
+			expectedAction: &model.RuleAction{FixedResponseStatusCode: aws.Int64(404)},
+		},
+		{
+			name:           "FixedResponse status code not supported by lattice",
+			route:          fixedResponseRoute(fixedResponseFilter(anv1alpha1.GroupName, "FixedResponse", "unavailable")),
+			expectedReason: gwv1.RouteReasonUnsupportedValue,
+		},
+		{
+			name:           "missing FixedResponse responds with an error",
+			route:          fixedResponseRoute(fixedResponseFilter(anv1alpha1.GroupName, "FixedResponse", "missing")),
+			expectedAction: &model.RuleAction{FixedResponseStatusCode: aws.Int64(500)},
+		},
+		{
+			name:           "extensionRef of another kind is not supported",
+			route:          fixedResponseRoute(fixedResponseFilter("example.com", "Custom", "maintenance")),
+			expectedReason: gwv1.RouteReasonUnsupportedValue,
+		},
+		{
+			name: "url rewrite is not supported",
+			route: core.NewHTTPRoute(gwv1beta1.HTTPRoute{
+				Spec: gwv1beta1.HTTPRouteSpec{
+					Rules: []gwv1beta1.HTTPRouteRule{
+						{
+							Filters: []gwv1beta1.HTTPRouteFilter{
+								{Type: gwv1.HTTPRouteFilterURLRewrite},
+							},
+							BackendRefs: []gwv1beta1.HTTPBackendRef{{BackendRef: backendRef}},
+						},
+					},
+				},
+			}),
+			expectedReason: gwv1.RouteReasonUnsupportedValue,
+		},
+		{
+			name: "backendRef header modifier is not supported",
+			route: core.NewHTTPRoute(gwv1beta1.HTTPRoute{
+				Spec: gwv1beta1.HTTPRouteSpec{
+					Rules: []gwv1beta1.HTTPRouteRule{
+						{
+							BackendRefs: []gwv1beta1.HTTPBackendRef{
+								{
+									BackendRef: backendRef,
+									Filters: []gwv1beta1.HTTPRouteFilter{
+										{Type: gwv1.HTTPRouteFilterRequestHeaderModifier},
+									},
+								},
+							},
+						},
+					},
+				},
+			}),
+			expectedReason: gwv1.RouteReasonUnsupportedValue,
+		},
+		{
+			name: "grpc header modifier is not supported",
+			route: core.NewGRPCRoute(gwv1alpha2.GRPCRoute{
+				ObjectMeta: apimachineryv1.ObjectMeta{
+					Name:      "service1",
+					Namespace: "default",
+				},
+				Spec: gwv1alpha2.GRPCRouteSpec{
+					Rules: []gwv1alpha2.GRPCRouteRule{
+						{
+							Filters: []gwv1alpha2.GRPCRouteFilter{
+								{Type: gwv1alpha2.GRPCRouteFilterRequestHeaderModifier},
+							},
+						},
+					},
+				},
+			}),
+			expectedReason: gwv1.RouteReasonUnsupportedValue,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := &latticeServiceModelBuildTask{
+				log:    gwlog.FallbackLogger,
+				route:  tt.route,
+				client: k8sClient,
+			}
+
+			action, err := task.buildRuleFilterAction(context.TODO(), tt.route.Spec().Rules()[0])
+			if tt.expectedReason != "" {
+				var unsupportedErr *UnsupportedRouteError
+				assert.ErrorAs(t, err, &unsupportedErr)
+				assert.Equal(t, tt.expectedReason, unsupportedErr.Reason)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedAction, action)
+		})
+	}
+}
@@ -2,22 +2,33 @@ package gateway
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/aws/aws-sdk-go/service/vpclattice"
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/aws/aws-application-networking-k8s/pkg/utils/gwlog"
 
 	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gwv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 
 	"github.com/aws/aws-application-networking-k8s/pkg/config"
 	"github.com/aws/aws-application-networking-k8s/pkg/k8s"
 	"github.com/aws/aws-application-networking-k8s/pkg/model/core"
 	model "github.com/aws/aws-application-networking-k8s/pkg/model/lattice"
+	"github.com/aws/aws-application-networking-k8s/pkg/utils"
 )
 
+// ACM certificates the gateway controller imported from the Secrets referenced by the gateway
+// listeners, a JSON object of the secret namespace/name to the certificate arn
+const CertificateArnsAnnotation = k8s.AnnotationPrefix + "certificate-arns"
+
 //go:generate mockgen -destination model_build_lattice_service_mock.go -package gateway github.com/aws/aws-application-networking-k8s/pkg/gateway LatticeServiceBuilder
 
 type LatticeServiceBuilder interface {
@@ -46,6 +57,13 @@ func (b *LatticeServiceModelBuilder) Build(
 	ctx context.Context,
 	route core.Route,
 ) (core.Stack, error) {
+	if err := validateMergedRouteHostnames(route); err != nil {
+		return nil, err
+	}
+	if key, ok := routeMergeKeyToBuild(route); ok {
+		return b.buildMergedService(ctx, route, key)
+	}
+
 	stack := core.NewDefaultStack(core.StackID(k8s.NamespacedName(route.K8sObject())))
 
 	task := &latticeServiceModelBuildTask{
@@ -85,6 +103,7 @@ func (t *latticeServiceModelBuildTask) buildModel(ctx context.Context) error {
 		return err
 	}
 	t.log.Debugf("Building rules for %d listeners", len(modelListeners))
+	var partialErr *PartiallyInvalidRouteError
 	for _, modelListener := range modelListeners {
 		if modelListener.Spec.Protocol == vpclattice.ListenerProtocolTlsPassthrough {
 			t.log.Debugf("Skip building rules for TLS_PASSTHROUGH listener %s, since lattice TLS_PASSTHROUGH listener can only have listener defaultAction and without any other rule", modelListener.ID())
@@ -95,10 +114,17 @@ func (t *latticeServiceModelBuildTask) buildModel(ctx context.Context) error {
 		// even on delete we try to build everything we may then need to remove
 		err = t.buildRules(ctx, modelListener.ID())
 		if err != nil {
+			// dropped rules are the same for every listener, keep building the valid ones
+			if errors.As(err, &partialErr) {
+				continue
+			}
 			return fmt.Errorf("failed to build rules due to %w", err)
 		}
 	}
 
+	if partialErr != nil {
+		return partialErr
+	}
 	return nil
 }
 
@@ -124,15 +150,32 @@ func (t *latticeServiceModelBuildTask) buildLatticeService(ctx context.Context)
 	}
 
 	for _, parentRef := range t.route.Spec().ParentRefs() {
-		spec.ServiceNetworkNames = append(spec.ServiceNetworkNames, string(parentRef.Name))
+		if core.IsRouteDetachedFromParent(t.route, parentRef) {
+			t.log.Debugf("Route %s-%s is not allowed by parent %s, skipping association",
+				t.route.Name(), t.route.Namespace(), parentRef.Name)
+			continue
+		}
+		snName, err := ServiceNetworkName(ctx, t.client, ParentRefGatewayName(t.route, parentRef))
+		if err != nil {
+			return nil, err
+		}
+		spec.ServiceNetworkNames = append(spec.ServiceNetworkNames, snName)
 	}
 	if config.ServiceNetworkOverrideMode {
 		spec.ServiceNetworkNames = []string{config.DefaultServiceNetwork}
 	}
 
-	if len(t.route.Spec().Hostnames()) > 0 {
-		// The 1st hostname will be used as lattice customer-domain-name
-		spec.CustomerDomainName = string(t.route.Spec().Hostnames()[0])
+	domainNames, unservable := SplitRouteHostnames(t.route)
+	if len(domainNames) > 1 {
+		unservable = append(unservable, domainNames[1:]...)
+	}
+	if len(unservable) > 0 {
+		t.log.Infof("Ignoring hostnames %v of route %s-%s, they cannot be served by VPC Lattice",
+			unservable, t.route.Name(), t.route.Namespace())
+	}
+	if len(domainNames) > 0 {
+		// The 1st hostname will be used as lattice customer-domain-name, a service has a single one
+		spec.CustomerDomainName = domainNames[0]
 
 		t.log.Infof("Setting customer-domain-name: %s for route %s-%s",
 			spec.CustomerDomainName, t.route.Name(), t.route.Namespace())
@@ -158,24 +201,41 @@ func (t *latticeServiceModelBuildTask) buildLatticeService(ctx context.Context)
 	return svc, nil
 }
 
-// returns empty string if not found
-func (t *latticeServiceModelBuildTask) getACMCertArn(ctx context.Context) (string, error) {
-	gw, err := t.getGateway(ctx)
-	if err != nil {
-		if apierrors.IsNotFound(err) && !t.route.DeletionTimestamp().IsZero() {
-			return "", nil // ok if we're deleting the route
+// SplitRouteHostnames returns the route hostnames the lattice service can be reached under, in route order
+// and without duplicates, and the ones it cannot serve. VPC Lattice custom domain names cannot be wildcards.
+func SplitRouteHostnames(route core.Route) (domainNames []string, unservable []string) {
+	seen := utils.NewSet[string]()
+	for _, hostname := range route.Spec().Hostnames() {
+		name := strings.ToLower(string(hostname))
+		if seen.Contains(name) {
+			continue
+		}
+		seen.Put(name)
+		if strings.HasPrefix(name, "*") {
+			unservable = append(unservable, string(hostname))
+			continue
 		}
-		return "", err
+		domainNames = append(domainNames, string(hostname))
 	}
+	return domainNames, unservable
+}
 
+// returns empty string if not found. The route controller does not deploy the route to parentRefs with a
+// different certificate, the first one found is the certificate of all of them.
+func (t *latticeServiceModelBuildTask) getACMCertArn(ctx context.Context) (string, error) {
 	for _, parentRef := range t.route.Spec().ParentRefs() {
-		if parentRef.Name != t.route.Spec().ParentRefs()[0].Name {
-			// when a service is associate to multiple service network(s), all listener config MUST be same
-			// so here we are only using the 1st gateway
-			t.log.Debugf("Ignore ParentRef of different gateway %s-%s", parentRef.Name, parentRef.Namespace)
+		if core.IsRouteDetachedFromParent(t.route, parentRef) {
 			continue
 		}
 
+		gw, err := t.getGateway(ctx, parentRef)
+		if err != nil {
+			if apierrors.IsNotFound(err) && !t.route.DeletionTimestamp().IsZero() {
+				continue // ok if we're deleting the route
+			}
+			return "", err
+		}
+
 		if parentRef.SectionName == nil {
 			continue
 		}
@@ -189,6 +249,9 @@ func (t *latticeServiceModelBuildTask) getACMCertArn(ctx context.Context) (strin
 						return string(curCertARN), nil
 					}
 				}
+				if secretName, ok := ListenerCertificateSecret(gw, section); ok {
+					return t.getImportedCertArn(gw, section, secretName)
+				}
 				break
 			}
 		}
@@ -197,6 +260,55 @@ func (t *latticeServiceModelBuildTask) getACMCertArn(ctx context.Context) (strin
 	return "", nil
 }
 
+// the certificate of the listener secret is imported by the gateway controller
+func (t *latticeServiceModelBuildTask) getImportedCertArn(gw *gwv1beta1.Gateway, listener gwv1beta1.Listener, secretName types.NamespacedName) (string, error) {
+	certArn, ok := GatewayCertificateArns(gw)[secretName.String()]
+	if ok {
+		t.log.Debugf("Found certificate %s of secret %s under section %s", certArn, secretName, listener.Name)
+		return certArn, nil
+	}
+	if !t.route.DeletionTimestamp().IsZero() {
+		return "", nil
+	}
+	return "", fmt.Errorf("certificate of secret %s referenced by listener %s of gateway %s/%s is not imported yet",
+		secretName, listener.Name, gw.Namespace, gw.Name)
+}
+
+// ListenerCertificateSecret returns the Secret the certificate of an HTTPS listener is imported from. Only
+// the first certificateRef is used as a lattice service has a single certificate, and the certificate-arn
+// option takes precedence over it.
+func ListenerCertificateSecret(gw *gwv1beta1.Gateway, listener gwv1beta1.Listener) (types.NamespacedName, bool) {
+	if listener.TLS == nil || listener.TLS.Mode == nil || *listener.TLS.Mode != gwv1.TLSModeTerminate ||
+		len(listener.TLS.CertificateRefs) == 0 {
+		return types.NamespacedName{}, false
+	}
+	if _, ok := listener.TLS.Options[awsCustomCertARN]; ok {
+		return types.NamespacedName{}, false
+	}
+	ref := listener.TLS.CertificateRefs[0]
+	if (ref.Group != nil && *ref.Group != corev1.GroupName) || (ref.Kind != nil && *ref.Kind != "Secret") {
+		return types.NamespacedName{}, false
+	}
+	secretName := types.NamespacedName{Namespace: gw.Namespace, Name: string(ref.Name)}
+	if ref.Namespace != nil {
+		secretName.Namespace = string(*ref.Namespace)
+	}
+	return secretName, true
+}
+
+// GatewayCertificateArns returns the certificate arns recorded by the gateway controller, by secret namespace/name
+func GatewayCertificateArns(gw *gwv1beta1.Gateway) map[string]string {
+	certArns := make(map[string]string)
+	value, ok := gw.Annotations[CertificateArnsAnnotation]
+	if !ok {
+		return certArns
+	}
+	if err := json.Unmarshal([]byte(value), &certArns); err != nil {
+		return make(map[string]string)
+	}
+	return certArns
+}
+
 type latticeServiceModelBuildTask struct {
 	log         gwlog.Logger
 	route       core.Route
@@ -55,6 +55,7 @@ func Test_LatticeServiceModelBuild(t *testing.T) {
 	tests := []struct {
 		name          string
 		gw            gwv1beta1.Gateway
+		otherGws      []gwv1beta1.Gateway
 		route         core.Route
 		wantErrIsNil  bool
 		wantIsDeleted bool
@@ -280,6 +281,97 @@ func Test_LatticeServiceModelBuild(t *testing.T) {
 				ServiceNetworkNames: []string{"gateway1"},
 			},
 		},
+		{
+			name:          "Service with certificate imported from a Secret",
+			wantIsDeleted: false,
+			wantErrIsNil:  true,
+			gw: gwv1beta1.Gateway{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "gateway1",
+					Namespace: "default",
+					Annotations: map[string]string{
+						CertificateArnsAnnotation: `{"default/cert":"imported-cert-arn"}`,
+					},
+				},
+				Spec: gwv1beta1.GatewaySpec{
+					Listeners: []gwv1beta1.Listener{
+						{
+							Name:     "tls",
+							Port:     443,
+							Protocol: "HTTPS",
+							TLS: &gwv1beta1.GatewayTLSConfig{
+								Mode:            &tlsModeTerminate,
+								CertificateRefs: []gwv1beta1.SecretObjectReference{{Name: "cert"}},
+							},
+						},
+					},
+				},
+			},
+			route: core.NewHTTPRoute(gwv1beta1.HTTPRoute{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "service1",
+					Namespace: "default",
+				},
+				Spec: gwv1beta1.HTTPRouteSpec{
+					CommonRouteSpec: gwv1beta1.CommonRouteSpec{
+						ParentRefs: []gwv1beta1.ParentReference{
+							{
+								Name:        "gateway1",
+								SectionName: &tlsSectionName,
+							},
+						},
+					},
+				},
+			}),
+			expected: model.ServiceSpec{
+				ServiceTagFields: model.ServiceTagFields{
+					RouteName:      "service1",
+					RouteNamespace: "default",
+					RouteType:      core.HttpRouteType,
+				},
+				CustomerCertARN:     "imported-cert-arn",
+				ServiceNetworkNames: []string{"gateway1"},
+			},
+		},
+		{
+			name:         "Certificate of the Secret is not imported yet",
+			wantErrIsNil: false,
+			gw: gwv1beta1.Gateway{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "gateway1",
+					Namespace: "default",
+				},
+				Spec: gwv1beta1.GatewaySpec{
+					Listeners: []gwv1beta1.Listener{
+						{
+							Name:     "tls",
+							Port:     443,
+							Protocol: "HTTPS",
+							TLS: &gwv1beta1.GatewayTLSConfig{
+								Mode:            &tlsModeTerminate,
+								CertificateRefs: []gwv1beta1.SecretObjectReference{{Name: "cert"}},
+							},
+						},
+					},
+				},
+			},
+			route: core.NewHTTPRoute(gwv1beta1.HTTPRoute{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "service1",
+					Namespace: "default",
+				},
+				Spec: gwv1beta1.HTTPRouteSpec{
+					CommonRouteSpec: gwv1beta1.CommonRouteSpec{
+						ParentRefs: []gwv1beta1.ParentReference{
+							{
+								Name:        "gateway1",
+								SectionName: &tlsSectionName,
+							},
+						},
+					},
+				},
+			}),
+		},
 		{
 			name: "GW does not exist",
 			gw: gwv1beta1.Gateway{
@@ -365,6 +457,14 @@ func Test_LatticeServiceModelBuild(t *testing.T) {
 					Namespace: "default",
 				},
 			},
+			otherGws: []gwv1beta1.Gateway{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "gateway2",
+						Namespace: "ns2",
+					},
+				},
+			},
 			route: core.NewHTTPRoute(gwv1beta1.HTTPRoute{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      "service1",
@@ -394,6 +494,275 @@ func Test_LatticeServiceModelBuild(t *testing.T) {
 				ServiceNetworkNames: []string{"gateway1", "gateway2"},
 			},
 		},
+		{
+			name:          "Multiple hostnames",
+			wantIsDeleted: false,
+			wantErrIsNil:  true,
+			gw: gwv1beta1.Gateway{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "gateway1",
+					Namespace: "default",
+				},
+			},
+			route: core.NewHTTPRoute(gwv1beta1.HTTPRoute{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "service1",
+					Namespace: "default",
+				},
+				Spec: gwv1beta1.HTTPRouteSpec{
+					CommonRouteSpec: gwv1beta1.CommonRouteSpec{
+						ParentRefs: []gwv1beta1.ParentReference{
+							{
+								Name:      "gateway1",
+								Namespace: namespacePtr("default"),
+							},
+						},
+					},
+					Hostnames: []gwv1beta1.Hostname{
+						"*.example.com",
+						"regional.example.com",
+						"global.example.com",
+						"Regional.example.com",
+					},
+				},
+			}),
+			expected: model.ServiceSpec{
+				ServiceTagFields: model.ServiceTagFields{
+					RouteName:      "service1",
+					RouteNamespace: "default",
+					RouteType:      core.HttpRouteType,
+				},
+				ServiceNetworkNames: []string{"gateway1"},
+				CustomerDomainName:  "regional.example.com",
+			},
+		},
+		{
+			name:          "Certificate of the 2nd gateway",
+			wantIsDeleted: false,
+			wantErrIsNil:  true,
+			gw: gwv1beta1.Gateway{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "gateway1",
+					Namespace: "default",
+				},
+				Spec: gwv1beta1.GatewaySpec{
+					Listeners: []gwv1beta1.Listener{
+						{
+							Name:     "http",
+							Port:     80,
+							Protocol: "HTTP",
+						},
+					},
+				},
+			},
+			otherGws: []gwv1beta1.Gateway{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "gateway2",
+						Namespace: "default",
+					},
+					Spec: gwv1beta1.GatewaySpec{
+						Listeners: []gwv1beta1.Listener{
+							{
+								Name:     "tls",
+								Port:     443,
+								Protocol: "HTTPS",
+								TLS: &gwv1beta1.GatewayTLSConfig{
+									Mode: &tlsModeTerminate,
+									Options: map[gwv1beta1.AnnotationKey]gwv1beta1.AnnotationValue{
+										"application-networking.k8s.aws/certificate-arn": "cert-arn-2",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			route: core.NewHTTPRoute(gwv1beta1.HTTPRoute{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "service1",
+					Namespace: "default",
+				},
+				Spec: gwv1beta1.HTTPRouteSpec{
+					CommonRouteSpec: gwv1beta1.CommonRouteSpec{
+						ParentRefs: []gwv1beta1.ParentReference{
+							{
+								Name:        "gateway1",
+								SectionName: &httpSectionName,
+							},
+							{
+								Name:        "gateway2",
+								SectionName: &tlsSectionName,
+							},
+						},
+					},
+				},
+			}),
+			expected: model.ServiceSpec{
+				ServiceTagFields: model.ServiceTagFields{
+					RouteName:      "service1",
+					RouteNamespace: "default",
+					RouteType:      core.HttpRouteType,
+				},
+				CustomerCertARN:     "cert-arn-2",
+				ServiceNetworkNames: []string{"gateway1", "gateway2"},
+			},
+		},
+		{
+			name:          "Parent with an incompatible certificate is not associated",
+			wantIsDeleted: false,
+			wantErrIsNil:  true,
+			gw: gwv1beta1.Gateway{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "gateway1",
+					Namespace: "default",
+				},
+				Spec: gwv1beta1.GatewaySpec{
+					Listeners: []gwv1beta1.Listener{
+						{
+							Name:     "tls",
+							Port:     443,
+							Protocol: "HTTPS",
+							TLS: &gwv1beta1.GatewayTLSConfig{
+								Mode: &tlsModeTerminate,
+								Options: map[gwv1beta1.AnnotationKey]gwv1beta1.AnnotationValue{
+									"application-networking.k8s.aws/certificate-arn": "cert-arn-1",
+								},
+							},
+						},
+					},
+				},
+			},
+			otherGws: []gwv1beta1.Gateway{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "gateway2",
+						Namespace: "default",
+					},
+					Spec: gwv1beta1.GatewaySpec{
+						Listeners: []gwv1beta1.Listener{
+							{
+								Name:     "tls",
+								Port:     443,
+								Protocol: "HTTPS",
+								TLS: &gwv1beta1.GatewayTLSConfig{
+									Mode: &tlsModeTerminate,
+									Options: map[gwv1beta1.AnnotationKey]gwv1beta1.AnnotationValue{
+										"application-networking.k8s.aws/certificate-arn": "cert-arn-2",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			route: core.NewHTTPRoute(gwv1beta1.HTTPRoute{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "service1",
+					Namespace: "default",
+				},
+				Spec: gwv1beta1.HTTPRouteSpec{
+					CommonRouteSpec: gwv1beta1.CommonRouteSpec{
+						ParentRefs: []gwv1beta1.ParentReference{
+							{
+								Name:        "gateway1",
+								SectionName: &tlsSectionName,
+							},
+							{
+								Name:        "gateway2",
+								SectionName: &tlsSectionName,
+							},
+						},
+					},
+				},
+				Status: gwv1beta1.HTTPRouteStatus{
+					RouteStatus: gwv1beta1.RouteStatus{
+						Parents: []gwv1beta1.RouteParentStatus{
+							{
+								ParentRef: gwv1beta1.ParentReference{
+									Name:        "gateway2",
+									SectionName: &tlsSectionName,
+								},
+								Conditions: []metav1.Condition{
+									{
+										Type:   string(gwv1beta1.RouteConditionAccepted),
+										Status: metav1.ConditionFalse,
+										Reason: string(core.RouteReasonIncompatibleListeners),
+									},
+								},
+							},
+						},
+					},
+				},
+			}),
+			expected: model.ServiceSpec{
+				ServiceTagFields: model.ServiceTagFields{
+					RouteName:      "service1",
+					RouteNamespace: "default",
+					RouteType:      core.HttpRouteType,
+				},
+				CustomerCertARN:     "cert-arn-1",
+				ServiceNetworkNames: []string{"gateway1"},
+			},
+		},
+		{
+			name:          "Parent not allowing the route is not associated",
+			wantIsDeleted: false,
+			wantErrIsNil:  true,
+			gw: gwv1beta1.Gateway{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "gateway1",
+					Namespace: "default",
+				},
+			},
+			route: core.NewHTTPRoute(gwv1beta1.HTTPRoute{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "service1",
+					Namespace: "default",
+				},
+				Spec: gwv1beta1.HTTPRouteSpec{
+					CommonRouteSpec: gwv1beta1.CommonRouteSpec{
+						ParentRefs: []gwv1beta1.ParentReference{
+							{
+								Name:      "gateway1",
+								Namespace: namespacePtr("default"),
+							},
+							{
+								Name:      "gateway2",
+								Namespace: namespacePtr("ns2"),
+							},
+						},
+					},
+				},
+				Status: gwv1beta1.HTTPRouteStatus{
+					RouteStatus: gwv1beta1.RouteStatus{
+						Parents: []gwv1beta1.RouteParentStatus{
+							{
+								ParentRef: gwv1beta1.ParentReference{
+									Name:      "gateway2",
+									Namespace: namespacePtr("ns2"),
+								},
+								Conditions: []metav1.Condition{
+									{
+										Type:   string(gwv1beta1.RouteConditionAccepted),
+										Status: metav1.ConditionFalse,
+										Reason: string(gwv1beta1.RouteReasonNotAllowedByListeners),
+									},
+								},
+							},
+						},
+					},
+				},
+			}),
+			expected: model.ServiceSpec{
+				ServiceTagFields: model.ServiceTagFields{
+					RouteName:      "service1",
+					RouteNamespace: "default",
+					RouteType:      core.HttpRouteType,
+				},
+				ServiceNetworkNames: []string{"gateway1"},
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -408,6 +777,9 @@ func Test_LatticeServiceModelBuild(t *testing.T) {
 			k8sClient := testclient.NewClientBuilder().WithScheme(k8sSchema).Build()
 
 			assert.NoError(t, k8sClient.Create(ctx, tt.gw.DeepCopy()))
+			for _, gw := range tt.otherGws {
+				assert.NoError(t, k8sClient.Create(ctx, gw.DeepCopy()))
+			}
 			stack := core.NewDefaultStack(core.StackID(k8s.NamespacedName(tt.route.K8sObject())))
 
 			task := &latticeServiceModelBuildTask{
@@ -2,22 +2,104 @@ package gateway
 
 import (
 	"context"
-	"errors"
 	"fmt"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/vpclattice"
+	"golang.org/x/exp/slices"
 	"k8s.io/apimachinery/pkg/types"
 	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
 	gwv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 
+	"github.com/aws/aws-application-networking-k8s/pkg/k8s"
+	"github.com/aws/aws-application-networking-k8s/pkg/model/core"
 	model "github.com/aws/aws-application-networking-k8s/pkg/model/lattice"
+	"github.com/aws/aws-application-networking-k8s/pkg/utils"
 )
 
 const (
 	awsCustomCertARN = "application-networking.k8s.aws/certificate-arn"
+
+	LATTICE_UNSUPPORTED_SNI_HOSTNAME = "LATTICE_UNSUPPORTED_SNI_HOSTNAME"
+	LATTICE_UNSUPPORTED_WEIGHT       = "LATTICE_UNSUPPORTED_WEIGHT"
+	LATTICE_EXCEED_MAX_TARGET_GROUPS = "LATTICE_EXCEED_MAX_TARGET_GROUPS"
+	LATTICE_INCOMPATIBLE_LISTENERS   = "LATTICE_INCOMPATIBLE_LISTENERS"
+	LATTICE_MAX_TARGET_GROUPS        = 10
+	LATTICE_MAX_TARGET_GROUP_WEIGHT  = 999
 )
 
+// ParentListenerConfig is the configuration a route gets from the gateway listener one of its parentRefs
+// attaches it to. The lattice service of the route has one listener per port and a single certificate for
+// all the service networks it is associated to, the configurations of all parentRefs must be compatible.
+type ParentListenerConfig struct {
+	GatewayName  types.NamespacedName
+	ListenerName string
+	Port         int64
+	Protocol     string
+	// certificate-arn option of the listener or namespace/name of its certificate Secret, empty without one
+	Certificate string
+}
+
+// NewParentListenerConfig resolves the gateway listener of the parentRef. Without sectionName nor port,
+// the route is attached to the first listener.
+func NewParentListenerConfig(gw *gwv1beta1.Gateway, parentRef gwv1beta1.ParentReference) (ParentListenerConfig, error) {
+	for _, listener := range gw.Spec.Listeners {
+		if parentRef.SectionName != nil && *parentRef.SectionName != listener.Name {
+			continue
+		}
+		if parentRef.Port != nil && *parentRef.Port != listener.Port {
+			continue
+		}
+		config := ParentListenerConfig{
+			GatewayName:  k8s.NamespacedName(gw),
+			ListenerName: string(listener.Name),
+			Port:         int64(listener.Port),
+			Protocol:     string(listener.Protocol),
+		}
+		if isTLSPassthroughGatewayListener(&listener) {
+			config.Protocol = vpclattice.ListenerProtocolTlsPassthrough
+		}
+		if listener.TLS != nil && listener.TLS.Mode != nil && *listener.TLS.Mode == gwv1.TLSModeTerminate {
+			if certArn, ok := listener.TLS.Options[awsCustomCertARN]; ok {
+				config.Certificate = string(certArn)
+			} else if secretName, ok := ListenerCertificateSecret(gw, listener); ok {
+				config.Certificate = secretName.String()
+			}
+		}
+		return config, nil
+	}
+	if len(gw.Spec.Listeners) == 0 {
+		return ParentListenerConfig{}, fmt.Errorf("error building listener, there is NO listeners on gateway %s", k8s.NamespacedName(gw))
+	}
+	if parentRef.SectionName != nil {
+		return ParentListenerConfig{}, fmt.Errorf("error building listener, no matching sectionName in parentRef for Name %s, Section %s",
+			parentRef.Name, *parentRef.SectionName)
+	}
+	return ParentListenerConfig{}, fmt.Errorf("error building listener, no matching port in parentRef for Name %s, Port %d",
+		parentRef.Name, *parentRef.Port)
+}
+
+func (c ParentListenerConfig) String() string {
+	return fmt.Sprintf("listener %s of gateway %s", c.ListenerName, c.GatewayName)
+}
+
+// IncompatibleWith returns an error when the route cannot be deployed to the lattice service with both
+// configurations: a port can only have one protocol, and HTTPS listeners must share the certificate.
+func (c ParentListenerConfig) IncompatibleWith(others ...ParentListenerConfig) error {
+	for _, other := range others {
+		if c.Port == other.Port && c.Protocol != other.Protocol {
+			return newUnsupportedValueError(LATTICE_INCOMPATIBLE_LISTENERS, "%s uses protocol %s on port %d, but %s uses %s",
+				c, c.Protocol, c.Port, other, other.Protocol)
+		}
+		if c.Protocol == vpclattice.ListenerProtocolHttps && other.Protocol == vpclattice.ListenerProtocolHttps &&
+			c.Certificate != other.Certificate {
+			return newUnsupportedValueError(LATTICE_INCOMPATIBLE_LISTENERS,
+				"certificate of %s differs from the one of %s, a VPC Lattice service has a single certificate", c, other)
+		}
+	}
+	return nil
+}
+
 func (t *latticeServiceModelBuildTask) extractListenerInfo(
 	ctx context.Context,
 	parentRef gwv1beta1.ParentReference,
@@ -27,56 +109,44 @@ func (t *latticeServiceModelBuildTask) extractListenerInfo(
 	}
 
 	t.log.Debugf("Building Listener for Route %s-%s", t.route.Name(), t.route.Namespace())
-	gw, err := t.getGateway(ctx)
+	gw, err := t.getGateway(ctx, parentRef)
 	if err != nil {
 		return 0, "", err
 	}
-	// If no SectionName is specified, use the first listener port
-	if parentRef.SectionName == nil {
-		if len(gw.Spec.Listeners) == 0 {
-			return 0, "", errors.New("error building listener, there is NO listeners on GW")
-		}
-		listenerPort := int(gw.Spec.Listeners[0].Port)
-		protocol := gw.Spec.Listeners[0].Protocol
-		return int64(listenerPort), string(protocol), nil
-	}
-	// Find the matching section name
-	for _, section := range gw.Spec.Listeners {
-		if section.Name == *parentRef.SectionName {
-			listenerPort := int(section.Port)
-			protocol := section.Protocol
-			if isTLSPassthroughGatewayListener(&section) {
-				t.log.Debugf("Found TLS passthrough section %v", section.TLS)
-				protocol = vpclattice.ListenerProtocolTlsPassthrough
-			}
-			return int64(listenerPort), string(protocol), nil
-		}
+	config, err := NewParentListenerConfig(gw, parentRef)
+	if err != nil {
+		return 0, "", err
 	}
-	return 0, "", fmt.Errorf("error building listener, no matching sectionName in parentRef for Name %s, Section %s", parentRef.Name, *parentRef.SectionName)
+	return config.Port, config.Protocol, nil
 }
 
 func isTLSPassthroughGatewayListener(listener *gwv1.Listener) bool {
 	return listener.Protocol == gwv1.TLSProtocolType && listener.TLS != nil && listener.TLS.Mode != nil && *listener.TLS.Mode == gwv1.TLSModePassthrough
 }
 
-func (t *latticeServiceModelBuildTask) getGateway(ctx context.Context) (*gwv1beta1.Gateway, error) {
-	var gwNamespace = t.route.Namespace()
-	if t.route.Spec().ParentRefs()[0].Namespace != nil {
-		gwNamespace = string(*t.route.Spec().ParentRefs()[0].Namespace)
+// ParentRefGatewayName returns the name of the gateway a route parentRef refers to, in the route namespace by default
+func ParentRefGatewayName(route core.Route, parentRef gwv1beta1.ParentReference) types.NamespacedName {
+	gwNamespace := route.Namespace()
+	if parentRef.Namespace != nil && *parentRef.Namespace != "" {
+		gwNamespace = string(*parentRef.Namespace)
 	}
-
-	gw := &gwv1beta1.Gateway{}
-	gwName := types.NamespacedName{
+	return types.NamespacedName{
 		Namespace: gwNamespace,
-		Name:      string(t.route.Spec().ParentRefs()[0].Name),
+		Name:      string(parentRef.Name),
 	}
+}
 
+func (t *latticeServiceModelBuildTask) getGateway(ctx context.Context, parentRef gwv1beta1.ParentReference) (*gwv1beta1.Gateway, error) {
+	gw := &gwv1beta1.Gateway{}
+	gwName := ParentRefGatewayName(t.route, parentRef)
 	if err := t.client.Get(ctx, gwName, gw); err != nil {
 		return nil, fmt.Errorf("failed to get gateway, name %s, err %w", gwName, err)
 	}
 	return gw, nil
 }
 
+// The route gets one listener per port and protocol of the listeners of all the parentRefs it is deployed to,
+// the route controller does not deploy it to parentRefs with an incompatible listener, see IncompatibleWith.
 func (t *latticeServiceModelBuildTask) buildListeners(ctx context.Context, stackSvcId string) error {
 	if len(t.route.Spec().ParentRefs()) == 0 {
 		t.log.Debugf("No ParentRefs on route %s-%s, nothing to do", t.route.Name(), t.route.Namespace())
@@ -86,11 +156,10 @@ func (t *latticeServiceModelBuildTask) buildListeners(ctx context.Context, stack
 		return nil
 	}
 
+	built := utils.NewSet[string]()
 	for _, parentRef := range t.route.Spec().ParentRefs() {
-		if parentRef.Name != t.route.Spec().ParentRefs()[0].Name {
-			// when a service is associate to multiple service network(s), all listener config MUST be same
-			// so here we are only using the 1st gateway
-			t.log.Debugf("Ignore parentref of different gateway %s-%s", parentRef.Name, parentRef.Namespace)
+		if core.IsRouteDetachedFromParent(t.route, parentRef) {
+			t.log.Debugf("Ignore parentref %s-%s the route is not deployed to", parentRef.Name, parentRef.Namespace)
 			continue
 		}
 
@@ -98,6 +167,11 @@ func (t *latticeServiceModelBuildTask) buildListeners(ctx context.Context, stack
 		if err != nil {
 			return err
 		}
+		listenerKey := fmt.Sprintf("%d-%s", port, protocol)
+		if built.Contains(listenerKey) {
+			continue
+		}
+		built.Put(listenerKey)
 
 		defaultAction, err := t.getListenerDefaultAction(ctx, protocol)
 		if err != nil {
@@ -133,12 +207,21 @@ func (t *latticeServiceModelBuildTask) getListenerDefaultAction(ctx context.Cont
 		}, nil
 	}
 
-	if len(t.route.Spec().Rules()) != 1 {
-		return nil, fmt.Errorf("only support exactly 1 rule for TLSRoute %s/%s, but got %d", t.route.Namespace(), t.route.Name(), len(t.route.Spec().Rules()))
+	if err := validateTLSRouteHostnames(t.route); err != nil {
+		return nil, err
 	}
-	modelRouteRule := t.route.Spec().Rules()[0]
-	ruleTgList, err := t.getTargetGroupsForRuleAction(ctx, modelRouteRule)
-	if err != nil {
+
+	// TLSRoute rules have no matches, every rule applies to all connections. Their backendRefs are
+	// combined into one weighted forward action, the only action of a lattice TLS_PASSTHROUGH listener
+	var ruleTgList []*model.RuleTargetGroup
+	for _, modelRouteRule := range t.route.Spec().Rules() {
+		tgs, err := t.getTargetGroupsForRuleAction(ctx, modelRouteRule)
+		if err != nil {
+			return nil, err
+		}
+		ruleTgList = mergeRuleTargetGroups(ruleTgList, tgs)
+	}
+	if err := validateForwardTargetGroups(ruleTgList); err != nil {
 		return nil, err
 	}
 
@@ -148,3 +231,110 @@ func (t *latticeServiceModelBuildTask) getListenerDefaultAction(ctx context.Cont
 		},
 	}, nil
 }
+
+// lattice routes TLS_PASSTHROUGH connections to a service by the SNI matching its custom domain name,
+// so a TLSRoute needs exactly one hostname, which cannot be a wildcard
+func validateTLSRouteHostnames(route core.Route) error {
+	domainNames, unservable := SplitRouteHostnames(route)
+	if len(unservable) > 0 {
+		return newUnsupportedValueError(LATTICE_UNSUPPORTED_SNI_HOSTNAME,
+			"wildcard hostnames %v cannot be used as SNI match", unservable)
+	}
+	if len(domainNames) != 1 {
+		return newUnsupportedValueError(LATTICE_UNSUPPORTED_SNI_HOSTNAME,
+			"exactly one hostname is required as SNI match, got %d", len(domainNames))
+	}
+	return nil
+}
+
+// appends the target groups to the list, the weights of a target group referenced more than once
+// are added up since lattice does not accept the same target group twice in a forward action
+func mergeRuleTargetGroups(tgList []*model.RuleTargetGroup, tgs []*model.RuleTargetGroup) []*model.RuleTargetGroup {
+	for _, tg := range tgs {
+		i := slices.IndexFunc(tgList, func(existing *model.RuleTargetGroup) bool {
+			return isSameRuleTargetGroup(existing, tg)
+		})
+		if i < 0 {
+			tgList = append(tgList, tg)
+			continue
+		}
+		tgList[i].Weight += tg.Weight
+	}
+	return tgList
+}
+
+func isSameRuleTargetGroup(a, b *model.RuleTargetGroup) bool {
+	if a.SvcImportTG != nil || b.SvcImportTG != nil {
+		return a.SvcImportTG != nil && b.SvcImportTG != nil && *a.SvcImportTG == *b.SvcImportTG
+	}
+	// invalid backendRefs are skipped on deployment, there is no need to merge them
+	return a.StackTargetGroupId != model.InvalidBackendRefTgId && a.StackTargetGroupId == b.StackTargetGroupId
+}
+
+func validateForwardTargetGroups(tgs []*model.RuleTargetGroup) error {
+	validTgs := 0
+	for _, tg := range tgs {
+		if tg.Weight > LATTICE_MAX_TARGET_GROUP_WEIGHT {
+			return newUnsupportedValueError(LATTICE_UNSUPPORTED_WEIGHT,
+				"backendRef weight %d exceeds the maximum of %d", tg.Weight, LATTICE_MAX_TARGET_GROUP_WEIGHT)
+		}
+		if tg.StackTargetGroupId != model.InvalidBackendRefTgId {
+			validTgs++
+		}
+	}
+	if validTgs > LATTICE_MAX_TARGET_GROUPS {
+		return newUnsupportedValueError(LATTICE_EXCEED_MAX_TARGET_GROUPS,
+			"%d backendRefs exceed the maximum of %d", validTgs, LATTICE_MAX_TARGET_GROUPS)
+	}
+	return nil
+}
+
+// a catch-all rule spec matches every request the listener receives, so its action is deployed as the
+// listener default action instead of the lowest priority lattice rule. A route can then choose what
+// requests no other rule matches get, rather than the 404 fixed response.
+func isCatchAllRuleSpec(ruleSpec model.RuleSpec) bool {
+	return ruleSpec.PathMatchPrefix && ruleSpec.PathMatchValue == "/" &&
+		ruleSpec.Method == "" && len(ruleSpec.MatchedHeaders) == 0
+}
+
+// sets the listener default action from the catch-all rule spec and returns the other rule specs,
+// the listener keeps its default action when there is no catch-all rule spec
+func (t *latticeServiceModelBuildTask) buildListenerDefaultActionFromRules(stackListenerId string, ruleSpecs []model.RuleSpec) (
+	[]model.RuleSpec, error,
+) {
+	i := slices.IndexFunc(ruleSpecs, isCatchAllRuleSpec)
+	if i < 0 {
+		return ruleSpecs, nil
+	}
+
+	var modelListeners []*model.Listener
+	if err := t.stack.ListResources(&modelListeners); err != nil {
+		return nil, err
+	}
+	i = slices.IndexFunc(modelListeners, func(l *model.Listener) bool { return l.ID() == stackListenerId })
+	if i < 0 {
+		return nil, fmt.Errorf("listener %s not found in the stack", stackListenerId)
+	}
+	modelListener := modelListeners[i]
+
+	var remaining []model.RuleSpec
+	for _, ruleSpec := range ruleSpecs {
+		if !isCatchAllRuleSpec(ruleSpec) {
+			remaining = append(remaining, ruleSpec)
+			continue
+		}
+		action := ruleSpec.Action
+		if action.FixedResponseStatusCode != nil {
+			modelListener.Spec.DefaultAction = &model.DefaultAction{
+				FixedResponseStatusCode: action.FixedResponseStatusCode,
+			}
+		} else {
+			modelListener.Spec.DefaultAction = &model.DefaultAction{
+				Forward: &action,
+			}
+		}
+		t.log.Debugf("Using catch-all rule of route %s-%s as default action of listener %d",
+			t.route.Name(), t.route.Namespace(), modelListener.Spec.Port)
+	}
+	return remaining, nil
+}
@@ -3,6 +3,7 @@ package gateway
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
 
 	"github.com/aws/aws-sdk-go/aws"
@@ -10,7 +11,10 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
 	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
 	gwv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
 	gwv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
@@ -166,6 +170,7 @@ func Test_ListenerModelBuild(t *testing.T) {
 					Namespace: "default",
 				},
 				Spec: gwv1alpha2.TLSRouteSpec{
+					Hostnames: []gwv1alpha2.Hostname{"service1.example.com"},
 					CommonRouteSpec: gwv1beta1.CommonRouteSpec{
 						ParentRefs: []gwv1beta1.ParentReference{
 							{
@@ -236,81 +241,6 @@ func Test_ListenerModelBuild(t *testing.T) {
 				},
 			},
 		},
-		{
-			name:                    "TLSRoute has more than one rule, build TLS_PASSTHROUGH listener failed",
-			gwListenerPort:          *PortNumberPtr(443),
-			wantErrIsNil:            false,
-			k8sGetGatewayCall:       true,
-			k8sGetServiceImportCall: false,
-			k8sGatewayReturnOK:      true,
-			k8sGatewayListenerType:  TLS_PASSTHROUGH,
-			route: core.NewTLSRoute(gwv1alpha2.TLSRoute{
-				ObjectMeta: metav1.ObjectMeta{
-					Name:      "service1",
-					Namespace: "default",
-				},
-				Spec: gwv1alpha2.TLSRouteSpec{
-					CommonRouteSpec: gwv1beta1.CommonRouteSpec{
-						ParentRefs: []gwv1beta1.ParentReference{
-							{
-								Name:        "gw1",
-								SectionName: &sectionName,
-							},
-						},
-					},
-					Rules: []gwv1alpha2.TLSRouteRule{
-						{
-							BackendRefs: []gwv1alpha2.BackendRef{
-								{
-									BackendObjectReference: gwv1beta1.BackendObjectReference{
-										Name: "k8s-service1",
-										Kind: &serviceKind,
-									},
-								},
-							},
-						},
-						{
-							BackendRefs: []gwv1alpha2.BackendRef{
-								{
-									BackendObjectReference: gwv1beta1.BackendObjectReference{
-										Name: "k8s-service2",
-										Kind: &serviceKind,
-									},
-								},
-							},
-						},
-					},
-				},
-			}),
-			expectedSpec: []model.ListenerSpec{
-				{
-					StackServiceId:    "svc-id",
-					K8SRouteName:      "service1",
-					K8SRouteNamespace: "default",
-					Port:              443,
-					Protocol:          vpclattice.ListenerProtocolTlsPassthrough,
-					DefaultAction: &model.DefaultAction{
-						Forward: &model.RuleAction{
-							TargetGroups: []*model.RuleTargetGroup{
-								{
-									StackTargetGroupId: "k8s-service1",
-									Weight:             10,
-								},
-								{
-									SvcImportTG: &model.SvcImportTargetGroup{
-										K8SServiceNamespace: "default",
-										K8SServiceName:      "k8s-service2",
-										VpcId:               "vpc-123",
-										K8SClusterName:      "eks-cluster",
-									},
-									Weight: 90,
-								},
-							},
-						},
-					},
-				},
-			},
-		},
 		{
 			name:              "no parentref",
 			gwListenerPort:    *PortNumberPtr(80),
@@ -511,3 +441,355 @@ func Test_ListenerModelBuild(t *testing.T) {
 		})
 	}
 }
+
+func Test_ListenerDefaultActionFromCatchAllRule(t *testing.T) {
+	catchAll := model.RuleSpec{PathMatchPrefix: true, PathMatchValue: "/"}
+	api := model.RuleSpec{PathMatchPrefix: true, PathMatchValue: "/api"}
+	withHeader := catchAll
+	withHeader.MatchedHeaders = []vpclattice.HeaderMatch{{Name: aws.String("version")}}
+	withMethod := catchAll
+	withMethod.Method = "GET"
+	forward := model.RuleAction{TargetGroups: []*model.RuleTargetGroup{{StackTargetGroupId: "tg-0", Weight: 1}}}
+	fixedResponse := model.RuleAction{FixedResponseStatusCode: aws.Int64(500)}
+	withAction := func(spec model.RuleSpec, action model.RuleAction) model.RuleSpec {
+		spec.Action = action
+		return spec
+	}
+	notFound := &model.DefaultAction{FixedResponseStatusCode: aws.Int64(model.DefaultActionFixedResponseStatusCode)}
+
+	tests := []struct {
+		name                  string
+		ruleSpecs             []model.RuleSpec
+		expectedRuleSpecs     []model.RuleSpec
+		expectedDefaultAction *model.DefaultAction
+	}{
+		{
+			name:                  "no catch-all rule keeps the 404 fixed response",
+			ruleSpecs:             []model.RuleSpec{api, withHeader, withMethod},
+			expectedRuleSpecs:     []model.RuleSpec{api, withHeader, withMethod},
+			expectedDefaultAction: notFound,
+		},
+		{
+			name:                  "catch-all rule forwards to its backendRefs",
+			ruleSpecs:             []model.RuleSpec{withAction(catchAll, forward), api},
+			expectedRuleSpecs:     []model.RuleSpec{api},
+			expectedDefaultAction: &model.DefaultAction{Forward: &forward},
+		},
+		{
+			name:                  "catch-all rule answers with a fixed response",
+			ruleSpecs:             []model.RuleSpec{api, withAction(catchAll, fixedResponse)},
+			expectedRuleSpecs:     []model.RuleSpec{api},
+			expectedDefaultAction: &model.DefaultAction{FixedResponseStatusCode: aws.Int64(500)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stack := core.NewDefaultStack(core.StackID{Name: "route", Namespace: "default"})
+			listener, err := model.NewListener(stack, model.ListenerSpec{
+				Protocol:      vpclattice.ListenerProtocolHttp,
+				Port:          80,
+				DefaultAction: notFound,
+			})
+			assert.NoError(t, err)
+
+			task := &latticeServiceModelBuildTask{
+				log:   gwlog.FallbackLogger,
+				route: core.NewHTTPRoute(gwv1beta1.HTTPRoute{}),
+				stack: stack,
+			}
+			ruleSpecs, err := task.buildListenerDefaultActionFromRules(listener.ID(), tt.ruleSpecs)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedRuleSpecs, ruleSpecs)
+			assert.Equal(t, tt.expectedDefaultAction, listener.Spec.DefaultAction)
+			assert.NoError(t, listener.Spec.Validate())
+		})
+	}
+}
+
+func Test_TLSRouteListenerDefaultAction(t *testing.T) {
+	var serviceKind gwv1beta1.Kind = "Service"
+	var serviceImportKind gwv1beta1.Kind = "ServiceImport"
+	backendRef := func(kind gwv1beta1.Kind, name string, weight int32) gwv1alpha2.BackendRef {
+		return gwv1alpha2.BackendRef{
+			BackendObjectReference: gwv1beta1.BackendObjectReference{Name: gwv1beta1.ObjectName(name), Kind: &kind},
+			Weight:                 aws.Int32(weight),
+		}
+	}
+	svcImportTG := func(name string, weight int64) *model.RuleTargetGroup {
+		return &model.RuleTargetGroup{
+			SvcImportTG: &model.SvcImportTargetGroup{K8SServiceNamespace: "default", K8SServiceName: name},
+			Weight:      weight,
+		}
+	}
+	var manyBackendRefs []gwv1alpha2.BackendRef
+	for i := 0; i <= LATTICE_MAX_TARGET_GROUPS; i++ {
+		manyBackendRefs = append(manyBackendRefs, backendRef(serviceKind, fmt.Sprintf("svc-%d", i), 1))
+	}
+
+	tests := []struct {
+		name                 string
+		hostnames            []gwv1alpha2.Hostname
+		rules                []gwv1alpha2.TLSRouteRule
+		expectedTargetGroups []*model.RuleTargetGroup
+		expectedErrCode      string
+	}{
+		{
+			name:      "backendRefs of all rules are forwarded to",
+			hostnames: []gwv1alpha2.Hostname{"tls.example.com"},
+			rules: []gwv1alpha2.TLSRouteRule{
+				{BackendRefs: []gwv1alpha2.BackendRef{backendRef(serviceKind, "svc", 90), backendRef(serviceImportKind, "import", 10)}},
+				{BackendRefs: []gwv1alpha2.BackendRef{backendRef(serviceKind, "canary", 5)}},
+			},
+			expectedTargetGroups: []*model.RuleTargetGroup{
+				{StackTargetGroupId: "tg-0", Weight: 90},
+				svcImportTG("import", 10),
+				{StackTargetGroupId: "tg-1", Weight: 5},
+			},
+		},
+		{
+			name:      "weights of a backend referenced by several rules are added up",
+			hostnames: []gwv1alpha2.Hostname{"tls.example.com"},
+			rules: []gwv1alpha2.TLSRouteRule{
+				{BackendRefs: []gwv1alpha2.BackendRef{backendRef(serviceImportKind, "import", 10)}},
+				{BackendRefs: []gwv1alpha2.BackendRef{backendRef(serviceImportKind, "import", 20), backendRef(serviceKind, "invalid", 1)}},
+				{BackendRefs: []gwv1alpha2.BackendRef{backendRef(serviceKind, "invalid", 1)}},
+			},
+			expectedTargetGroups: []*model.RuleTargetGroup{
+				svcImportTG("import", 30),
+				{StackTargetGroupId: model.InvalidBackendRefTgId, Weight: 1},
+				{StackTargetGroupId: model.InvalidBackendRefTgId, Weight: 1},
+			},
+		},
+		{
+			name:            "hostname is required",
+			rules:           []gwv1alpha2.TLSRouteRule{{BackendRefs: []gwv1alpha2.BackendRef{backendRef(serviceKind, "svc", 1)}}},
+			expectedErrCode: LATTICE_UNSUPPORTED_SNI_HOSTNAME,
+		},
+		{
+			name:            "wildcard hostname",
+			hostnames:       []gwv1alpha2.Hostname{"tls.example.com", "*.example.com"},
+			rules:           []gwv1alpha2.TLSRouteRule{{BackendRefs: []gwv1alpha2.BackendRef{backendRef(serviceKind, "svc", 1)}}},
+			expectedErrCode: LATTICE_UNSUPPORTED_SNI_HOSTNAME,
+		},
+		{
+			name:            "more than one hostname",
+			hostnames:       []gwv1alpha2.Hostname{"tls.example.com", "other.example.com"},
+			rules:           []gwv1alpha2.TLSRouteRule{{BackendRefs: []gwv1alpha2.BackendRef{backendRef(serviceKind, "svc", 1)}}},
+			expectedErrCode: LATTICE_UNSUPPORTED_SNI_HOSTNAME,
+		},
+		{
+			name:            "weight exceeds the lattice maximum",
+			hostnames:       []gwv1alpha2.Hostname{"tls.example.com"},
+			rules:           []gwv1alpha2.TLSRouteRule{{BackendRefs: []gwv1alpha2.BackendRef{backendRef(serviceKind, "svc", 1000)}}},
+			expectedErrCode: LATTICE_UNSUPPORTED_WEIGHT,
+		},
+		{
+			name:      "added up weight exceeds the lattice maximum",
+			hostnames: []gwv1alpha2.Hostname{"tls.example.com"},
+			rules: []gwv1alpha2.TLSRouteRule{
+				{BackendRefs: []gwv1alpha2.BackendRef{backendRef(serviceImportKind, "import", 500)}},
+				{BackendRefs: []gwv1alpha2.BackendRef{backendRef(serviceImportKind, "import", 500)}},
+			},
+			expectedErrCode: LATTICE_UNSUPPORTED_WEIGHT,
+		},
+		{
+			name:            "too many backendRefs",
+			hostnames:       []gwv1alpha2.Hostname{"tls.example.com"},
+			rules:           []gwv1alpha2.TLSRouteRule{{BackendRefs: manyBackendRefs}},
+			expectedErrCode: LATTICE_EXCEED_MAX_TARGET_GROUPS,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.TODO()
+			k8sScheme := runtime.NewScheme()
+			clientgoscheme.AddToScheme(k8sScheme)
+			anv1alpha1.AddToScheme(k8sScheme)
+			k8sClient := testclient.NewClientBuilder().WithScheme(k8sScheme).Build()
+
+			route := core.NewTLSRoute(gwv1alpha2.TLSRoute{
+				ObjectMeta: metav1.ObjectMeta{Name: "route", Namespace: "default"},
+				Spec:       gwv1alpha2.TLSRouteSpec{Hostnames: tt.hostnames, Rules: tt.rules},
+			})
+			task := &latticeServiceModelBuildTask{
+				log:         gwlog.FallbackLogger,
+				route:       route,
+				client:      k8sClient,
+				stack:       core.NewDefaultStack(core.StackID{Name: "route", Namespace: "default"}),
+				brTgBuilder: &dummyTgBuilder{},
+			}
+
+			defaultAction, err := task.getListenerDefaultAction(ctx, vpclattice.ListenerProtocolTlsPassthrough)
+			if tt.expectedErrCode != "" {
+				var unsupportedErr *UnsupportedRouteError
+				assert.True(t, errors.As(err, &unsupportedErr))
+				assert.Equal(t, gwv1.RouteReasonUnsupportedValue, unsupportedErr.Reason)
+				assert.Contains(t, unsupportedErr.Message, tt.expectedErrCode)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, &model.DefaultAction{
+				Forward: &model.RuleAction{TargetGroups: tt.expectedTargetGroups},
+			}, defaultAction)
+		})
+	}
+}
+
+func Test_ParentListenerConfigIncompatibleWith(t *testing.T) {
+	terminate := gwv1.TLSModeTerminate
+	httpsListener := func(name string, port gwv1beta1.PortNumber, certArn string) gwv1beta1.Listener {
+		return gwv1beta1.Listener{
+			Name:     gwv1beta1.SectionName(name),
+			Port:     port,
+			Protocol: gwv1.HTTPSProtocolType,
+			TLS: &gwv1beta1.GatewayTLSConfig{
+				Mode:    &terminate,
+				Options: map[gwv1beta1.AnnotationKey]gwv1beta1.AnnotationValue{awsCustomCertARN: gwv1beta1.AnnotationValue(certArn)},
+			},
+		}
+	}
+	gw1 := &gwv1beta1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{Name: "gw1", Namespace: "default"},
+		Spec: gwv1beta1.GatewaySpec{Listeners: []gwv1beta1.Listener{
+			{Name: "http", Port: 80, Protocol: gwv1.HTTPProtocolType},
+			httpsListener("https", 443, "cert-1"),
+		}},
+	}
+	gw2 := &gwv1beta1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{Name: "gw2", Namespace: "default"},
+		Spec: gwv1beta1.GatewaySpec{Listeners: []gwv1beta1.Listener{
+			{Name: "http", Port: 443, Protocol: gwv1.HTTPProtocolType},
+			httpsListener("https", 443, "cert-2"),
+			httpsListener("https-same-cert", 8443, "cert-1"),
+			{Name: "http-8080", Port: 8080, Protocol: gwv1.HTTPProtocolType},
+		}},
+	}
+	section := func(name string) *gwv1beta1.SectionName {
+		sectionName := gwv1beta1.SectionName(name)
+		return &sectionName
+	}
+
+	accepted, err := NewParentListenerConfig(gw1, gwv1beta1.ParentReference{Name: "gw1", SectionName: section("https")})
+	assert.NoError(t, err)
+	assert.Equal(t, ParentListenerConfig{
+		GatewayName:  types.NamespacedName{Namespace: "default", Name: "gw1"},
+		ListenerName: "https",
+		Port:         443,
+		Protocol:     vpclattice.ListenerProtocolHttps,
+		Certificate:  "cert-1",
+	}, accepted)
+
+	tests := []struct {
+		name         string
+		parentRef    gwv1beta1.ParentReference
+		incompatible bool
+	}{
+		{
+			name:      "other listener of the same gateway",
+			parentRef: gwv1beta1.ParentReference{Name: "gw1", SectionName: section("http")},
+		},
+		{
+			name:         "same port with another protocol",
+			parentRef:    gwv1beta1.ParentReference{Name: "gw2", SectionName: section("http")},
+			incompatible: true,
+		},
+		{
+			name:         "another certificate",
+			parentRef:    gwv1beta1.ParentReference{Name: "gw2", SectionName: section("https")},
+			incompatible: true,
+		},
+		{
+			name:      "same certificate on another port",
+			parentRef: gwv1beta1.ParentReference{Name: "gw2", SectionName: section("https-same-cert")},
+		},
+		{
+			name:      "listener selected by port",
+			parentRef: gwv1beta1.ParentReference{Name: "gw2", Port: PortNumberPtr(8080)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gw := gw1
+			if tt.parentRef.Name == "gw2" {
+				gw = gw2
+			}
+			config, err := NewParentListenerConfig(gw, tt.parentRef)
+			assert.NoError(t, err)
+
+			err = config.IncompatibleWith(accepted)
+			if !tt.incompatible {
+				assert.NoError(t, err)
+				return
+			}
+			var unsupportedErr *UnsupportedRouteError
+			assert.ErrorAs(t, err, &unsupportedErr)
+			assert.Contains(t, unsupportedErr.Message, LATTICE_INCOMPATIBLE_LISTENERS)
+		})
+	}
+
+	_, err = NewParentListenerConfig(gw1, gwv1beta1.ParentReference{Name: "gw1", SectionName: section("missing")})
+	assert.Error(t, err)
+}
+
+func Test_ListenersOfMultipleGateways(t *testing.T) {
+	ctx := context.TODO()
+	k8sSchema := runtime.NewScheme()
+	clientgoscheme.AddToScheme(k8sSchema)
+	gwv1beta1.AddToScheme(k8sSchema)
+	k8sClient := testclient.NewClientBuilder().WithScheme(k8sSchema).Build()
+
+	for _, gw := range []*gwv1beta1.Gateway{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "gw1", Namespace: "default"},
+			Spec: gwv1beta1.GatewaySpec{Listeners: []gwv1beta1.Listener{
+				{Name: "http", Port: 80, Protocol: gwv1.HTTPProtocolType},
+			}},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "gw2", Namespace: "other"},
+			Spec: gwv1beta1.GatewaySpec{Listeners: []gwv1beta1.Listener{
+				{Name: "http", Port: 8080, Protocol: gwv1.HTTPProtocolType},
+			}},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "gw3", Namespace: "default"},
+			Spec: gwv1beta1.GatewaySpec{Listeners: []gwv1beta1.Listener{
+				{Name: "http", Port: 80, Protocol: gwv1.HTTPProtocolType},
+			}},
+		},
+	} {
+		assert.NoError(t, k8sClient.Create(ctx, gw))
+	}
+
+	route := core.NewHTTPRoute(gwv1beta1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{Name: "route", Namespace: "default"},
+		Spec: gwv1beta1.HTTPRouteSpec{
+			CommonRouteSpec: gwv1beta1.CommonRouteSpec{
+				ParentRefs: []gwv1beta1.ParentReference{
+					{Name: "gw1"},
+					{Name: "gw2", Namespace: (*gwv1beta1.Namespace)(aws.String("other"))},
+					{Name: "gw3"},
+				},
+			},
+		},
+	})
+	stack := core.NewDefaultStack(core.StackID(k8s.NamespacedName(route.K8sObject())))
+	task := &latticeServiceModelBuildTask{
+		log:    gwlog.FallbackLogger,
+		route:  route,
+		stack:  stack,
+		client: k8sClient,
+	}
+	assert.NoError(t, task.buildListeners(ctx, "svc-id"))
+
+	// gw1 and gw3 share the listener on port 80
+	var listeners []*model.Listener
+	assert.NoError(t, stack.ListResources(&listeners))
+	var ports []int64
+	for _, listener := range listeners {
+		ports = append(ports, listener.Spec.Port)
+	}
+	assert.ElementsMatch(t, []int64{80, 8080}, ports)
+}
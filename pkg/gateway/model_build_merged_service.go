@@ -0,0 +1,435 @@
+package gateway
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/service/vpclattice"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/aws/aws-application-networking-k8s/pkg/config"
+	"github.com/aws/aws-application-networking-k8s/pkg/k8s"
+	"github.com/aws/aws-application-networking-k8s/pkg/model/core"
+	model "github.com/aws/aws-application-networking-k8s/pkg/model/lattice"
+	"github.com/aws/aws-application-networking-k8s/pkg/utils"
+	"github.com/aws/aws-application-networking-k8s/pkg/utils/gwlog"
+)
+
+const (
+	// merge key of the service a route was last deployed to, absent when it was deployed as its own service
+	MergedServiceAnnotation = k8s.AnnotationPrefix + "merged-service"
+
+	LATTICE_CONFLICTING_RULE          = "LATTICE_CONFLICTING_RULE"
+	LATTICE_MULTIPLE_MERGED_HOSTNAMES = "LATTICE_MULTIPLE_MERGED_HOSTNAMES"
+
+	// reason of a route whose rules all conflict with the rules of merged routes taking precedence
+	RouteReasonConflicted gwv1.RouteConditionReason = "Conflicted"
+)
+
+// RouteMergeKey identifies the routes deployed as a single lattice service in merge mode: HTTPRoutes and
+// GRPCRoutes attached to the same gateway, with the same hostname.
+type RouteMergeKey struct {
+	GatewayNamespace string
+	GatewayName      string
+	Hostname         string
+}
+
+// String returns the key as recorded by the MergedServiceAnnotation
+func (k RouteMergeKey) String() string {
+	return fmt.Sprintf("%s/%s/%s", k.GatewayNamespace, k.GatewayName, k.Hostname)
+}
+
+func (k RouteMergeKey) LatticeServiceName() string {
+	return utils.MergedLatticeServiceName(k.GatewayName, k.GatewayNamespace, k.Hostname)
+}
+
+// RouteMergeKeyOf returns the key of the service the route is merged into. Routes are deployed as their own
+// service when merge mode is disabled, when they are TLSRoutes or when they have no hostname lattice can serve.
+// Routes with several hostnames have no key either, they are rejected by validateMergedRouteHostnames.
+func RouteMergeKeyOf(route core.Route) (RouteMergeKey, bool) {
+	if _, ok := MergeableRouteType(route); !ok || !config.MergeRoutesByHostname || len(route.Spec().ParentRefs()) == 0 {
+		return RouteMergeKey{}, false
+	}
+	domainNames, _ := SplitRouteHostnames(route)
+	if len(domainNames) != 1 {
+		return RouteMergeKey{}, false
+	}
+
+	// routes are merged by the hostname of their 1st gateway
+	parentRef := route.Spec().ParentRefs()[0]
+	gwNamespace := route.Namespace()
+	if parentRef.Namespace != nil {
+		gwNamespace = string(*parentRef.Namespace)
+	}
+	return RouteMergeKey{
+		GatewayNamespace: gwNamespace,
+		GatewayName:      string(parentRef.Name),
+		Hostname:         strings.ToLower(domainNames[0]),
+	}, true
+}
+
+// DeployedRouteMergeKey returns the key recorded on the route when it was last deployed to a merged service,
+// false when it was deployed as its own service
+func DeployedRouteMergeKey(route core.Route) (RouteMergeKey, bool) {
+	_, ok := MergeableRouteType(route)
+	value, found := route.K8sObject().GetAnnotations()[MergedServiceAnnotation]
+	if !ok || !found {
+		return RouteMergeKey{}, false
+	}
+	parts := strings.SplitN(value, "/", 3)
+	if len(parts) != 3 {
+		return RouteMergeKey{}, false
+	}
+	return RouteMergeKey{
+		GatewayNamespace: parts[0],
+		GatewayName:      parts[1],
+		Hostname:         parts[2],
+	}, true
+}
+
+// In merge mode, a route with several hostnames would belong to the merged service of each of them. Such a
+// route is not deployed, rather than being merged by one hostname and taking the others from their services.
+func validateMergedRouteHostnames(route core.Route) error {
+	if _, ok := MergeableRouteType(route); !ok || !config.MergeRoutesByHostname || !route.DeletionTimestamp().IsZero() {
+		return nil
+	}
+	domainNames, _ := SplitRouteHostnames(route)
+	if len(domainNames) > 1 {
+		return newUnsupportedValueError(LATTICE_MULTIPLE_MERGED_HOSTNAMES,
+			"routes are merged by hostname, a route cannot have more than one hostname, found %s",
+			strings.Join(domainNames, ", "))
+	}
+	return nil
+}
+
+// a deleted route is removed from the service it was last deployed to, other routes are deployed
+// to the service they belong to
+func routeMergeKeyToBuild(route core.Route) (RouteMergeKey, bool) {
+	if !route.DeletionTimestamp().IsZero() {
+		return DeployedRouteMergeKey(route)
+	}
+	return RouteMergeKeyOf(route)
+}
+
+// MergeableRouteType returns the type of the route, false when routes of its type cannot be merged
+func MergeableRouteType(route core.Route) (core.RouteType, bool) {
+	switch route.(type) {
+	case *core.HTTPRoute:
+		return core.HttpRouteType, true
+	case *core.GRPCRoute:
+		return core.GrpcRouteType, true
+	default:
+		// TLS_PASSTHROUGH listeners cannot have rules, the routes of a service cannot be told apart
+		return "", false
+	}
+}
+
+// ListMergedRoutes returns the routes deployed to the merged service of the key, in precedence order.
+// As mandated by the gateway spec, the oldest route takes precedence, then the first in alphabetical
+// order of namespace/name. Deleted routes and routes detached from all parents are not deployed.
+func ListMergedRoutes(ctx context.Context, k8sClient client.Client, key RouteMergeKey) ([]core.Route, error) {
+	routes, err := core.ListHTTPRoutes(ctx, k8sClient)
+	if err != nil {
+		return nil, err
+	}
+	grpcRoutes, err := core.ListGRPCRoutes(ctx, k8sClient)
+	if err != nil {
+		return nil, err
+	}
+	routes = append(routes, grpcRoutes...)
+
+	var merged []core.Route
+	for _, route := range routes {
+		if !route.DeletionTimestamp().IsZero() || core.IsRouteDetachedFromAllParents(route) {
+			continue
+		}
+		if routeKey, ok := RouteMergeKeyOf(route); ok && routeKey == key {
+			merged = append(merged, route)
+		}
+	}
+	sortRoutesByPrecedence(merged)
+	return merged, nil
+}
+
+func sortRoutesByPrecedence(routes []core.Route) {
+	sort.SliceStable(routes, func(i, j int) bool {
+		ti := routes[i].K8sObject().GetCreationTimestamp()
+		tj := routes[j].K8sObject().GetCreationTimestamp()
+		if !ti.Equal(&tj) {
+			return ti.Before(&tj)
+		}
+		if routes[i].Namespace() != routes[j].Namespace() {
+			return routes[i].Namespace() < routes[j].Namespace()
+		}
+		if routes[i].Name() != routes[j].Name() {
+			return routes[i].Name() < routes[j].Name()
+		}
+		return routes[i].GroupKind().Kind < routes[j].GroupKind().Kind
+	})
+}
+
+// IsSameRoute returns whether both routes are the same object, merged routes of different types can share a name
+func IsSameRoute(a, b core.Route) bool {
+	return a.GroupKind() == b.GroupKind() && a.Namespace() == b.Namespace() && a.Name() == b.Name()
+}
+
+func (b *LatticeServiceModelBuilder) buildMergedService(ctx context.Context, route core.Route, key RouteMergeKey) (core.Stack, error) {
+	listed, err := ListMergedRoutes(ctx, b.client, key)
+	if err != nil {
+		return nil, err
+	}
+	// the route is built as given rather than as listed, e.g. it may be a copy being detached
+	var members []core.Route
+	for _, member := range listed {
+		if !IsSameRoute(member, route) {
+			members = append(members, member)
+		}
+	}
+	if route.DeletionTimestamp().IsZero() {
+		members = append(members, route)
+		sortRoutesByPrecedence(members)
+	}
+
+	stack := core.NewDefaultStack(core.StackID{Namespace: key.GatewayNamespace, Name: key.LatticeServiceName()})
+	task := &mergedServiceModelBuildTask{
+		log:         b.log,
+		client:      b.client,
+		stack:       stack,
+		brTgBuilder: b.brTgBuilder,
+		key:         key,
+		route:       route,
+		members:     members,
+	}
+	if err := task.run(ctx); err != nil {
+		return task.stack, err
+	}
+	return task.stack, nil
+}
+
+// builds the lattice service merging the routes of a gateway hostname, only the errors of the route
+// the model is built for are returned. The rules of the other routes are reported by their own builds.
+type mergedServiceModelBuildTask struct {
+	log         gwlog.Logger
+	client      client.Client
+	stack       core.Stack
+	brTgBuilder BackendRefTargetGroupModelBuilder
+	key         RouteMergeKey
+	route       core.Route
+	// routes deployed to the service in precedence order, without the route when it is deleted
+	members []core.Route
+}
+
+func (t *mergedServiceModelBuildTask) memberTask(route core.Route) *latticeServiceModelBuildTask {
+	return &latticeServiceModelBuildTask{
+		log:         t.log,
+		route:       route,
+		client:      t.client,
+		stack:       t.stack,
+		brTgBuilder: t.brTgBuilder,
+	}
+}
+
+func (t *mergedServiceModelBuildTask) run(ctx context.Context) error {
+	modelSvc, err := t.buildLatticeService(ctx)
+	if err != nil {
+		return err
+	}
+
+	if !t.route.DeletionTimestamp().IsZero() {
+		// the rules of the route are removed from the service, then its target groups are deleted
+		if err := t.memberTask(t.route).buildDeletedRouteTargetGroups(ctx); err != nil {
+			return err
+		}
+	}
+	if modelSvc.IsDeleted {
+		return nil
+	}
+
+	if err := t.buildListeners(ctx, modelSvc.ID()); err != nil {
+		return fmt.Errorf("failed to build listener due to %w", err)
+	}
+	if err := t.buildRules(ctx); err != nil {
+		var partialErr *PartiallyInvalidRouteError
+		if errors.As(err, &partialErr) {
+			return err
+		}
+		return fmt.Errorf("failed to build rules due to %w", err)
+	}
+	return nil
+}
+
+func (t *mergedServiceModelBuildTask) buildLatticeService(ctx context.Context) (*model.Service, error) {
+	spec := model.ServiceSpec{
+		ServiceTagFields: model.ServiceTagFields{
+			GatewayName:      t.key.GatewayName,
+			GatewayNamespace: t.key.GatewayNamespace,
+			Hostname:         t.key.Hostname,
+		},
+		CustomerDomainName: t.key.Hostname,
+	}
+
+	// the route taking precedence owns the DNS records, the deleted route keeps them until it is gone
+	dnsRoute := t.route
+	if len(t.members) > 0 {
+		dnsRoute = t.members[0]
+	}
+	spec.DnsRouteName = dnsRoute.Name()
+	spec.DnsRouteNamespace = dnsRoute.Namespace()
+	spec.DnsRouteType, _ = MergeableRouteType(dnsRoute)
+
+	snNames := utils.NewSet[string]()
+	for _, member := range t.members {
+		for _, parentRef := range member.Spec().ParentRefs() {
+			if core.IsRouteDetachedFromParent(member, parentRef) {
+				continue
+			}
+			snName, err := ServiceNetworkName(ctx, t.client, ParentRefGatewayName(member, parentRef))
+			if err != nil {
+				return nil, err
+			}
+			if snNames.Contains(snName) {
+				continue
+			}
+			snNames.Put(snName)
+			spec.ServiceNetworkNames = append(spec.ServiceNetworkNames, snName)
+		}
+
+		if spec.CustomerCertARN == "" {
+			certArn, err := t.memberTask(member).getACMCertArn(ctx)
+			if err != nil {
+				return nil, err
+			}
+			spec.CustomerCertARN = certArn
+		}
+	}
+	if config.ServiceNetworkOverrideMode {
+		spec.ServiceNetworkNames = []string{config.DefaultServiceNetwork}
+	}
+
+	svc, err := model.NewLatticeService(t.stack, spec)
+	if err != nil {
+		return nil, err
+	}
+
+	t.log.Debugf("Added service %s merging %d routes of %s to the stack (ID %s)",
+		svc.Spec.LatticeServiceName(), len(t.members), t.key, svc.ID())
+	svc.IsDeleted = len(t.members) == 0
+	return svc, nil
+}
+
+// the routes share one listener per port and protocol of the gateway listeners they are attached to
+func (t *mergedServiceModelBuildTask) buildListeners(ctx context.Context, stackSvcId string) error {
+	built := utils.NewSet[string]()
+	for _, member := range t.members {
+		memberTask := t.memberTask(member)
+		for _, parentRef := range member.Spec().ParentRefs() {
+			if core.IsRouteDetachedFromParent(member, parentRef) {
+				t.log.Debugf("Ignore parentref %s-%s route %s-%s is not deployed to",
+					parentRef.Name, parentRef.Namespace, member.Name(), member.Namespace())
+				continue
+			}
+
+			port, protocol, err := memberTask.extractListenerInfo(ctx, parentRef)
+			if err == nil && protocol == vpclattice.ListenerProtocolTlsPassthrough {
+				err = fmt.Errorf("%s %s-%s cannot be merged into a TLS_PASSTHROUGH listener",
+					member.GroupKind().Kind, member.Name(), member.Namespace())
+			}
+			if err != nil {
+				if member == t.route {
+					return err
+				}
+				t.log.Infof("Skipping listener of route %s-%s merged into %s due to %s",
+					member.Name(), member.Namespace(), t.key, err)
+				continue
+			}
+			listenerKey := fmt.Sprintf("%d-%s", port, protocol)
+			if built.Contains(listenerKey) {
+				continue
+			}
+			built.Put(listenerKey)
+
+			defaultAction, err := memberTask.getListenerDefaultAction(ctx, protocol)
+			if err != nil {
+				return err
+			}
+			spec := model.ListenerSpec{
+				StackServiceId:    stackSvcId,
+				K8SRouteName:      t.key.GatewayName,
+				K8SRouteNamespace: t.key.GatewayNamespace,
+				Port:              port,
+				Protocol:          protocol,
+				DefaultAction:     defaultAction,
+			}
+			modelListener, err := model.NewListener(t.stack, spec)
+			if err != nil {
+				return err
+			}
+			t.log.Debugf("Added listener %d-%s of %s to the stack (ID %s)", port, protocol, t.key, modelListener.ID())
+		}
+	}
+	return nil
+}
+
+// The rules of all routes are ordered by precedence on every listener. A match already used by a route
+// taking precedence is not deployed again, the rule it belongs to is reported as conflicting.
+func (t *mergedServiceModelBuildTask) buildRules(ctx context.Context) error {
+	var modelListeners []*model.Listener
+	if err := t.stack.ListResources(&modelListeners); err != nil {
+		return err
+	}
+
+	var routeErr error
+	for _, modelListener := range modelListeners {
+		var builtSpecs []model.RuleSpec
+		// route of each built rule spec, to report conflicts
+		var specRoutes []core.Route
+		for _, member := range t.members {
+			specsByRule, droppedRules, err := t.memberTask(member).buildRouteRuleSpecs(ctx, modelListener.ID())
+			if err != nil {
+				if member == t.route {
+					return err
+				}
+				t.log.Infof("Skipping rules of route %s-%s merged into %s due to %s",
+					member.Name(), member.Namespace(), t.key, err)
+				continue
+			}
+
+			for i, ruleSpecs := range specsByRule {
+				for _, ruleSpec := range ruleSpecs {
+					if j := indexOfRuleMatch(builtSpecs, ruleSpec); j >= 0 {
+						if owner := specRoutes[j]; owner != member && droppedRules[i] == nil {
+							droppedRules[i] = newUnsupportedRouteError(RouteReasonConflicted, LATTICE_CONFLICTING_RULE,
+								"a match conflicts with %s %s-%s, which takes precedence",
+								owner.GroupKind().Kind, owner.Name(), owner.Namespace())
+						}
+						continue
+					}
+					builtSpecs = append(builtSpecs, ruleSpec)
+					specRoutes = append(specRoutes, member)
+				}
+			}
+
+			if member == t.route {
+				routeErr = newDroppedRulesError(len(member.Spec().Rules()), droppedRules)
+			}
+		}
+
+		// the catch-all rule of the route taking precedence is the default action, the others conflict with it
+		builtSpecs, err := t.memberTask(t.route).buildListenerDefaultActionFromRules(modelListener.ID(), builtSpecs)
+		if err != nil {
+			return err
+		}
+		if len(builtSpecs) > model.MaxRulePriority {
+			return newUnsupportedValueError(LATTICE_EXCEED_MAX_RULES,
+				"routes merged into %s require more than %d rules", t.key, model.MaxRulePriority)
+		}
+		if err := t.memberTask(t.route).addRulesByPrecedence(builtSpecs); err != nil {
+			return err
+		}
+	}
+	return routeErr
+}
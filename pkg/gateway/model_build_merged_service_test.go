@@ -0,0 +1,326 @@
+package gateway
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+	apimachineryv1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gwv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	gwv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/aws/aws-application-networking-k8s/pkg/config"
+	"github.com/aws/aws-application-networking-k8s/pkg/model/core"
+	model "github.com/aws/aws-application-networking-k8s/pkg/model/lattice"
+	"github.com/aws/aws-application-networking-k8s/pkg/utils/gwlog"
+)
+
+func newMergedTestRoute(name string, created time.Time, hostname string, rules ...gwv1beta1.HTTPRouteRule) *gwv1beta1.HTTPRoute {
+	return &gwv1beta1.HTTPRoute{
+		ObjectMeta: apimachineryv1.ObjectMeta{
+			Name:              name,
+			Namespace:         "default",
+			CreationTimestamp: apimachineryv1.NewTime(created),
+		},
+		Spec: gwv1beta1.HTTPRouteSpec{
+			CommonRouteSpec: gwv1beta1.CommonRouteSpec{
+				ParentRefs: []gwv1beta1.ParentReference{{Name: "gw"}},
+			},
+			Hostnames: []gwv1beta1.Hostname{gwv1beta1.Hostname(hostname)},
+			Rules:     rules,
+		},
+	}
+}
+
+func newMergedTestRule(matchType gwv1.PathMatchType, path string) gwv1beta1.HTTPRouteRule {
+	var serviceKind gwv1beta1.Kind = "Service"
+	return gwv1beta1.HTTPRouteRule{
+		Matches: []gwv1beta1.HTTPRouteMatch{{
+			Path: &gwv1beta1.HTTPPathMatch{Type: &matchType, Value: aws.String(path)},
+		}},
+		BackendRefs: []gwv1beta1.HTTPBackendRef{{
+			BackendRef: gwv1beta1.BackendRef{
+				BackendObjectReference: gwv1beta1.BackendObjectReference{Name: "svc", Kind: &serviceKind},
+			},
+		}},
+	}
+}
+
+func Test_RouteMergeKeyOf(t *testing.T) {
+	defer func() { config.MergeRoutesByHostname = false }()
+	route := core.NewHTTPRoute(*newMergedTestRoute("route", time.Now(), "API.example.com"))
+
+	_, ok := RouteMergeKeyOf(route)
+	assert.False(t, ok, "merge mode is disabled")
+
+	config.MergeRoutesByHostname = true
+	key, ok := RouteMergeKeyOf(route)
+	assert.True(t, ok)
+	assert.Equal(t, RouteMergeKey{
+		GatewayNamespace: "default",
+		GatewayName:      "gw",
+		Hostname:         "api.example.com",
+	}, key)
+
+	wildcard := core.NewHTTPRoute(*newMergedTestRoute("route", time.Now(), "*.example.com"))
+	_, ok = RouteMergeKeyOf(wildcard)
+	assert.False(t, ok, "no hostname can be served")
+
+	multiHostname := newMergedTestRoute("route", time.Now(), "api.example.com")
+	multiHostname.Spec.Hostnames = append(multiHostname.Spec.Hostnames, "other.example.com")
+	_, ok = RouteMergeKeyOf(core.NewHTTPRoute(*multiHostname))
+	assert.False(t, ok, "routes with several hostnames are not merged")
+
+	tlsRoute := core.NewTLSRoute(gwv1alpha2.TLSRoute{
+		ObjectMeta: apimachineryv1.ObjectMeta{Name: "route", Namespace: "default"},
+		Spec: gwv1alpha2.TLSRouteSpec{
+			CommonRouteSpec: gwv1alpha2.CommonRouteSpec{ParentRefs: []gwv1alpha2.ParentReference{{Name: "gw"}}},
+			Hostnames:       []gwv1alpha2.Hostname{"api.example.com"},
+		},
+	})
+	_, ok = RouteMergeKeyOf(tlsRoute)
+	assert.False(t, ok, "TLSRoutes are not merged")
+
+	_, ok = DeployedRouteMergeKey(route)
+	assert.False(t, ok)
+	route.K8sObject().SetAnnotations(map[string]string{MergedServiceAnnotation: key.String()})
+	deployed, ok := DeployedRouteMergeKey(route)
+	assert.True(t, ok)
+	assert.Equal(t, key, deployed)
+}
+
+func Test_LatticeServiceModelBuild_MergedRoutes(t *testing.T) {
+	config.MergeRoutesByHostname = true
+	defer func() { config.MergeRoutesByHostname = false }()
+	ctx := context.TODO()
+
+	k8sSchema := runtime.NewScheme()
+	clientgoscheme.AddToScheme(k8sSchema)
+	gwv1beta1.AddToScheme(k8sSchema)
+	gwv1alpha2.AddToScheme(k8sSchema)
+	k8sClient := testclient.NewClientBuilder().WithScheme(k8sSchema).Build()
+
+	gw := &gwv1beta1.Gateway{
+		ObjectMeta: apimachineryv1.ObjectMeta{Name: "gw", Namespace: "default"},
+		Spec: gwv1beta1.GatewaySpec{
+			Listeners: []gwv1beta1.Listener{{Name: "http", Port: 80, Protocol: gwv1.HTTPProtocolType}},
+		},
+	}
+	created := time.Now().Add(-time.Hour)
+	routeA := newMergedTestRoute("route-a", created, "api.example.com",
+		newMergedTestRule(gwv1.PathMatchPathPrefix, "/api"),
+		newMergedTestRule(gwv1.PathMatchPathPrefix, "/shared"))
+	routeB := newMergedTestRoute("route-b", created.Add(time.Minute), "api.example.com",
+		newMergedTestRule(gwv1.PathMatchPathPrefix, "/shared"),
+		newMergedTestRule(gwv1.PathMatchExact, "/b"))
+	otherHostname := newMergedTestRoute("route-c", created, "other.example.com",
+		newMergedTestRule(gwv1.PathMatchPathPrefix, "/"))
+	for _, obj := range []client.Object{gw, routeA, routeB, otherHostname} {
+		assert.NoError(t, k8sClient.Create(ctx, obj))
+	}
+	key, _ := RouteMergeKeyOf(core.NewHTTPRoute(*routeA))
+	builder := NewLatticeServiceBuilder(gwlog.FallbackLogger, k8sClient, &dummyTgBuilder{})
+
+	build := func(route core.Route) (*model.Service, []*model.Listener, map[string]int64, error) {
+		stack, err := builder.Build(ctx, route)
+		var svcs []*model.Service
+		var listeners []*model.Listener
+		var rules []*model.Rule
+		assert.NoError(t, stack.ListResources(&svcs))
+		assert.NoError(t, stack.ListResources(&listeners))
+		assert.NoError(t, stack.ListResources(&rules))
+		assert.Len(t, svcs, 1)
+		priorities := make(map[string]int64)
+		for _, rule := range rules {
+			priorities[rule.Spec.PathMatchValue] = rule.Spec.Priority
+		}
+		return svcs[0], listeners, priorities, err
+	}
+
+	t.Run("routes sharing a hostname are merged", func(t *testing.T) {
+		svc, listeners, priorities, err := build(core.NewHTTPRoute(*routeA))
+		assert.NoError(t, err)
+		assert.False(t, svc.IsDeleted)
+		assert.Equal(t, key.LatticeServiceName(), svc.LatticeServiceName())
+		assert.Equal(t, model.ServiceTagFields{
+			GatewayName:      "gw",
+			GatewayNamespace: "default",
+			Hostname:         "api.example.com",
+		}, svc.Spec.ServiceTagFields)
+		assert.Equal(t, "api.example.com", svc.Spec.CustomerDomainName)
+		assert.Equal(t, []string{"gw"}, svc.Spec.ServiceNetworkNames)
+		assert.Equal(t, "route-a", svc.Spec.DnsRouteName)
+		assert.Equal(t, core.HttpRouteType, svc.Spec.DnsRouteType)
+		assert.Len(t, listeners, 1)
+		// /shared of route-b conflicts with route-a, which is older
+		assert.Equal(t, map[string]int64{"/b": 1, "/shared": 2, "/api": 3}, priorities)
+	})
+
+	t.Run("conflicting rules are reported on the route", func(t *testing.T) {
+		_, _, priorities, err := build(core.NewHTTPRoute(*routeB))
+		assert.Equal(t, map[string]int64{"/b": 1, "/shared": 2, "/api": 3}, priorities)
+		var partialErr *PartiallyInvalidRouteError
+		assert.True(t, errors.As(err, &partialErr))
+		assert.Equal(t, []int{0}, partialErr.DroppedRules)
+		assert.Contains(t, partialErr.Message, LATTICE_CONFLICTING_RULE)
+		assert.Contains(t, partialErr.Message, "route-a")
+	})
+
+	t.Run("deleted route only removes its rules", func(t *testing.T) {
+		deleted := routeA.DeepCopy()
+		deleted.DeletionTimestamp = &apimachineryv1.Time{Time: time.Now()}
+		deleted.Annotations = map[string]string{MergedServiceAnnotation: key.String()}
+		svc, listeners, priorities, err := build(core.NewHTTPRoute(*deleted))
+		assert.NoError(t, err)
+		assert.False(t, svc.IsDeleted)
+		assert.Equal(t, "route-b", svc.Spec.DnsRouteName)
+		assert.Len(t, listeners, 1)
+		assert.Equal(t, map[string]int64{"/b": 1, "/shared": 2}, priorities)
+	})
+
+	t.Run("service is deleted with its last route", func(t *testing.T) {
+		otherKey, _ := RouteMergeKeyOf(core.NewHTTPRoute(*otherHostname))
+		deleted := otherHostname.DeepCopy()
+		deleted.DeletionTimestamp = &apimachineryv1.Time{Time: time.Now()}
+		deleted.Annotations = map[string]string{MergedServiceAnnotation: otherKey.String()}
+		svc, listeners, priorities, err := build(core.NewHTTPRoute(*deleted))
+		assert.NoError(t, err)
+		assert.True(t, svc.IsDeleted)
+		assert.Equal(t, otherKey.LatticeServiceName(), svc.LatticeServiceName())
+		assert.Empty(t, listeners)
+		assert.Empty(t, priorities)
+	})
+
+	t.Run("deleted route without annotation was deployed as its own service", func(t *testing.T) {
+		deleted := routeB.DeepCopy()
+		deleted.DeletionTimestamp = &apimachineryv1.Time{Time: time.Now()}
+		svc, _, _, err := build(core.NewHTTPRoute(*deleted))
+		assert.NoError(t, err)
+		assert.True(t, svc.IsDeleted)
+		assert.Equal(t, "route-b-default", svc.LatticeServiceName())
+	})
+}
+
+func Test_LatticeServiceModelBuild_MergedHTTPAndGRPCRoutes(t *testing.T) {
+	config.MergeRoutesByHostname = true
+	defer func() { config.MergeRoutesByHostname = false }()
+	ctx := context.TODO()
+
+	k8sSchema := runtime.NewScheme()
+	clientgoscheme.AddToScheme(k8sSchema)
+	gwv1beta1.AddToScheme(k8sSchema)
+	gwv1alpha2.AddToScheme(k8sSchema)
+	k8sClient := testclient.NewClientBuilder().WithScheme(k8sSchema).Build()
+
+	gw := &gwv1beta1.Gateway{
+		ObjectMeta: apimachineryv1.ObjectMeta{Name: "gw", Namespace: "default"},
+		Spec: gwv1beta1.GatewaySpec{
+			Listeners: []gwv1beta1.Listener{{Name: "http", Port: 80, Protocol: gwv1.HTTPProtocolType}},
+		},
+	}
+	created := time.Now().Add(-time.Hour)
+	httpRoute := newMergedTestRoute("route", created.Add(time.Minute), "api.example.com",
+		newMergedTestRule(gwv1.PathMatchPathPrefix, "/api"))
+	var serviceKind gwv1beta1.Kind = "Service"
+	methodMatchType := gwv1alpha2.GRPCMethodMatchExact
+	// same name as the HTTPRoute, routes of different types are told apart
+	grpcRoute := &gwv1alpha2.GRPCRoute{
+		ObjectMeta: apimachineryv1.ObjectMeta{
+			Name:              "route",
+			Namespace:         "default",
+			CreationTimestamp: apimachineryv1.NewTime(created),
+		},
+		Spec: gwv1alpha2.GRPCRouteSpec{
+			CommonRouteSpec: gwv1alpha2.CommonRouteSpec{
+				ParentRefs: []gwv1alpha2.ParentReference{{Name: "gw"}},
+			},
+			Hostnames: []gwv1alpha2.Hostname{"api.example.com"},
+			Rules: []gwv1alpha2.GRPCRouteRule{{
+				Matches: []gwv1alpha2.GRPCRouteMatch{{
+					Method: &gwv1alpha2.GRPCMethodMatch{Type: &methodMatchType, Service: aws.String("greet.Greeter")},
+				}},
+				BackendRefs: []gwv1alpha2.GRPCBackendRef{{
+					BackendRef: gwv1beta1.BackendRef{
+						BackendObjectReference: gwv1beta1.BackendObjectReference{Name: "svc", Kind: &serviceKind},
+					},
+				}},
+			}},
+		},
+	}
+	for _, obj := range []client.Object{gw, httpRoute, grpcRoute} {
+		assert.NoError(t, k8sClient.Create(ctx, obj))
+	}
+
+	httpKey, ok := RouteMergeKeyOf(core.NewHTTPRoute(*httpRoute))
+	assert.True(t, ok)
+	grpcKey, ok := RouteMergeKeyOf(core.NewGRPCRoute(*grpcRoute))
+	assert.True(t, ok)
+	assert.Equal(t, httpKey, grpcKey)
+
+	routes, err := ListMergedRoutes(ctx, k8sClient, httpKey)
+	assert.NoError(t, err)
+	assert.Len(t, routes, 2)
+
+	builder := NewLatticeServiceBuilder(gwlog.FallbackLogger, k8sClient, &dummyTgBuilder{})
+	for _, route := range []core.Route{core.NewHTTPRoute(*httpRoute), core.NewGRPCRoute(*grpcRoute)} {
+		stack, err := builder.Build(ctx, route)
+		assert.NoError(t, err)
+		var svcs []*model.Service
+		var rules []*model.Rule
+		assert.NoError(t, stack.ListResources(&svcs))
+		assert.NoError(t, stack.ListResources(&rules))
+		assert.Len(t, svcs, 1)
+		assert.Equal(t, httpKey.LatticeServiceName(), svcs[0].LatticeServiceName())
+		// the GRPCRoute is older and owns the DNS records
+		assert.Equal(t, "route", svcs[0].Spec.DnsRouteName)
+		assert.Equal(t, core.GrpcRouteType, svcs[0].Spec.DnsRouteType)
+
+		var paths []string
+		for _, rule := range rules {
+			paths = append(paths, rule.Spec.PathMatchValue)
+		}
+		assert.ElementsMatch(t, []string{"/greet.Greeter/", "/api"}, paths)
+	}
+}
+
+func Test_LatticeServiceModelBuild_MergedRouteWithSeveralHostnames(t *testing.T) {
+	config.MergeRoutesByHostname = true
+	defer func() { config.MergeRoutesByHostname = false }()
+	ctx := context.TODO()
+
+	k8sSchema := runtime.NewScheme()
+	clientgoscheme.AddToScheme(k8sSchema)
+	gwv1beta1.AddToScheme(k8sSchema)
+	gwv1alpha2.AddToScheme(k8sSchema)
+	k8sClient := testclient.NewClientBuilder().WithScheme(k8sSchema).Build()
+	builder := NewLatticeServiceBuilder(gwlog.FallbackLogger, k8sClient, &dummyTgBuilder{})
+
+	route := newMergedTestRoute("route", time.Now(), "api.example.com",
+		newMergedTestRule(gwv1.PathMatchPathPrefix, "/"))
+	route.Spec.Hostnames = append(route.Spec.Hostnames, "other.example.com", "*.example.com")
+
+	_, err := builder.Build(ctx, core.NewHTTPRoute(*route))
+	var unsupportedErr *UnsupportedRouteError
+	assert.ErrorAs(t, err, &unsupportedErr)
+	assert.Equal(t, gwv1.RouteReasonUnsupportedValue, unsupportedErr.Reason)
+	assert.Contains(t, unsupportedErr.Message, "api.example.com, other.example.com")
+
+	// the route is removed from the service it was merged into before
+	route.Annotations = map[string]string{MergedServiceAnnotation: "default/gw/api.example.com"}
+	now := apimachineryv1.Now()
+	route.DeletionTimestamp = &now
+	stack, err := builder.Build(ctx, core.NewHTTPRoute(*route))
+	assert.NoError(t, err)
+	var svcs []*model.Service
+	assert.NoError(t, stack.ListResources(&svcs))
+	assert.Len(t, svcs, 1)
+	assert.True(t, svcs[0].IsDeleted)
+}
@@ -0,0 +1,119 @@
+package gateway
+
+import (
+	"errors"
+	"fmt"
+	"regexp/syntax"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/vpclattice"
+)
+
+type regexMatchType string
+
+const (
+	regexMatchExact    regexMatchType = "Exact"
+	regexMatchPrefix   regexMatchType = "Prefix"
+	regexMatchContains regexMatchType = "Contains"
+)
+
+// regexMatch is a RegularExpression match of a route translated into a VPC Lattice string match
+type regexMatch struct {
+	Type          regexMatchType
+	Value         string
+	CaseSensitive bool
+}
+
+// analyzeRegex translates a RegularExpression route match into a VPC Lattice string match. The
+// expression must match the whole value, so ^ and $ anchors are optional. Only a literal, optionally
+// preceded and/or followed by .*, can be translated:
+//
+//	foo, ^foo$     exact match of foo
+//	foo.*, ^foo.*  prefix match of foo
+//	.*foo.*        match of values containing foo
+//
+// A (?i) flag on the literal makes the match case insensitive. The returned error explains why
+// any other expression cannot be translated.
+func analyzeRegex(expr string) (regexMatch, error) {
+	re, err := syntax.Parse(expr, syntax.Perl)
+	if err != nil {
+		return regexMatch{}, fmt.Errorf("invalid regular expression: %s", err)
+	}
+	re = re.Simplify()
+
+	subs := []*syntax.Regexp{re}
+	if re.Op == syntax.OpConcat {
+		subs = re.Sub
+	}
+	if len(subs) > 0 && (subs[0].Op == syntax.OpBeginText || subs[0].Op == syntax.OpBeginLine) {
+		subs = subs[1:]
+	}
+	if len(subs) > 0 && (subs[len(subs)-1].Op == syntax.OpEndText || subs[len(subs)-1].Op == syntax.OpEndLine) {
+		subs = subs[:len(subs)-1]
+	}
+	leadingAny := len(subs) > 0 && isAnyString(subs[0])
+	if leadingAny {
+		subs = subs[1:]
+	}
+	trailingAny := len(subs) > 0 && isAnyString(subs[len(subs)-1])
+	if trailingAny {
+		subs = subs[:len(subs)-1]
+	}
+
+	if len(subs) == 0 {
+		return regexMatch{}, errors.New("expression has no literal to match")
+	}
+	// adjacent literals with different flags are not merged by the parser, e.g. foo(?i)bar
+	var value strings.Builder
+	foldCase := subs[0].Flags & syntax.FoldCase
+	for _, sub := range subs {
+		if sub.Op == syntax.OpAnyChar || sub.Op == syntax.OpAnyCharNotNL {
+			return regexMatch{}, errors.New(`only a literal optionally preceded or followed by .* is supported, found ".", use "\." to match a dot`)
+		}
+		if sub.Op != syntax.OpLiteral {
+			return regexMatch{}, fmt.Errorf("only a literal optionally preceded or followed by .* is supported, found %s", sub)
+		}
+		if sub.Flags&syntax.FoldCase != foldCase {
+			return regexMatch{}, errors.New("case sensitivity must be the same for the whole literal")
+		}
+		value.WriteString(string(sub.Rune))
+	}
+
+	match := regexMatch{
+		Value:         value.String(),
+		CaseSensitive: foldCase == 0,
+	}
+	if !match.CaseSensitive {
+		// the parser stores case folded literals in upper case
+		match.Value = strings.ToLower(match.Value)
+	}
+	switch {
+	case leadingAny && trailingAny:
+		match.Type = regexMatchContains
+	case trailingAny:
+		match.Type = regexMatchPrefix
+	case leadingAny:
+		return regexMatch{}, errors.New("suffix matches are not supported by VPC Lattice")
+	default:
+		match.Type = regexMatchExact
+	}
+	return match, nil
+}
+
+// .* matching any string
+func isAnyString(re *syntax.Regexp) bool {
+	return re.Op == syntax.OpStar && len(re.Sub) == 1 &&
+		(re.Sub[0].Op == syntax.OpAnyChar || re.Sub[0].Op == syntax.OpAnyCharNotNL)
+}
+
+func (m regexMatch) headerMatchType() *vpclattice.HeaderMatchType {
+	switch m.Type {
+	case regexMatchPrefix:
+		return &vpclattice.HeaderMatchType{Prefix: aws.String(m.Value)}
+	case regexMatchContains:
+		return &vpclattice.HeaderMatchType{Contains: aws.String(m.Value)}
+	default:
+		return &vpclattice.HeaderMatchType{Exact: aws.String(m.Value)}
+	}
+}
@@ -0,0 +1,45 @@
+package gateway
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_analyzeRegex(t *testing.T) {
+	tests := []struct {
+		expr        string
+		expected    regexMatch
+		errContains string
+	}{
+		{expr: "foo", expected: regexMatch{Type: regexMatchExact, Value: "foo", CaseSensitive: true}},
+		{expr: "^foo$", expected: regexMatch{Type: regexMatchExact, Value: "foo", CaseSensitive: true}},
+		{expr: `v1\.2`, expected: regexMatch{Type: regexMatchExact, Value: "v1.2", CaseSensitive: true}},
+		{expr: "^foo.*", expected: regexMatch{Type: regexMatchPrefix, Value: "foo", CaseSensitive: true}},
+		{expr: "foo.*$", expected: regexMatch{Type: regexMatchPrefix, Value: "foo", CaseSensitive: true}},
+		{expr: ".*bar.*", expected: regexMatch{Type: regexMatchContains, Value: "bar", CaseSensitive: true}},
+		{expr: "^(?s).*bar.*$", expected: regexMatch{Type: regexMatchContains, Value: "bar", CaseSensitive: true}},
+		{expr: "(?i)^Foo.*", expected: regexMatch{Type: regexMatchPrefix, Value: "foo", CaseSensitive: false}},
+		{expr: "(?i)b", expected: regexMatch{Type: regexMatchExact, Value: "b", CaseSensitive: false}},
+		{expr: ".*foo", errContains: "suffix"},
+		{expr: ".*", errContains: "no literal"},
+		{expr: "^$", errContains: "no literal"},
+		{expr: "foo|bar", errContains: "only a literal"},
+		{expr: "fo+", errContains: "only a literal"},
+		{expr: "foo.+", errContains: "only a literal"},
+		{expr: "com.example", errContains: `use "\." to match a dot`},
+		{expr: "foo(?i)bar", errContains: "case sensitivity"},
+		{expr: "foo(", errContains: "invalid regular expression"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			match, err := analyzeRegex(tt.expr)
+			if tt.errContains != "" {
+				assert.ErrorContains(t, err, tt.errContains)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, match)
+		})
+	}
+}
@@ -4,11 +4,17 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
 
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
 
 	anv1alpha1 "github.com/aws/aws-application-networking-k8s/pkg/apis/applicationnetworking/v1alpha1"
+	"github.com/aws/aws-application-networking-k8s/pkg/config"
+	"github.com/aws/aws-application-networking-k8s/pkg/k8s"
 	"github.com/aws/aws-application-networking-k8s/pkg/model/core"
 
 	"github.com/aws/aws-sdk-go/aws"
@@ -22,80 +28,295 @@ import (
 )
 
 const (
-	LATTICE_NO_SUPPORT_FOR_MULTIPLE_MATCHES = "LATTICE_NO_SUPPORT_FOR_MULTIPLE_MATCHES"
-	LATTICE_EXCEED_MAX_HEADER_MATCHES       = "LATTICE_EXCEED_MAX_HEADER_MATCHES"
-	LATTICE_UNSUPPORTED_MATCH_TYPE          = "LATTICE_UNSUPPORTED_MATCH_TYPE"
-	LATTICE_UNSUPPORTED_HEADER_MATCH_TYPE   = "LATTICE_UNSUPPORTED_HEADER_MATCH_TYPE"
-	LATTICE_UNSUPPORTED_PATH_MATCH_TYPE     = "LATTICE_UNSUPPORTED_PATH_MATCH_TYPE"
-	LATTICE_MAX_HEADER_MATCHES              = 5
+	LATTICE_EXCEED_MAX_RULES              = "LATTICE_EXCEED_MAX_RULES"
+	LATTICE_UNSUPPORTED_BACKEND_KIND      = "LATTICE_UNSUPPORTED_BACKEND_KIND"
+	LATTICE_EXCEED_MAX_HEADER_MATCHES     = "LATTICE_EXCEED_MAX_HEADER_MATCHES"
+	LATTICE_UNSUPPORTED_MATCH_TYPE        = "LATTICE_UNSUPPORTED_MATCH_TYPE"
+	LATTICE_UNSUPPORTED_HEADER_MATCH_TYPE = "LATTICE_UNSUPPORTED_HEADER_MATCH_TYPE"
+	LATTICE_UNSUPPORTED_PATH_MATCH_TYPE   = "LATTICE_UNSUPPORTED_PATH_MATCH_TYPE"
+	LATTICE_UNSUPPORTED_REGEX             = "LATTICE_UNSUPPORTED_REGEX"
+	LATTICE_MAX_HEADER_MATCHES            = 5
 )
 
+const (
+	// annotations of a ServiceImport narrowing it to the target groups exported from a cluster, VPC or account
+	ServiceImportClusterNameAnnotation = k8s.AnnotationPrefix + "aws-eks-cluster-name"
+	ServiceImportVpcAnnotation         = k8s.AnnotationPrefix + "aws-vpc"
+	ServiceImportAccountAnnotation     = k8s.AnnotationPrefix + "aws-account-id"
+)
+
+// NewSvcImportTargetGroup identifies the target groups exported for the ServiceImport of the given name,
+// narrowed to a cluster and VPC by the ServiceImport annotations
+func NewSvcImportTargetGroup(namespace, name string, annotations map[string]string) model.SvcImportTargetGroup {
+	return model.SvcImportTargetGroup{
+		K8SServiceNamespace: namespace,
+		K8SServiceName:      name,
+		K8SClusterName:      annotations[ServiceImportClusterNameAnnotation],
+		VpcId:               annotations[ServiceImportVpcAnnotation],
+	}
+}
+
+// IsOtherAccountServiceImport tells whether the ServiceImport imports the exports of another account. VPC Lattice
+// rules only forward to target groups of the account of the service, and AWS RAM does not share target groups.
+func IsOtherAccountServiceImport(annotations map[string]string) bool {
+	accountId := annotations[ServiceImportAccountAnnotation]
+	return accountId != "" && accountId != config.AccountID
+}
+
+// UnsupportedRouteError is returned by the model builder when a route uses a feature of the spec
+// which VPC Lattice cannot express. The route controller reports it as a route condition.
+type UnsupportedRouteError struct {
+	Reason  gwv1.RouteConditionReason
+	Message string
+}
+
+func (e *UnsupportedRouteError) Error() string {
+	return e.Message
+}
+
+// PartiallyInvalidRouteError is returned along with a usable stack when some of the route rules
+// cannot be built. Those rules are dropped from the stack, the valid ones can still be deployed.
+type PartiallyInvalidRouteError struct {
+	// indices of the dropped rules in the route spec, in ascending order
+	DroppedRules []int
+	Message      string
+}
+
+func (e *PartiallyInvalidRouteError) Error() string {
+	return e.Message
+}
+
+// returns nil if no rules were dropped, a PartiallyInvalidRouteError if some were,
+// and an UnsupportedRouteError if none of the route rules are left
+func newDroppedRulesError(ruleCount int, droppedRules map[int]*UnsupportedRouteError) error {
+	if len(droppedRules) == 0 {
+		return nil
+	}
+
+	var indices []int
+	for i := range droppedRules {
+		indices = append(indices, i)
+	}
+	sort.Ints(indices)
+
+	var idxStrs, msgs []string
+	for _, i := range indices {
+		idxStrs = append(idxStrs, strconv.Itoa(i))
+		msgs = append(msgs, fmt.Sprintf("rule %d: %s", i, droppedRules[i].Message))
+	}
+
+	if len(droppedRules) == ruleCount {
+		if ruleCount == 1 {
+			return droppedRules[indices[0]]
+		}
+		return &UnsupportedRouteError{
+			Reason:  droppedRules[indices[0]].Reason,
+			Message: strings.Join(msgs, "; "),
+		}
+	}
+
+	// message prefix is mandated by the gw spec for the PartiallyInvalid condition
+	return &PartiallyInvalidRouteError{
+		DroppedRules: indices,
+		Message:      fmt.Sprintf("Dropped Rule(s) %s: %s", strings.Join(idxStrs, ", "), strings.Join(msgs, "; ")),
+	}
+}
+
+// every rule error message is prefixed with its code, the rule index is added when the rule is dropped
+func newUnsupportedRouteError(reason gwv1.RouteConditionReason, code string, format string, args ...any) *UnsupportedRouteError {
+	return &UnsupportedRouteError{
+		Reason:  reason,
+		Message: fmt.Sprintf("%s: %s", code, fmt.Sprintf(format, args...)),
+	}
+}
+
+func newUnsupportedValueError(code string, format string, args ...any) *UnsupportedRouteError {
+	return newUnsupportedRouteError(gwv1.RouteReasonUnsupportedValue, code, format, args...)
+}
+
 func (t *latticeServiceModelBuildTask) buildRules(ctx context.Context, stackListenerId string) error {
+	specsByRule, droppedRules, err := t.buildRouteRuleSpecs(ctx, stackListenerId)
+	if err != nil {
+		return err
+	}
+
+	var builtSpecs []model.RuleSpec
+	for _, ruleSpecs := range specsByRule {
+		for _, ruleSpec := range ruleSpecs {
+			// lattice rules are identified by their match, so a match repeated within the route
+			// would end up on the same lattice rule. The first occurrence wins, as it would by precedence.
+			if indexOfRuleMatch(builtSpecs, ruleSpec) >= 0 {
+				t.log.Debugf("Skipping duplicate match for route %s-%s", t.route.Name(), t.route.Namespace())
+				continue
+			}
+			builtSpecs = append(builtSpecs, ruleSpec)
+		}
+	}
+
+	builtSpecs, err = t.buildListenerDefaultActionFromRules(stackListenerId, builtSpecs)
+	if err != nil {
+		return err
+	}
+	if len(builtSpecs) > model.MaxRulePriority {
+		return newUnsupportedValueError(LATTICE_EXCEED_MAX_RULES,
+			"route requires more than %d rules", model.MaxRulePriority)
+	}
+	if err := t.addRulesByPrecedence(builtSpecs); err != nil {
+		return err
+	}
+
+	return newDroppedRulesError(len(t.route.Spec().Rules()), droppedRules)
+}
+
+// builds the rule specs of every route rule, a route rule with multiple matches is fanned out into
+// one rule spec per match. Rules which cannot be built are returned as dropped rules instead.
+func (t *latticeServiceModelBuildTask) buildRouteRuleSpecs(ctx context.Context, stackListenerId string) (
+	[][]model.RuleSpec, map[int]*UnsupportedRouteError, error,
+) {
 	// note we only build rules for non-deleted routes
 	t.log.Debugf("Processing %d rules", len(t.route.Spec().Rules()))
-	for i, rule := range t.route.Spec().Rules() {
-		ruleSpec := model.RuleSpec{
-			StackListenerId: stackListenerId,
-			Priority:        int64(i + 1),
-		}
 
-		if len(rule.Matches()) > 1 {
-			// only support 1 match today
-			return errors.New(LATTICE_NO_SUPPORT_FOR_MULTIPLE_MATCHES)
-		} else if len(rule.Matches()) > 0 {
-			t.log.Debugf("Processing rule match")
-			match := rule.Matches()[0]
-
-			switch m := match.(type) {
-			case *core.HTTPRouteMatch:
-				if err := t.updateRuleSpecForHttpRoute(m, &ruleSpec); err != nil {
-					return err
-				}
-			case *core.GRPCRouteMatch:
-				if err := t.updateRuleSpecForGrpcRoute(m, &ruleSpec); err != nil {
-					return err
-				}
-			default:
-				return fmt.Errorf("unsupported rule match: %T", m)
-			}
+	specsByRule := make([][]model.RuleSpec, len(t.route.Spec().Rules()))
+	droppedRules := make(map[int]*UnsupportedRouteError)
+	if !t.route.DeletionTimestamp().IsZero() {
+		// don't bother adding rules on delete, these will be removed automatically with the owning route/lattice service
+		// target groups will still be present and removed as needed
+		t.log.Debugf("Skipping adding rules to the stack since the route is deleted")
+		return specsByRule, droppedRules, t.buildDeletedRouteTargetGroups(ctx)
+	}
 
-			if err := t.updateRuleSpecWithHeaderMatches(match, &ruleSpec); err != nil {
-				return err
+	for i, rule := range t.route.Spec().Rules() {
+		ruleSpecs, err := t.buildRuleSpecs(ctx, rule, stackListenerId)
+		if err != nil {
+			// an invalid rule is dropped, the remaining rules of the route are still deployed
+			var unsupportedErr *UnsupportedRouteError
+			if !errors.As(err, &unsupportedErr) {
+				return nil, nil, err
 			}
-		} else {
+			t.log.Infof("Dropping rule %d of route %s-%s due to %s", i, t.route.Name(), t.route.Namespace(), err)
+			droppedRules[i] = unsupportedErr
+			continue
+		}
+		specsByRule[i] = ruleSpecs
+	}
+	return specsByRule, droppedRules, nil
+}
 
-			// Match every traffic on no matches
-			ruleSpec.PathMatchValue = "/"
-			ruleSpec.PathMatchPrefix = true
-			if _, ok := rule.(*core.GRPCRouteRule); ok {
-				ruleSpec.Method = string(gwv1.HTTPMethodPost)
+// adds the target groups of a deleted route to the stack, they are deleted along with the route
+func (t *latticeServiceModelBuildTask) buildDeletedRouteTargetGroups(ctx context.Context) error {
+	for _, rule := range t.route.Spec().Rules() {
+		if _, err := t.getTargetGroupsForRuleAction(ctx, rule); err != nil {
+			var unsupportedErr *UnsupportedRouteError
+			if !errors.As(err, &unsupportedErr) {
+				return err
 			}
+		}
+	}
+	return nil
+}
 
+// adds the rules to the stack, with priorities assigned by match precedence
+func (t *latticeServiceModelBuildTask) addRulesByPrecedence(ruleSpecs []model.RuleSpec) error {
+	sortRuleSpecsByPrecedence(ruleSpecs)
+	for i, ruleSpec := range ruleSpecs {
+		ruleSpec.Priority = int64(i + 1)
+		stackRule, err := model.NewRule(t.stack, ruleSpec)
+		if err != nil {
+			return err
 		}
+		t.log.Debugf("Added rule %d to the stack (ID %s)", stackRule.Spec.Priority, stackRule.ID())
+	}
+	return nil
+}
 
+// builds the rule specs of a single route rule, including the action, but without priority
+func (t *latticeServiceModelBuildTask) buildRuleSpecs(ctx context.Context, rule core.RouteRule, stackListenerId string) ([]model.RuleSpec, error) {
+	ruleSpecs, err := t.buildRuleSpecsForMatches(rule, stackListenerId)
+	if err != nil {
+		return nil, err
+	}
+
+	filterAction, err := t.buildRuleFilterAction(ctx, rule)
+	if err != nil {
+		return nil, err
+	}
+
+	ruleAction := model.RuleAction{}
+	if filterAction != nil {
+		// the filter determines the response, backendRefs are not used by this rule
+		ruleAction = *filterAction
+	} else {
 		ruleTgList, err := t.getTargetGroupsForRuleAction(ctx, rule)
 		if err != nil {
-			return err
+			return nil, err
 		}
+		ruleAction.TargetGroups = ruleTgList
+	}
 
-		ruleSpec.Action = model.RuleAction{
-			TargetGroups: ruleTgList,
+	for i := range ruleSpecs {
+		ruleSpecs[i].Action = ruleAction
+	}
+	return ruleSpecs, nil
+}
+
+// builds one rule spec per route rule match, without priority and action. Matches are ORed together
+// by the gateway spec, so each becomes its own lattice rule.
+func (t *latticeServiceModelBuildTask) buildRuleSpecsForMatches(rule core.RouteRule, stackListenerId string) ([]model.RuleSpec, error) {
+	if len(rule.Matches()) == 0 {
+		// Match every traffic on no matches
+		ruleSpec := model.RuleSpec{
+			StackListenerId: stackListenerId,
+			PathMatchValue:  "/",
+			PathMatchPrefix: true,
+		}
+		if _, ok := rule.(*core.GRPCRouteRule); ok {
+			ruleSpec.Method = string(gwv1.HTTPMethodPost)
 		}
+		return []model.RuleSpec{ruleSpec}, nil
+	}
 
-		// don't bother adding rules on delete, these will be removed automatically with the owning route/lattice service
-		// target groups will still be present and removed as needed
-		if t.route.DeletionTimestamp().IsZero() {
-			stackRule, err := model.NewRule(t.stack, ruleSpec)
-			if err != nil {
-				return err
+	var ruleSpecs []model.RuleSpec
+	for _, match := range rule.Matches() {
+		t.log.Debugf("Processing rule match")
+		ruleSpec := model.RuleSpec{
+			StackListenerId: stackListenerId,
+		}
+
+		switch m := match.(type) {
+		case *core.HTTPRouteMatch:
+			if err := t.updateRuleSpecForHttpRoute(m, &ruleSpec); err != nil {
+				return nil, err
 			}
-			t.log.Debugf("Added rule %d to the stack (ID %s)", stackRule.Spec.Priority, stackRule.ID())
-		} else {
-			t.log.Debugf("Skipping adding rule %d to the stack since the route is deleted", ruleSpec.Priority)
+		case *core.GRPCRouteMatch:
+			if err := t.updateRuleSpecForGrpcRoute(m, &ruleSpec); err != nil {
+				return nil, err
+			}
+		default:
+			return nil, fmt.Errorf("unsupported rule match: %T", m)
+		}
+
+		if err := t.updateRuleSpecWithHeaderMatches(match, &ruleSpec); err != nil {
+			return nil, err
 		}
+
+		ruleSpecs = append(ruleSpecs, ruleSpec)
 	}
 
-	return nil
+	return ruleSpecs, nil
+}
+
+// returns the index of the rule spec with the same match, -1 if there is none
+func indexOfRuleMatch(ruleSpecs []model.RuleSpec, ruleSpec model.RuleSpec) int {
+	for i, existing := range ruleSpecs {
+		if existing.PathMatchValue == ruleSpec.PathMatchValue &&
+			existing.PathMatchExact == ruleSpec.PathMatchExact &&
+			existing.PathMatchPrefix == ruleSpec.PathMatchPrefix &&
+			existing.Method == ruleSpec.Method &&
+			reflect.DeepEqual(existing.MatchedHeaders, ruleSpec.MatchedHeaders) {
+			return i
+		}
+	}
+	return -1
 }
 
 func (t *latticeServiceModelBuildTask) updateRuleSpecForHttpRoute(m *core.HTTPRouteMatch, ruleSpec *model.RuleSpec) error {
@@ -123,7 +344,8 @@ func (t *latticeServiceModelBuildTask) updateRuleSpecForHttpRoute(m *core.HTTPRo
 		default:
 			t.log.Debugf("Unsupported path match type %s for httproute %s-%s",
 				*m.Path().Type, t.route.Name(), t.route.Namespace())
-			return errors.New(LATTICE_UNSUPPORTED_PATH_MATCH_TYPE)
+			return newUnsupportedValueError(LATTICE_UNSUPPORTED_PATH_MATCH_TYPE,
+				"path match type %s is not supported", *m.Path().Type)
 		}
 		ruleSpec.PathMatchValue = *m.Path().Value
 	}
@@ -136,11 +358,17 @@ func (t *latticeServiceModelBuildTask) updateRuleSpecForHttpRoute(m *core.HTTPRo
 		ruleSpec.Method = string(*m.Method())
 	}
 
-	// controller does not support query matcher type today
-	if m.QueryParams() != nil {
-		t.log.Infof("Unsupported match type for httproute %s, namespace %s",
+	// VPC Lattice rules can only match on path, method and headers, there is no primitive
+	// a query parameter match can be translated into without changing its semantics
+	if len(m.QueryParams()) > 0 {
+		t.log.Infof("Unsupported query parameter match for httproute %s, namespace %s",
 			t.route.Name(), t.route.Namespace())
-		return errors.New(LATTICE_UNSUPPORTED_MATCH_TYPE)
+		var names []string
+		for _, qp := range m.QueryParams() {
+			names = append(names, string(qp.Name))
+		}
+		return newUnsupportedValueError(LATTICE_UNSUPPORTED_MATCH_TYPE,
+			"query parameter matches are not supported by VPC Lattice rules, found %s", strings.Join(names, ", "))
 	}
 	return nil
 }
@@ -151,7 +379,8 @@ func (t *latticeServiceModelBuildTask) updateRuleSpecForGrpcRoute(m *core.GRPCRo
 	method := m.Method()
 	// VPC Lattice doesn't support suffix/regex matching, so we can't support method match without service
 	if method.Service == nil && method.Method != nil {
-		return fmt.Errorf("cannot create GRPCRouteMatch for nil service and non-nil method")
+		return newUnsupportedValueError(LATTICE_UNSUPPORTED_MATCH_TYPE,
+			"gRPC method match requires a service when a method is set")
 	}
 	switch *method.Type {
 	case gwv1alpha2.GRPCMethodMatchExact:
@@ -168,9 +397,74 @@ func (t *latticeServiceModelBuildTask) updateRuleSpecForGrpcRoute(m *core.GRPCRo
 			ruleSpec.PathMatchExact = true
 			ruleSpec.PathMatchValue = fmt.Sprintf("/%s/%s", *method.Service, *method.Method)
 		}
+	case gwv1alpha2.GRPCMethodMatchRegularExpression:
+		return t.updateRuleSpecForGrpcRegexMethod(method, ruleSpec)
+	default:
+		return newUnsupportedValueError(LATTICE_UNSUPPORTED_MATCH_TYPE,
+			"gRPC method match type %s is not supported", *method.Type)
+	}
+	return nil
+}
+
+// gRPC requests have the /service/method path, the service and method expressions are translated
+// into a single case sensitive path match
+func (t *latticeServiceModelBuildTask) updateRuleSpecForGrpcRegexMethod(method *gwv1alpha2.GRPCMethodMatch, ruleSpec *model.RuleSpec) error {
+	if method.Service == nil {
+		t.log.Debugf("Match all paths due to nil service and nil method")
+		ruleSpec.PathMatchPrefix = true
+		ruleSpec.PathMatchValue = "/"
+		return nil
+	}
+
+	service, err := analyzeRegex(*method.Service)
+	if err != nil {
+		return newUnsupportedValueError(LATTICE_UNSUPPORTED_REGEX,
+			"gRPC service regular expression %q cannot be translated: %s", *method.Service, err)
+	}
+	if !service.CaseSensitive {
+		return newUnsupportedValueError(LATTICE_UNSUPPORTED_REGEX,
+			"gRPC service regular expression %q cannot be translated: VPC Lattice path matches are case sensitive", *method.Service)
+	}
+
+	if method.Method == nil {
+		switch service.Type {
+		case regexMatchExact:
+			ruleSpec.PathMatchValue = fmt.Sprintf("/%s/", service.Value)
+		case regexMatchPrefix:
+			ruleSpec.PathMatchValue = fmt.Sprintf("/%s", service.Value)
+		default:
+			return newUnsupportedValueError(LATTICE_UNSUPPORTED_REGEX,
+				"gRPC service regular expression %q cannot be translated: VPC Lattice path matches only support exact and prefix matches", *method.Service)
+		}
+		t.log.Debugf("Match by gRPC service regular expression %s, regardless of method", *method.Service)
+		ruleSpec.PathMatchPrefix = true
+		return nil
+	}
+
+	if service.Type != regexMatchExact {
+		return newUnsupportedValueError(LATTICE_UNSUPPORTED_REGEX,
+			"gRPC service regular expression %q cannot be translated: a method match requires an exact service", *method.Service)
+	}
+	m, err := analyzeRegex(*method.Method)
+	if err != nil {
+		return newUnsupportedValueError(LATTICE_UNSUPPORTED_REGEX,
+			"gRPC method regular expression %q cannot be translated: %s", *method.Method, err)
+	}
+	if !m.CaseSensitive {
+		return newUnsupportedValueError(LATTICE_UNSUPPORTED_REGEX,
+			"gRPC method regular expression %q cannot be translated: VPC Lattice path matches are case sensitive", *method.Method)
+	}
+	switch m.Type {
+	case regexMatchExact:
+		ruleSpec.PathMatchExact = true
+	case regexMatchPrefix:
+		ruleSpec.PathMatchPrefix = true
 	default:
-		return fmt.Errorf("unsupported gRPC method match type %s", *method.Type)
+		return newUnsupportedValueError(LATTICE_UNSUPPORTED_REGEX,
+			"gRPC method regular expression %q cannot be translated: VPC Lattice path matches only support exact and prefix matches", *method.Method)
 	}
+	t.log.Debugf("Match by gRPC service %s and method regular expression %s", service.Value, *method.Method)
+	ruleSpec.PathMatchValue = fmt.Sprintf("/%s/%s", service.Value, m.Value)
 	return nil
 }
 
@@ -180,27 +474,38 @@ func (t *latticeServiceModelBuildTask) updateRuleSpecWithHeaderMatches(match cor
 	}
 
 	if len(match.Headers()) > LATTICE_MAX_HEADER_MATCHES {
-		return errors.New(LATTICE_EXCEED_MAX_HEADER_MATCHES)
+		return newUnsupportedValueError(LATTICE_EXCEED_MAX_HEADER_MATCHES,
+			"at most %d header matches are supported per match, found %d", LATTICE_MAX_HEADER_MATCHES, len(match.Headers()))
 	}
 
 	t.log.Debugf("Examining match headers for route %s-%s", t.route.Name(), t.route.Namespace())
 
 	for _, header := range match.Headers() {
-		t.log.Debugf("Examining match.Header: header.Type %s", *header.Type())
-		if header.Type() != nil && *header.Type() != gwv1.HeaderMatchExact {
-			t.log.Debugf("Unsupported header matchtype %s for httproute %s-%s",
-				*header.Type(), t.route.Name(), t.route.Namespace())
-			return errors.New(LATTICE_UNSUPPORTED_HEADER_MATCH_TYPE)
+		headerName := header.Name()
+		headerMatch := vpclattice.HeaderMatch{
+			Name: &headerName,
 		}
 
-		matchType := vpclattice.HeaderMatchType{
-			Exact: aws.String(header.Value()),
+		switch {
+		case header.Type() == nil || *header.Type() == gwv1.HeaderMatchExact:
+			headerMatch.Match = &vpclattice.HeaderMatchType{
+				Exact: aws.String(header.Value()),
+			}
+		case *header.Type() == gwv1.HeaderMatchRegularExpression:
+			regex, err := analyzeRegex(header.Value())
+			if err != nil {
+				return newUnsupportedValueError(LATTICE_UNSUPPORTED_REGEX,
+					"regular expression %q on header %s cannot be translated: %s", header.Value(), headerName, err)
+			}
+			t.log.Debugf("Using %s header match for regular expression %s on header %s", regex.Type, header.Value(), headerName)
+			headerMatch.Match = regex.headerMatchType()
+			headerMatch.CaseSensitive = aws.Bool(regex.CaseSensitive)
+		default:
+			t.log.Debugf("Unsupported header matchtype %s for httproute %s-%s",
+				*header.Type(), t.route.Name(), t.route.Namespace())
+			return newUnsupportedValueError(LATTICE_UNSUPPORTED_HEADER_MATCH_TYPE,
+				"header match type %s on header %s is not supported", *header.Type(), header.Name())
 		}
-		headerName := header.Name()
-
-		headerMatch := vpclattice.HeaderMatch{}
-		headerMatch.Match = &matchType
-		headerMatch.Name = &headerName
 
 		ruleSpec.MatchedHeaders = append(ruleSpec.MatchedHeaders, headerMatch)
 	}
@@ -226,15 +531,32 @@ func (t *latticeServiceModelBuildTask) getTargetGroupsForRuleAction(ctx context.
 
 		t.log.Debugf("Processing %s backendRef %s-%s", string(*backendRef.Kind()), backendRef.Name(), namespace)
 
-		if string(*backendRef.Kind()) == "ServiceImport" {
-			// there needs to be a pre-existing target group, we fetch all the fields
-			// needed to identify it
-			svcImportTg := model.SvcImportTargetGroup{
-				K8SServiceNamespace: namespace,
-				K8SServiceName:      string(backendRef.Name()),
+		kind := string(*backendRef.Kind())
+		if kind != "Service" && kind != "ServiceImport" {
+			return nil, newUnsupportedValueError(LATTICE_UNSUPPORTED_BACKEND_KIND,
+				"backendRef %s has unsupported kind %s", backendRef.Name(), kind)
+		}
+
+		permitted := true
+		if t.route.DeletionTimestamp().IsZero() {
+			var err error
+			permitted, err = IsBackendRefPermitted(ctx, t.client, t.route, backendRef)
+			if err != nil {
+				return nil, err
 			}
+		}
+		if !permitted {
+			// requests routed to a backendRef no ReferenceGrant allows fail as for any invalid backendRef
+			t.log.Infof("backendRef %s-%s on route %s is not permitted by any ReferenceGrant",
+				backendRef.Name(), namespace, t.route.Name())
+			ruleTG.StackTargetGroupId = model.InvalidBackendRefTgId
+			tgList = append(tgList, &ruleTG)
+			continue
+		}
 
-			// if there's a matching top-level service import, we can get additional fields
+		if string(*backendRef.Kind()) == "ServiceImport" {
+			// there needs to be a pre-existing target group, we fetch all the fields
+			// needed to identify it, a matching top-level service import gives additional fields
 			svcImportName := types.NamespacedName{
 				Namespace: namespace,
 				Name:      string(backendRef.Name()),
@@ -245,14 +567,16 @@ func (t *latticeServiceModelBuildTask) getTargetGroupsForRuleAction(ctx context.
 					return nil, err
 				}
 			}
-			vpc, ok := svcImport.Annotations["application-networking.k8s.aws/aws-vpc"]
-			if ok {
-				svcImportTg.VpcId = vpc
+			if IsOtherAccountServiceImport(svcImport.Annotations) {
+				t.log.Infof("backendRef %s-%s on route %s imports from account %s, its target groups cannot be used",
+					backendRef.Name(), namespace, t.route.Name(), svcImport.Annotations[ServiceImportAccountAnnotation])
+				ruleTG.StackTargetGroupId = model.InvalidBackendRefTgId
+				tgList = append(tgList, &ruleTG)
+				continue
 			}
-
-			eksCluster, ok := svcImport.Annotations["application-networking.k8s.aws/aws-eks-cluster-name"]
-			if ok {
-				svcImportTg.K8SClusterName = eksCluster
+			svcImportTg := NewSvcImportTargetGroup(svcImportName.Namespace, svcImportName.Name, svcImport.Annotations)
+			if backendRef.Port() != nil {
+				svcImportTg.K8SServicePort = int32(*backendRef.Port())
 			}
 			ruleTG.SvcImportTG = &svcImportTg
 		}
@@ -0,0 +1,58 @@
+package gateway
+
+import (
+	"sort"
+	"strings"
+
+	model "github.com/aws/aws-application-networking-k8s/pkg/model/lattice"
+)
+
+// sortRuleSpecsByPrecedence orders rule specs the way the Gateway API ranks matches, lattice rule
+// priorities are then assigned in this order. A rule spec comes first when it has:
+//
+//  1. an exact path match
+//  2. a prefix path match with the most characters
+//  3. a method match
+//  4. the most header matches
+//
+// Query parameter matches are not supported by VPC Lattice, so they are not ranked. gRPC service and
+// method matches are built as path matches, where a longer service or a method makes the path
+// longer or exact, so the same order applies to them.
+//
+// Rule specs of equal precedence with different paths or methods never match the same request, they
+// are ordered by path and method so that reordering them in the route does not change their lattice
+// priorities. The remaining ties keep the declaration order, the first matching rule wins.
+func sortRuleSpecsByPrecedence(ruleSpecs []model.RuleSpec) {
+	sort.SliceStable(ruleSpecs, func(i, j int) bool {
+		return comparePrecedence(ruleSpecs[i], ruleSpecs[j]) < 0
+	})
+}
+
+// returns a negative number when a ranks before b, a positive one when b ranks before a,
+// and 0 when their order is the declaration order
+func comparePrecedence(a, b model.RuleSpec) int {
+	if a.PathMatchExact != b.PathMatchExact {
+		if a.PathMatchExact {
+			return -1
+		}
+		return 1
+	}
+	if !a.PathMatchExact && len(a.PathMatchValue) != len(b.PathMatchValue) {
+		return len(b.PathMatchValue) - len(a.PathMatchValue)
+	}
+	if (a.Method != "") != (b.Method != "") {
+		if a.Method != "" {
+			return -1
+		}
+		return 1
+	}
+	if len(a.MatchedHeaders) != len(b.MatchedHeaders) {
+		return len(b.MatchedHeaders) - len(a.MatchedHeaders)
+	}
+
+	// same precedence, the rule specs only overlap when their paths and methods are the same
+	if c := strings.Compare(a.PathMatchValue, b.PathMatchValue); c != 0 {
+		return c
+	}
+	return strings.Compare(a.Method, b.Method)
+}
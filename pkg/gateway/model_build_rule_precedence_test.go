@@ -0,0 +1,131 @@
+package gateway
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/vpclattice"
+	"github.com/stretchr/testify/assert"
+	apimachineryv1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gwv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/aws/aws-application-networking-k8s/pkg/model/core"
+	model "github.com/aws/aws-application-networking-k8s/pkg/model/lattice"
+	"github.com/aws/aws-application-networking-k8s/pkg/utils/gwlog"
+)
+
+func Test_sortRuleSpecsByPrecedence(t *testing.T) {
+	header := func(name string) vpclattice.HeaderMatch {
+		return vpclattice.HeaderMatch{Name: aws.String(name), Match: &vpclattice.HeaderMatchType{Exact: aws.String("v")}}
+	}
+	root := model.RuleSpec{PathMatchPrefix: true, PathMatchValue: "/"}
+	api := model.RuleSpec{PathMatchPrefix: true, PathMatchValue: "/api"}
+	apiV1 := model.RuleSpec{PathMatchPrefix: true, PathMatchValue: "/api/v1"}
+	exactHealth := model.RuleSpec{PathMatchExact: true, PathMatchValue: "/health"}
+	exactA := model.RuleSpec{PathMatchExact: true, PathMatchValue: "/a"}
+	apiGet := model.RuleSpec{PathMatchPrefix: true, PathMatchValue: "/api", Method: "GET"}
+	apiPost := model.RuleSpec{PathMatchPrefix: true, PathMatchValue: "/api", Method: "POST"}
+	apiOneHeader := model.RuleSpec{PathMatchPrefix: true, PathMatchValue: "/api",
+		MatchedHeaders: []vpclattice.HeaderMatch{header("x")}}
+	apiOtherHeader := model.RuleSpec{PathMatchPrefix: true, PathMatchValue: "/api",
+		MatchedHeaders: []vpclattice.HeaderMatch{header("y")}}
+	apiTwoHeaders := model.RuleSpec{PathMatchPrefix: true, PathMatchValue: "/api",
+		MatchedHeaders: []vpclattice.HeaderMatch{header("x"), header("y")}}
+
+	tests := []struct {
+		name     string
+		specs    []model.RuleSpec
+		expected []model.RuleSpec
+	}{
+		{
+			name:     "longest prefix first",
+			specs:    []model.RuleSpec{root, api, apiV1},
+			expected: []model.RuleSpec{apiV1, api, root},
+		},
+		{
+			name:     "exact before any prefix",
+			specs:    []model.RuleSpec{apiV1, exactA},
+			expected: []model.RuleSpec{exactA, apiV1},
+		},
+		{
+			name:     "method before header count",
+			specs:    []model.RuleSpec{apiTwoHeaders, api, apiGet},
+			expected: []model.RuleSpec{apiGet, apiTwoHeaders, api},
+		},
+		{
+			name:     "most headers first",
+			specs:    []model.RuleSpec{apiOneHeader, apiTwoHeaders},
+			expected: []model.RuleSpec{apiTwoHeaders, apiOneHeader},
+		},
+		{
+			name:     "disjoint exact paths are ordered by path",
+			specs:    []model.RuleSpec{exactHealth, exactA},
+			expected: []model.RuleSpec{exactA, exactHealth},
+		},
+		{
+			name:     "disjoint methods are ordered by method",
+			specs:    []model.RuleSpec{apiPost, apiGet},
+			expected: []model.RuleSpec{apiGet, apiPost},
+		},
+		{
+			name:     "overlapping ties keep the declaration order",
+			specs:    []model.RuleSpec{apiOtherHeader, apiOneHeader},
+			expected: []model.RuleSpec{apiOtherHeader, apiOneHeader},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sortRuleSpecsByPrecedence(tt.specs)
+			assert.Equal(t, tt.expected, tt.specs)
+		})
+	}
+}
+
+func Test_RuleModelBuild_PriorityByPrecedence(t *testing.T) {
+	var serviceKind gwv1beta1.Kind = "Service"
+	pathPrefix := gwv1.PathMatchPathPrefix
+	pathExact := gwv1.PathMatchExact
+
+	rule := func(matchType *gwv1.PathMatchType, path string) gwv1beta1.HTTPRouteRule {
+		return gwv1beta1.HTTPRouteRule{
+			Matches: []gwv1beta1.HTTPRouteMatch{{
+				Path: &gwv1beta1.HTTPPathMatch{Type: matchType, Value: aws.String(path)},
+			}},
+			BackendRefs: []gwv1beta1.HTTPBackendRef{{
+				BackendRef: gwv1beta1.BackendRef{
+					BackendObjectReference: gwv1beta1.BackendObjectReference{Name: "svc", Kind: &serviceKind},
+				},
+			}},
+		}
+	}
+	build := func(rules ...gwv1beta1.HTTPRouteRule) map[string]int64 {
+		route := core.NewHTTPRoute(gwv1beta1.HTTPRoute{
+			ObjectMeta: apimachineryv1.ObjectMeta{Name: "route", Namespace: "default"},
+			Spec:       gwv1beta1.HTTPRouteSpec{Rules: rules},
+		})
+		stack := core.NewDefaultStack(core.StackID{Name: "route", Namespace: "default"})
+		task := &latticeServiceModelBuildTask{
+			log:         gwlog.FallbackLogger,
+			route:       route,
+			stack:       stack,
+			brTgBuilder: &dummyTgBuilder{},
+		}
+		assert.NoError(t, task.buildRules(context.TODO(), "listener-id"))
+
+		var stackRules []*model.Rule
+		assert.NoError(t, stack.ListResources(&stackRules))
+		priorities := make(map[string]int64)
+		for _, r := range stackRules {
+			priorities[r.Spec.PathMatchValue] = r.Spec.Priority
+		}
+		return priorities
+	}
+
+	// "/api" listed first must not shadow "/api/v1"
+	expected := map[string]int64{"/health": 1, "/api/v1": 2, "/api": 3}
+	assert.Equal(t, expected, build(rule(&pathPrefix, "/api"), rule(&pathPrefix, "/api/v1"), rule(&pathExact, "/health")))
+	// reordering the route rules keeps the priorities
+	assert.Equal(t, expected, build(rule(&pathExact, "/health"), rule(&pathPrefix, "/api/v1"), rule(&pathPrefix, "/api")))
+}
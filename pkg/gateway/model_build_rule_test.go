@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/aws/aws-sdk-go/aws"
@@ -22,6 +23,7 @@ import (
 	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
 
 	anv1alpha1 "github.com/aws/aws-application-networking-k8s/pkg/apis/applicationnetworking/v1alpha1"
+	"github.com/aws/aws-application-networking-k8s/pkg/config"
 	"github.com/aws/aws-application-networking-k8s/pkg/k8s"
 	"github.com/aws/aws-application-networking-k8s/pkg/model/core"
 	model "github.com/aws/aws-application-networking-k8s/pkg/model/lattice"
@@ -116,12 +118,22 @@ func Test_RuleModelBuild(t *testing.T) {
 			Kind: &serviceImportKind,
 		},
 	}
+	var grpcPort gwv1beta1.PortNumber = 50051
+	var backendServiceImportPortRef = gwv1beta1.BackendRef{
+		BackendObjectReference: gwv1beta1.BackendObjectReference{
+			Name: "targetgroup1",
+			Kind: &serviceImportKind,
+			Port: &grpcPort,
+		},
+	}
 
 	tests := []struct {
-		name         string
-		route        core.Route
-		wantErrIsNil bool
-		expectedSpec []model.RuleSpec
+		name                  string
+		route                 core.Route
+		referenceGrants       []gwv1beta1.ReferenceGrant
+		wantErrIsNil          bool
+		expectedSpec          []model.RuleSpec
+		expectedDefaultAction *model.DefaultAction
 	}{
 		{
 			name:         "rule, default service action",
@@ -151,17 +163,13 @@ func Test_RuleModelBuild(t *testing.T) {
 					},
 				},
 			}),
-			expectedSpec: []model.RuleSpec{ // note priority is only calculated at synthesis b/c it requires access to existing rules
-				{
-					StackListenerId: "listener-id",
-					PathMatchPrefix: true,
-					PathMatchValue:  "/",
-					Action: model.RuleAction{
-						TargetGroups: []*model.RuleTargetGroup{
-							{
-								StackTargetGroupId: "tg-0",
-								Weight:             int64(weight1),
-							},
+			// a rule without matches is a catch-all rule, it becomes the listener default action
+			expectedDefaultAction: &model.DefaultAction{
+				Forward: &model.RuleAction{
+					TargetGroups: []*model.RuleTargetGroup{
+						{
+							StackTargetGroupId: "tg-0",
+							Weight:             int64(weight1),
 						},
 					},
 				},
@@ -195,20 +203,15 @@ func Test_RuleModelBuild(t *testing.T) {
 					},
 				},
 			}),
-			expectedSpec: []model.RuleSpec{
-				{
-					StackListenerId: "listener-id",
-					PathMatchPrefix: true,
-					PathMatchValue:  "/",
-					Action: model.RuleAction{
-						TargetGroups: []*model.RuleTargetGroup{
-							{
-								SvcImportTG: &model.SvcImportTargetGroup{
-									K8SServiceName:      string(backendServiceImportRef.Name),
-									K8SServiceNamespace: "default",
-								},
-								Weight: 1,
+			expectedDefaultAction: &model.DefaultAction{
+				Forward: &model.RuleAction{
+					TargetGroups: []*model.RuleTargetGroup{
+						{
+							SvcImportTG: &model.SvcImportTargetGroup{
+								K8SServiceName:      string(backendServiceImportRef.Name),
+								K8SServiceNamespace: "default",
 							},
+							Weight: 1,
 						},
 					},
 				},
@@ -245,24 +248,19 @@ func Test_RuleModelBuild(t *testing.T) {
 					},
 				},
 			}),
-			expectedSpec: []model.RuleSpec{
-				{
-					StackListenerId: "listener-id",
-					PathMatchPrefix: true,
-					PathMatchValue:  "/",
-					Action: model.RuleAction{
-						TargetGroups: []*model.RuleTargetGroup{
-							{
-								StackTargetGroupId: "tg-0",
-								Weight:             int64(weight1),
-							},
-							{
-								SvcImportTG: &model.SvcImportTargetGroup{
-									K8SServiceName:      string(backendRef2.Name),
-									K8SServiceNamespace: "default",
-								},
-								Weight: int64(weight2),
+			expectedDefaultAction: &model.DefaultAction{
+				Forward: &model.RuleAction{
+					TargetGroups: []*model.RuleTargetGroup{
+						{
+							StackTargetGroupId: "tg-0",
+							Weight:             int64(weight1),
+						},
+						{
+							SvcImportTG: &model.SvcImportTargetGroup{
+								K8SServiceName:      string(backendRef2.Name),
+								K8SServiceNamespace: "default",
 							},
+							Weight: int64(weight2),
 						},
 					},
 				},
@@ -429,6 +427,29 @@ func Test_RuleModelBuild(t *testing.T) {
 		{
 			name:         "rule, different namespace combination",
 			wantErrIsNil: true,
+			referenceGrants: []gwv1beta1.ReferenceGrant{
+				{
+					ObjectMeta: apimachineryv1.ObjectMeta{
+						Name:      "allow-non-default",
+						Namespace: string(namespace),
+					},
+					Spec: gwv1beta1.ReferenceGrantSpec{
+						From: []gwv1beta1.ReferenceGrantFrom{
+							{
+								Group:     gwv1beta1.GroupName,
+								Kind:      "HTTPRoute",
+								Namespace: "non-default",
+							},
+						},
+						To: []gwv1beta1.ReferenceGrantTo{
+							{
+								Group: anv1alpha1.GroupName,
+								Kind:  gwv1beta1.Kind(serviceImportKind),
+							},
+						},
+					},
+				},
+			},
 			route: core.NewHTTPRoute(gwv1beta1.HTTPRoute{
 				ObjectMeta: apimachineryv1.ObjectMeta{
 					Name:      "service1",
@@ -529,11 +550,9 @@ func Test_RuleModelBuild(t *testing.T) {
 					Action: model.RuleAction{
 						TargetGroups: []*model.RuleTargetGroup{
 							{
-								SvcImportTG: &model.SvcImportTargetGroup{
-									K8SServiceName:      string(backendRef1Namespace2.Name),
-									K8SServiceNamespace: string(*backendRef1Namespace2.Namespace),
-								},
-								Weight: int64(weight2),
+								// no ReferenceGrant in namespace2
+								StackTargetGroupId: model.InvalidBackendRefTgId,
+								Weight:             int64(weight2),
 							},
 						},
 					},
@@ -588,9 +607,103 @@ func Test_RuleModelBuild(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:         "rule, service import port for GRPCRoute",
+			wantErrIsNil: true,
+			route: core.NewGRPCRoute(gwv1alpha2.GRPCRoute{
+				ObjectMeta: apimachineryv1.ObjectMeta{
+					Name:      "service1",
+					Namespace: "default",
+				},
+				Spec: gwv1alpha2.GRPCRouteSpec{
+					CommonRouteSpec: gwv1alpha2.CommonRouteSpec{
+						ParentRefs: []gwv1alpha2.ParentReference{
+							{
+								Name:        "gw1",
+								SectionName: &httpSectionName,
+							},
+						},
+					},
+					Rules: []gwv1alpha2.GRPCRouteRule{
+						{
+							BackendRefs: []gwv1alpha2.GRPCBackendRef{
+								{
+									BackendRef: backendServiceImportPortRef,
+								},
+							},
+						},
+					},
+				},
+			}),
+			expectedSpec: []model.RuleSpec{
+				{
+					StackListenerId: "listener-id",
+					Method:          string(httpPost),
+					PathMatchPrefix: true,
+					PathMatchValue:  "/",
+					Action: model.RuleAction{
+						TargetGroups: []*model.RuleTargetGroup{
+							{
+								SvcImportTG: &model.SvcImportTargetGroup{
+									K8SServiceName:      string(backendServiceImportPortRef.Name),
+									K8SServiceNamespace: "default",
+									K8SServicePort:      50051,
+								},
+								Weight: 1,
+							},
+						},
+					},
+				},
+			},
+		},
 		{
 			name:         "rule, gRPC routes with methods and multiple namespaces",
 			wantErrIsNil: true,
+			referenceGrants: []gwv1beta1.ReferenceGrant{
+				{
+					ObjectMeta: apimachineryv1.ObjectMeta{
+						Name:      "allow-grpc-routes",
+						Namespace: string(namespace),
+					},
+					Spec: gwv1beta1.ReferenceGrantSpec{
+						From: []gwv1beta1.ReferenceGrantFrom{
+							{
+								Group:     gwv1beta1.GroupName,
+								Kind:      "GRPCRoute",
+								Namespace: "non-default",
+							},
+						},
+						To: []gwv1beta1.ReferenceGrantTo{
+							{
+								Group: anv1alpha1.GroupName,
+								Kind:  gwv1beta1.Kind(serviceImportKind),
+							},
+						},
+					},
+				},
+				{
+					ObjectMeta: apimachineryv1.ObjectMeta{
+						Name:      "allow-targetgroup2",
+						Namespace: string(namespace2),
+					},
+					Spec: gwv1beta1.ReferenceGrantSpec{
+						From: []gwv1beta1.ReferenceGrantFrom{
+							{
+								Group:     gwv1beta1.GroupName,
+								Kind:      "GRPCRoute",
+								Namespace: "non-default",
+							},
+						},
+						To: []gwv1beta1.ReferenceGrantTo{
+							{
+								Group: anv1alpha1.GroupName,
+								Kind:  gwv1beta1.Kind(serviceImportKind),
+								Name:  (*gwv1beta1.ObjectName)(&backendRef1Namespace2.Name),
+							},
+						},
+					},
+				},
+			},
 			route: core.NewGRPCRoute(gwv1alpha2.GRPCRoute{
 				ObjectMeta: apimachineryv1.ObjectMeta{
 					Name:      "service1",
@@ -1070,8 +1183,114 @@ func Test_RuleModelBuild(t *testing.T) {
 			}),
 		},
 		{
-			name:         "Negative, multiple methods",
-			wantErrIsNil: false,
+			name:         "multiple matches",
+			wantErrIsNil: true,
+			route: core.NewHTTPRoute(gwv1beta1.HTTPRoute{
+				ObjectMeta: apimachineryv1.ObjectMeta{
+					Name:      "service1",
+					Namespace: "default",
+				},
+				Spec: gwv1beta1.HTTPRouteSpec{
+					CommonRouteSpec: gwv1beta1.CommonRouteSpec{
+						ParentRefs: []gwv1beta1.ParentReference{
+							{
+								Name:        "gw1",
+								SectionName: &httpSectionName,
+							},
+						},
+					},
+					Rules: []gwv1beta1.HTTPRouteRule{
+						{
+							Matches: []gwv1beta1.HTTPRouteMatch{
+								{
+
+									Path: &gwv1beta1.HTTPPathMatch{
+										Type:  &k8sPathMatchExactType,
+										Value: &path1,
+									},
+								},
+								{
+
+									Path: &gwv1beta1.HTTPPathMatch{
+										Type:  &k8sPathMatchPrefix,
+										Value: &path2,
+									},
+									Method: &httpGet,
+								},
+							},
+							BackendRefs: []gwv1beta1.HTTPBackendRef{
+								{
+									BackendRef: backendRef1,
+								},
+							},
+						},
+						{
+							Matches: []gwv1beta1.HTTPRouteMatch{
+								{
+
+									Path: &gwv1beta1.HTTPPathMatch{
+										Type:  &k8sPathMatchPrefix,
+										Value: &path3,
+									},
+								},
+							},
+							BackendRefs: []gwv1beta1.HTTPBackendRef{
+								{
+									BackendRef: backendRef2,
+								},
+							},
+						},
+					},
+				},
+			}),
+			expectedSpec: []model.RuleSpec{
+				{
+					StackListenerId: "listener-id",
+					PathMatchExact:  true,
+					PathMatchValue:  path1,
+					Priority:        1,
+					Action: model.RuleAction{
+						TargetGroups: []*model.RuleTargetGroup{
+							{
+								StackTargetGroupId: "tg-0",
+								Weight:             int64(weight1),
+							},
+						},
+					},
+				},
+				{
+					StackListenerId: "listener-id",
+					PathMatchPrefix: true,
+					PathMatchValue:  path2,
+					Method:          string(httpGet),
+					Priority:        2,
+					Action: model.RuleAction{
+						TargetGroups: []*model.RuleTargetGroup{
+							{
+								StackTargetGroupId: "tg-0",
+								Weight:             int64(weight1),
+							},
+						},
+					},
+				},
+				{
+					StackListenerId: "listener-id",
+					PathMatchPrefix: true,
+					PathMatchValue:  path3,
+					Priority:        3,
+					Action: model.RuleAction{
+						TargetGroups: []*model.RuleTargetGroup{
+							{
+								Weight: int64(weight2),
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:         "duplicate matches are collapsed",
+			wantErrIsNil: true,
 			route: core.NewHTTPRoute(gwv1beta1.HTTPRoute{
 				ObjectMeta: apimachineryv1.ObjectMeta{
 					Name:      "service1",
@@ -1113,6 +1332,22 @@ func Test_RuleModelBuild(t *testing.T) {
 					},
 				},
 			}),
+			expectedSpec: []model.RuleSpec{
+				{
+					StackListenerId: "listener-id",
+					PathMatchExact:  true,
+					PathMatchValue:  path1,
+					Priority:        1,
+					Action: model.RuleAction{
+						TargetGroups: []*model.RuleTargetGroup{
+							{
+								StackTargetGroupId: "tg-0",
+								Weight:             int64(weight1),
+							},
+						},
+					},
+				},
+			},
 		},
 		{
 			name:         "GRPC match on service and method",
@@ -1414,17 +1649,12 @@ func Test_RuleModelBuild(t *testing.T) {
 					},
 				},
 			}),
-			expectedSpec: []model.RuleSpec{
-				{
-					StackListenerId: "listener-id",
-					PathMatchPrefix: true,
-					PathMatchValue:  "/",
-					Action: model.RuleAction{
-						TargetGroups: []*model.RuleTargetGroup{
-							{
-								StackTargetGroupId: model.InvalidBackendRefTgId,
-								Weight:             int64(*invalidBackendRef.Weight),
-							},
+			expectedDefaultAction: &model.DefaultAction{
+				Forward: &model.RuleAction{
+					TargetGroups: []*model.RuleTargetGroup{
+						{
+							StackTargetGroupId: model.InvalidBackendRefTgId,
+							Weight:             int64(*invalidBackendRef.Weight),
 						},
 					},
 				},
@@ -1462,21 +1692,16 @@ func Test_RuleModelBuild(t *testing.T) {
 					},
 				},
 			}),
-			expectedSpec: []model.RuleSpec{
-				{
-					StackListenerId: "listener-id",
-					PathMatchPrefix: true,
-					PathMatchValue:  "/",
-					Action: model.RuleAction{
-						TargetGroups: []*model.RuleTargetGroup{
-							{
-								StackTargetGroupId: model.InvalidBackendRefTgId,
-								Weight:             int64(*invalidBackendRef.Weight),
-							},
-							{
-								StackTargetGroupId: "tg-0",
-								Weight:             int64(*backendRef1.Weight),
-							},
+			expectedDefaultAction: &model.DefaultAction{
+				Forward: &model.RuleAction{
+					TargetGroups: []*model.RuleTargetGroup{
+						{
+							StackTargetGroupId: model.InvalidBackendRefTgId,
+							Weight:             int64(*invalidBackendRef.Weight),
+						},
+						{
+							StackTargetGroupId: "tg-0",
+							Weight:             int64(*backendRef1.Weight),
 						},
 					},
 				},
@@ -1492,6 +1717,7 @@ func Test_RuleModelBuild(t *testing.T) {
 			k8sSchema := runtime.NewScheme()
 			k8sSchema.AddKnownTypes(anv1alpha1.SchemeGroupVersion, &anv1alpha1.ServiceImport{})
 			clientgoscheme.AddToScheme(k8sSchema)
+			gwv1beta1.AddToScheme(k8sSchema)
 			k8sClient := testclient.NewClientBuilder().WithScheme(k8sSchema).Build()
 
 			svc := corev1.Service{
@@ -1502,7 +1728,16 @@ func Test_RuleModelBuild(t *testing.T) {
 				Status: corev1.ServiceStatus{},
 			}
 			assert.NoError(t, k8sClient.Create(ctx, svc.DeepCopy()))
+			for _, grant := range tt.referenceGrants {
+				assert.NoError(t, k8sClient.Create(ctx, grant.DeepCopy()))
+			}
 			stack := core.NewDefaultStack(core.StackID(k8s.NamespacedName(tt.route.K8sObject())))
+			defaultAction := &model.DefaultAction{FixedResponseStatusCode: aws.Int64(model.DefaultActionFixedResponseStatusCode)}
+			listener := &model.Listener{
+				ResourceMeta: core.NewResourceMeta(stack, "AWS::VPCServiceNetwork::Listener", "listener-id"),
+				Spec:         model.ListenerSpec{Protocol: "HTTP", Port: 80, DefaultAction: defaultAction},
+			}
+			assert.NoError(t, stack.AddResource(listener))
 
 			task := &latticeServiceModelBuildTask{
 				log:         gwlog.FallbackLogger,
@@ -1524,6 +1759,10 @@ func Test_RuleModelBuild(t *testing.T) {
 			stack.ListResources(&resRules)
 
 			validateEqual(t, tt.expectedSpec, resRules)
+			if tt.expectedDefaultAction != nil {
+				defaultAction = tt.expectedDefaultAction
+			}
+			assert.Equal(t, defaultAction, listener.Spec.DefaultAction)
 		})
 	}
 }
@@ -1541,8 +1780,11 @@ func validateEqual(t *testing.T, expectedRules []model.RuleSpec, actualRules []*
 		assert.Equal(t, expectedSpec.PathMatchExact, actualRule.Spec.PathMatchExact)
 		assert.Equal(t, expectedSpec.Method, actualRule.Spec.Method)
 
-		// priority is not determined by model building, but in synthesis, so we don't
-		// validate priority here
+		// priority is adjusted again in synthesis, so we only validate it when the
+		// test case cares about the relative order of fanned-out rules
+		if expectedSpec.Priority != 0 {
+			assert.Equal(t, expectedSpec.Priority, actualRule.Spec.Priority)
+		}
 
 		assert.True(t, reflect.DeepEqual(expectedSpec.MatchedHeaders, actualRule.Spec.MatchedHeaders))
 
@@ -1556,3 +1798,418 @@ func validateEqual(t *testing.T, expectedRules []model.RuleSpec, actualRules []*
 		}
 	}
 }
+
+func Test_RuleModelBuild_UnsupportedMatch(t *testing.T) {
+	var httpSectionName gwv1beta1.SectionName = "http"
+	var serviceKind gwv1beta1.Kind = "Service"
+	var k8sPathMatchRegex = gwv1.PathMatchRegularExpression
+	var k8sHeaderMatchRegex = gwv1.HeaderMatchRegularExpression
+	var path1 = "/ver1"
+
+	var backendRef1 = gwv1beta1.BackendRef{
+		BackendObjectReference: gwv1beta1.BackendObjectReference{
+			Name: "targetgroup1",
+			Kind: &serviceKind,
+		},
+	}
+
+	tests := []struct {
+		name          string
+		match         gwv1beta1.HTTPRouteMatch
+		expectedInMsg string
+	}{
+		{
+			name: "query param match",
+			match: gwv1beta1.HTTPRouteMatch{
+				QueryParams: []gwv1beta1.HTTPQueryParamMatch{
+					{
+						Name:  "version",
+						Value: "beta",
+					},
+				},
+			},
+			expectedInMsg: "version",
+		},
+		{
+			name: "suffix regex header match",
+			match: gwv1beta1.HTTPRouteMatch{
+				Headers: []gwv1beta1.HTTPHeaderMatch{
+					{
+						Type:  &k8sHeaderMatchRegex,
+						Name:  "x-env",
+						Value: ".*-prod",
+					},
+				},
+			},
+			expectedInMsg: `regular expression ".*-prod" on header x-env cannot be translated: suffix matches are not supported`,
+		},
+		{
+			name: "regex path match",
+			match: gwv1beta1.HTTPRouteMatch{
+				Path: &gwv1beta1.HTTPPathMatch{
+					Type:  &k8sPathMatchRegex,
+					Value: &path1,
+				},
+			},
+			expectedInMsg: string(k8sPathMatchRegex),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.TODO()
+			route := core.NewHTTPRoute(gwv1beta1.HTTPRoute{
+				ObjectMeta: apimachineryv1.ObjectMeta{
+					Name:      "service1",
+					Namespace: "default",
+				},
+				Spec: gwv1beta1.HTTPRouteSpec{
+					CommonRouteSpec: gwv1beta1.CommonRouteSpec{
+						ParentRefs: []gwv1beta1.ParentReference{
+							{
+								Name:        "gw1",
+								SectionName: &httpSectionName,
+							},
+						},
+					},
+					Rules: []gwv1beta1.HTTPRouteRule{
+						{
+							Matches: []gwv1beta1.HTTPRouteMatch{tt.match},
+							BackendRefs: []gwv1beta1.HTTPBackendRef{
+								{
+									BackendRef: backendRef1,
+								},
+							},
+						},
+					},
+				},
+			})
+
+			k8sSchema := runtime.NewScheme()
+			clientgoscheme.AddToScheme(k8sSchema)
+			k8sClient := testclient.NewClientBuilder().WithScheme(k8sSchema).Build()
+			stack := core.NewDefaultStack(core.StackID(k8s.NamespacedName(route.K8sObject())))
+
+			task := &latticeServiceModelBuildTask{
+				log:         gwlog.FallbackLogger,
+				route:       route,
+				stack:       stack,
+				client:      k8sClient,
+				brTgBuilder: &dummyTgBuilder{},
+			}
+
+			err := task.buildRules(ctx, "listener-id")
+			var unsupportedErr *UnsupportedRouteError
+			assert.ErrorAs(t, err, &unsupportedErr)
+			assert.Equal(t, gwv1.RouteReasonUnsupportedValue, unsupportedErr.Reason)
+			assert.Contains(t, unsupportedErr.Message, tt.expectedInMsg)
+		})
+	}
+}
+
+func Test_RuleModelBuild_RegexMatches(t *testing.T) {
+	var headerMatchRegex = gwv1.HeaderMatchRegularExpression
+	var methodMatchRegex = gwv1alpha2.GRPCMethodMatchRegularExpression
+
+	grpcMatch := func(service *string, method *string) core.RouteMatch {
+		route := core.NewGRPCRoute(gwv1alpha2.GRPCRoute{
+			Spec: gwv1alpha2.GRPCRouteSpec{
+				Rules: []gwv1alpha2.GRPCRouteRule{{
+					Matches: []gwv1alpha2.GRPCRouteMatch{{
+						Method: &gwv1alpha2.GRPCMethodMatch{
+							Type:    &methodMatchRegex,
+							Service: service,
+							Method:  method,
+						},
+					}},
+				}},
+			},
+		})
+		return route.Spec().Rules()[0].Matches()[0]
+	}
+	httpMatch := core.NewHTTPRoute(gwv1beta1.HTTPRoute{
+		Spec: gwv1beta1.HTTPRouteSpec{
+			Rules: []gwv1beta1.HTTPRouteRule{{
+				Matches: []gwv1beta1.HTTPRouteMatch{{
+					Headers: []gwv1beta1.HTTPHeaderMatch{
+						{Type: &headerMatchRegex, Name: "x-version", Value: "^v2.*"},
+						{Type: &headerMatchRegex, Name: "x-user", Value: "(?i).*Admin.*"},
+					},
+				}},
+			}},
+		},
+	}).Spec().Rules()[0].Matches()[0]
+
+	tests := []struct {
+		name          string
+		match         core.RouteMatch
+		expected      model.RuleSpec
+		expectedInMsg string
+	}{
+		{
+			name:  "header prefix and case insensitive contains",
+			match: httpMatch,
+			expected: model.RuleSpec{
+				MatchedHeaders: []vpclattice.HeaderMatch{
+					{
+						Name:          aws.String("x-version"),
+						Match:         &vpclattice.HeaderMatchType{Prefix: aws.String("v2")},
+						CaseSensitive: aws.Bool(true),
+					},
+					{
+						Name:          aws.String("x-user"),
+						Match:         &vpclattice.HeaderMatchType{Contains: aws.String("admin")},
+						CaseSensitive: aws.Bool(false),
+					},
+				},
+			},
+		},
+		{
+			name:     "gRPC service prefix",
+			match:    grpcMatch(aws.String(`com\.example.*`), nil),
+			expected: model.RuleSpec{Method: "POST", PathMatchPrefix: true, PathMatchValue: "/com.example"},
+		},
+		{
+			name:     "gRPC exact service",
+			match:    grpcMatch(aws.String(`^com\.example\.Greeter$`), nil),
+			expected: model.RuleSpec{Method: "POST", PathMatchPrefix: true, PathMatchValue: "/com.example.Greeter/"},
+		},
+		{
+			name:     "gRPC method prefix",
+			match:    grpcMatch(aws.String("Greeter"), aws.String("Say.*")),
+			expected: model.RuleSpec{Method: "POST", PathMatchPrefix: true, PathMatchValue: "/Greeter/Say"},
+		},
+		{
+			name:     "gRPC exact method",
+			match:    grpcMatch(aws.String("Greeter"), aws.String("SayHello")),
+			expected: model.RuleSpec{Method: "POST", PathMatchExact: true, PathMatchValue: "/Greeter/SayHello"},
+		},
+		{
+			name:          "gRPC method with service prefix",
+			match:         grpcMatch(aws.String("Greet.*"), aws.String("SayHello")),
+			expectedInMsg: `gRPC service regular expression "Greet.*" cannot be translated: a method match requires an exact service`,
+		},
+		{
+			name:          "gRPC service contains",
+			match:         grpcMatch(aws.String(".*Greeter.*"), nil),
+			expectedInMsg: "only support exact and prefix matches",
+		},
+		{
+			name:          "gRPC case insensitive method",
+			match:         grpcMatch(aws.String("Greeter"), aws.String("(?i)sayhello")),
+			expectedInMsg: "VPC Lattice path matches are case sensitive",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := &latticeServiceModelBuildTask{
+				log:   gwlog.FallbackLogger,
+				route: core.NewHTTPRoute(gwv1beta1.HTTPRoute{}),
+			}
+			ruleSpec := model.RuleSpec{}
+			var err error
+			if grpcMatch, ok := tt.match.(*core.GRPCRouteMatch); ok {
+				err = task.updateRuleSpecForGrpcRoute(grpcMatch, &ruleSpec)
+			} else {
+				err = task.updateRuleSpecWithHeaderMatches(tt.match, &ruleSpec)
+			}
+			if tt.expectedInMsg != "" {
+				var unsupportedErr *UnsupportedRouteError
+				assert.ErrorAs(t, err, &unsupportedErr)
+				assert.Contains(t, unsupportedErr.Message, LATTICE_UNSUPPORTED_REGEX)
+				assert.Contains(t, unsupportedErr.Message, tt.expectedInMsg)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, ruleSpec)
+		})
+	}
+}
+
+func Test_RuleModelBuild_DroppedRules(t *testing.T) {
+	var httpSectionName gwv1beta1.SectionName = "http"
+	var serviceKind gwv1beta1.Kind = "Service"
+	var unknownKind gwv1beta1.Kind = "Unknown"
+	var k8sPathMatchPrefix = gwv1.PathMatchPathPrefix
+	var path1 = "/ver1"
+
+	validRule := gwv1beta1.HTTPRouteRule{
+		Matches: []gwv1beta1.HTTPRouteMatch{
+			{
+				Path: &gwv1beta1.HTTPPathMatch{
+					Type:  &k8sPathMatchPrefix,
+					Value: &path1,
+				},
+			},
+		},
+		BackendRefs: []gwv1beta1.HTTPBackendRef{
+			{
+				BackendRef: gwv1beta1.BackendRef{
+					BackendObjectReference: gwv1beta1.BackendObjectReference{
+						Name: "targetgroup1",
+						Kind: &serviceKind,
+					},
+				},
+			},
+		},
+	}
+	queryParamRule := gwv1beta1.HTTPRouteRule{
+		Matches: []gwv1beta1.HTTPRouteMatch{
+			{
+				QueryParams: []gwv1beta1.HTTPQueryParamMatch{
+					{
+						Name:  "version",
+						Value: "beta",
+					},
+				},
+			},
+		},
+	}
+	unknownKindRule := gwv1beta1.HTTPRouteRule{
+		BackendRefs: []gwv1beta1.HTTPBackendRef{
+			{
+				BackendRef: gwv1beta1.BackendRef{
+					BackendObjectReference: gwv1beta1.BackendObjectReference{
+						Name: "something",
+						Kind: &unknownKind,
+					},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name            string
+		rules           []gwv1beta1.HTTPRouteRule
+		expectedDropped []int
+		expectedRules   int
+		allDropped      bool
+	}{
+		{
+			name:          "all rules valid",
+			rules:         []gwv1beta1.HTTPRouteRule{validRule},
+			expectedRules: 1,
+		},
+		{
+			name:            "invalid match is dropped",
+			rules:           []gwv1beta1.HTTPRouteRule{queryParamRule, validRule},
+			expectedDropped: []int{0},
+			expectedRules:   1,
+		},
+		{
+			name:            "invalid backendRef kind is dropped",
+			rules:           []gwv1beta1.HTTPRouteRule{validRule, unknownKindRule, queryParamRule},
+			expectedDropped: []int{1, 2},
+			expectedRules:   1,
+		},
+		{
+			name:       "all rules invalid",
+			rules:      []gwv1beta1.HTTPRouteRule{queryParamRule, unknownKindRule},
+			allDropped: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.TODO()
+			route := core.NewHTTPRoute(gwv1beta1.HTTPRoute{
+				ObjectMeta: apimachineryv1.ObjectMeta{
+					Name:      "service1",
+					Namespace: "default",
+				},
+				Spec: gwv1beta1.HTTPRouteSpec{
+					CommonRouteSpec: gwv1beta1.CommonRouteSpec{
+						ParentRefs: []gwv1beta1.ParentReference{
+							{
+								Name:        "gw1",
+								SectionName: &httpSectionName,
+							},
+						},
+					},
+					Rules: tt.rules,
+				},
+			})
+
+			k8sSchema := runtime.NewScheme()
+			clientgoscheme.AddToScheme(k8sSchema)
+			k8sClient := testclient.NewClientBuilder().WithScheme(k8sSchema).Build()
+			stack := core.NewDefaultStack(core.StackID(k8s.NamespacedName(route.K8sObject())))
+
+			task := &latticeServiceModelBuildTask{
+				log:         gwlog.FallbackLogger,
+				route:       route,
+				stack:       stack,
+				client:      k8sClient,
+				brTgBuilder: &dummyTgBuilder{},
+			}
+
+			err := task.buildRules(ctx, "listener-id")
+			if tt.allDropped {
+				var unsupportedErr *UnsupportedRouteError
+				assert.ErrorAs(t, err, &unsupportedErr)
+				return
+			}
+
+			if tt.expectedDropped == nil {
+				assert.NoError(t, err)
+			} else {
+				var partialErr *PartiallyInvalidRouteError
+				assert.ErrorAs(t, err, &partialErr)
+				assert.Equal(t, tt.expectedDropped, partialErr.DroppedRules)
+				assert.True(t, strings.HasPrefix(partialErr.Message, "Dropped Rule"))
+			}
+
+			var resRules []*model.Rule
+			stack.ListResources(&resRules)
+			assert.Equal(t, tt.expectedRules, len(resRules))
+			assert.Equal(t, path1, resRules[0].Spec.PathMatchValue)
+		})
+	}
+}
+
+func Test_RuleModelBuild_OtherAccountServiceImport(t *testing.T) {
+	accountID := config.AccountID
+	defer func() { config.AccountID = accountID }()
+	config.AccountID = "111111111111"
+	ctx := context.TODO()
+
+	var serviceImportKind gwv1beta1.Kind = "ServiceImport"
+	k8sSchema := runtime.NewScheme()
+	clientgoscheme.AddToScheme(k8sSchema)
+	anv1alpha1.AddToScheme(k8sSchema)
+	gwv1beta1.AddToScheme(k8sSchema)
+	k8sClient := testclient.NewClientBuilder().WithScheme(k8sSchema).WithObjects(
+		&anv1alpha1.ServiceImport{ObjectMeta: apimachineryv1.ObjectMeta{Name: "local", Namespace: "default",
+			Annotations: map[string]string{ServiceImportAccountAnnotation: "111111111111"}}},
+		&anv1alpha1.ServiceImport{ObjectMeta: apimachineryv1.ObjectMeta{Name: "remote", Namespace: "default",
+			Annotations: map[string]string{ServiceImportAccountAnnotation: "222222222222"}}},
+	).Build()
+
+	route := core.NewHTTPRoute(gwv1beta1.HTTPRoute{
+		ObjectMeta: apimachineryv1.ObjectMeta{Name: "service1", Namespace: "default"},
+		Spec: gwv1beta1.HTTPRouteSpec{
+			Rules: []gwv1beta1.HTTPRouteRule{
+				{
+					BackendRefs: []gwv1beta1.HTTPBackendRef{
+						{BackendRef: gwv1beta1.BackendRef{BackendObjectReference: gwv1beta1.BackendObjectReference{
+							Name: "local", Kind: &serviceImportKind}}},
+						{BackendRef: gwv1beta1.BackendRef{BackendObjectReference: gwv1beta1.BackendObjectReference{
+							Name: "remote", Kind: &serviceImportKind}}},
+					},
+				},
+			},
+		},
+	})
+	task := &latticeServiceModelBuildTask{
+		log:    gwlog.FallbackLogger,
+		route:  route,
+		client: k8sClient,
+	}
+
+	// target groups of another account cannot be forwarded to, the backendRef is invalid
+	tgs, err := task.getTargetGroupsForRuleAction(ctx, route.Spec().Rules()[0])
+	assert.NoError(t, err)
+	assert.Len(t, tgs, 2)
+	assert.Equal(t, &model.SvcImportTargetGroup{K8SServiceName: "local", K8SServiceNamespace: "default"}, tgs[0].SvcImportTG)
+	assert.Nil(t, tgs[1].SvcImportTG)
+	assert.Equal(t, model.InvalidBackendRefTgId, tgs[1].StackTargetGroupId)
+}
@@ -40,7 +40,7 @@ type SvcExportTargetGroupModelBuilder interface {
 	Build(ctx context.Context, svcExport *anv1alpha1.ServiceExport) (core.Stack, error)
 
 	// used for reconciliation of existing target groups against a service export object
-	BuildTargetGroup(ctx context.Context, svcExport *anv1alpha1.ServiceExport) (*model.TargetGroup, error)
+	BuildTargetGroups(ctx context.Context, svcExport *anv1alpha1.ServiceExport) ([]*model.TargetGroup, error)
 }
 
 type SvcExportTargetGroupBuilder struct {
@@ -87,7 +87,7 @@ func (b *SvcExportTargetGroupBuilder) Build(
 	return task.stack, nil
 }
 
-func (b *SvcExportTargetGroupBuilder) BuildTargetGroup(ctx context.Context, svcExport *anv1alpha1.ServiceExport) (*model.TargetGroup, error) {
+func (b *SvcExportTargetGroupBuilder) BuildTargetGroups(ctx context.Context, svcExport *anv1alpha1.ServiceExport) ([]*model.TargetGroup, error) {
 	stack := core.NewDefaultStack(core.StackID(k8s.NamespacedName(svcExport)))
 
 	task := &svcExportTargetGroupModelBuildTask{
@@ -98,18 +98,21 @@ func (b *SvcExportTargetGroupBuilder) BuildTargetGroup(ctx context.Context, svcE
 		tgp:           policy.NewTargetGroupPolicyHandler(b.log, b.client),
 	}
 
-	return task.buildTargetGroup(ctx)
+	return task.buildTargetGroups(ctx)
 }
 
 func (t *svcExportTargetGroupModelBuildTask) run(ctx context.Context) error {
-	tg, err := t.buildTargetGroup(ctx)
+	tgs, err := t.buildTargetGroups(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to build target group for service export %s-%s due to %w",
 			t.serviceExport.Name, t.serviceExport.Namespace, err)
 	}
 
-	if !tg.IsDeleted {
-		err = t.buildTargets(ctx, tg.ID())
+	for _, tg := range tgs {
+		if tg.IsDeleted {
+			continue
+		}
+		err = t.buildTargets(ctx, tg.ID(), tg.Spec.K8SServicePort)
 		if err != nil {
 			t.log.Debugf("Failed to build targets for service export %s-%s due to %s",
 				t.serviceExport.Name, t.serviceExport.Namespace, err)
@@ -120,16 +123,18 @@ func (t *svcExportTargetGroupModelBuildTask) run(ctx context.Context) error {
 	return nil
 }
 
-func (t *svcExportTargetGroupModelBuildTask) buildTargets(ctx context.Context, stackTgId string) error {
+func (t *svcExportTargetGroupModelBuildTask) buildTargets(ctx context.Context, stackTgId string, port int32) error {
 	targetsBuilder := NewTargetsBuilder(t.log, t.client, t.stack)
-	_, err := targetsBuilder.BuildForServiceExport(ctx, t.serviceExport, stackTgId)
+	_, err := targetsBuilder.BuildForServiceExport(ctx, t.serviceExport, port, stackTgId)
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
-func (t *svcExportTargetGroupModelBuildTask) buildTargetGroup(ctx context.Context) (*model.TargetGroup, error) {
+// buildTargetGroups builds a target group for each port in the spec of the service export,
+// or a single target group for all the exported ports when the spec has none.
+func (t *svcExportTargetGroupModelBuildTask) buildTargetGroups(ctx context.Context) ([]*model.TargetGroup, error) {
 	svc := &corev1.Service{}
 	noSvcFoundAndDeleting := false
 	if err := t.client.Get(ctx, k8s.NamespacedName(t.serviceExport), svc); err != nil {
@@ -163,9 +168,40 @@ func (t *svcExportTargetGroupModelBuildTask) buildTargetGroup(ctx context.Contex
 		return nil, err
 	}
 
+	if len(t.serviceExport.Spec.Ports) == 0 {
+		stackTG, err := t.buildTargetGroup(80, 0, protocol, protocolVersion, ipAddressType, healthCheckConfig)
+		if err != nil {
+			return nil, err
+		}
+		return []*model.TargetGroup{stackTG}, nil
+	}
+
+	var stackTGs []*model.TargetGroup
+	for _, exportedPort := range t.serviceExport.Spec.Ports {
+		if !noSvcFoundAndDeleting && !hasServicePort(svc, exportedPort.Port) {
+			return nil, fmt.Errorf("port %d is not a port of service %s",
+				exportedPort.Port, k8s.NamespacedName(svc))
+		}
+		portProtocol, portProtocolVersion, err := parseExportedPortConfig(exportedPort, protocol, protocolVersion)
+		if err != nil {
+			return nil, err
+		}
+		stackTG, err := t.buildTargetGroup(exportedPort.Port, exportedPort.Port,
+			portProtocol, portProtocolVersion, ipAddressType, healthCheckConfig)
+		if err != nil {
+			return nil, err
+		}
+		stackTGs = append(stackTGs, stackTG)
+	}
+	return stackTGs, nil
+}
+
+func (t *svcExportTargetGroupModelBuildTask) buildTargetGroup(port int32, servicePort int32,
+	protocol string, protocolVersion string, ipAddressType string,
+	healthCheckConfig *vpclattice.HealthCheckConfig) (*model.TargetGroup, error) {
 	spec := model.TargetGroupSpec{
 		Type:              model.TargetGroupTypeIP,
-		Port:              80,
+		Port:              port,
 		Protocol:          protocol,
 		ProtocolVersion:   protocolVersion,
 		IpAddressType:     ipAddressType,
@@ -177,6 +213,7 @@ func (t *svcExportTargetGroupModelBuildTask) buildTargetGroup(ctx context.Contex
 	spec.K8SServiceName = t.serviceExport.Name
 	spec.K8SServiceNamespace = t.serviceExport.Namespace
 	spec.K8SProtocolVersion = protocolVersion
+	spec.K8SServicePort = servicePort
 
 	stackTG, err := model.NewTargetGroup(t.stack, spec)
 	if err != nil {
@@ -187,6 +224,15 @@ func (t *svcExportTargetGroupModelBuildTask) buildTargetGroup(ctx context.Contex
 	return stackTG, nil
 }
 
+func hasServicePort(svc *corev1.Service, port int32) bool {
+	for _, servicePort := range svc.Spec.Ports {
+		if servicePort.Port == port {
+			return true
+		}
+	}
+	return false
+}
+
 type BackendRefTargetGroupModelBuilder interface {
 	Build(ctx context.Context, route core.Route, backendRef core.BackendRef, stack core.Stack) (core.Stack, *model.TargetGroup, error)
 }
@@ -371,6 +417,26 @@ func getBackendRefNsName(route core.Route, backendRef core.BackendRef) types.Nam
 	return backendRefNsName
 }
 
+// IsBackendRefPermitted checks a cross namespace backendRef of the route is allowed by a ReferenceGrant
+func IsBackendRefPermitted(ctx context.Context, k8sClient client.Client, route core.Route, backendRef core.BackendRef) (bool, error) {
+	kind := "Service"
+	if backendRef.Kind() != nil {
+		kind = string(*backendRef.Kind())
+	}
+	group := ""
+	if backendRef.Group() != nil {
+		group = string(*backendRef.Group())
+	} else if kind == "ServiceImport" {
+		group = anv1alpha1.GroupName
+	}
+	backendRefNsName := getBackendRefNsName(route, backendRef)
+	routeGk := route.GroupKind()
+
+	return k8s.IsReferencePermitted(ctx, k8sClient,
+		k8s.ObjectRef{Group: routeGk.Group, Kind: routeGk.Kind, Namespace: route.Namespace(), Name: route.Name()},
+		k8s.ObjectRef{Group: group, Kind: kind, Namespace: backendRefNsName.Namespace, Name: backendRefNsName.Name})
+}
+
 func parseTargetGroupConfig(tgp *anv1alpha1.TargetGroupPolicy) (
 	protocol string, protocolVersion string, healthCheckConfig *vpclattice.HealthCheckConfig, err error) {
 	protocol = "HTTP"
@@ -396,6 +462,26 @@ func parseTargetGroupConfig(tgp *anv1alpha1.TargetGroupPolicy) (
 	return protocol, protocolVersion, healthCheckConfig, nil
 }
 
+// parseExportedPortConfig overrides the protocol and protocolVersion of the TargetGroupPolicy
+// with the ones of an exported port.
+func parseExportedPortConfig(exportedPort anv1alpha1.ExportedPort, protocol string, protocolVersion string) (string, string, error) {
+	if exportedPort.Protocol != "" {
+		protocol = exportedPort.Protocol
+		if protocol == vpclattice.TargetGroupProtocolTcp {
+			protocolVersion = ""
+		} else if protocolVersion == "" {
+			protocolVersion = vpclattice.TargetGroupProtocolVersionHttp1
+		}
+	}
+	if exportedPort.ProtocolVersion != "" {
+		if protocol == vpclattice.TargetGroupProtocolTcp {
+			return "", "", fmt.Errorf("protocolVersion is not supported for TCP port %d", exportedPort.Port)
+		}
+		protocolVersion = exportedPort.ProtocolVersion
+	}
+	return protocol, protocolVersion, nil
+}
+
 func parseHealthCheckConfig(tgp *anv1alpha1.TargetGroupPolicy) *vpclattice.HealthCheckConfig {
 	hc := tgp.Spec.HealthCheck
 	if hc == nil {
@@ -15,10 +15,12 @@ import (
 
 	"github.com/aws/aws-application-networking-k8s/pkg/utils/gwlog"
 
+	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/vpclattice"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
@@ -270,6 +272,147 @@ func Test_TGModelByServiceExportBuild(t *testing.T) {
 	}
 }
 
+func Test_TGModelByServiceExportPortsBuild(t *testing.T) {
+	config.VpcID = "vpc-id"
+	config.ClusterName = "cluster-name"
+
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "export1",
+			Namespace: "ns1",
+		},
+		Spec: corev1.ServiceSpec{
+			Ports: []corev1.ServicePort{
+				{Name: "http", Port: 80},
+				{Name: "grpc", Port: 50051},
+			},
+			IPFamilies: []corev1.IPFamily{corev1.IPv4Protocol},
+		},
+	}
+	epSlice := &discoveryv1.EndpointSlice{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "export1-abc",
+			Namespace: "ns1",
+			Labels:    map[string]string{discoveryv1.LabelServiceName: "export1"},
+		},
+		Ports: []discoveryv1.EndpointPort{
+			{Name: aws.String("http"), Port: aws.Int32(8080)},
+			{Name: aws.String("grpc"), Port: aws.Int32(9090)},
+		},
+		Endpoints: []discoveryv1.Endpoint{
+			{
+				Addresses:  []string{"10.0.0.1"},
+				Conditions: discoveryv1.EndpointConditions{Ready: aws.Bool(true)},
+			},
+		},
+	}
+
+	type expectedTg struct {
+		port            int32
+		protocol        string
+		protocolVersion string
+		targetPort      int64
+	}
+	tests := []struct {
+		name        string
+		ports       []anv1alpha1.ExportedPort
+		expectedTgs []expectedTg
+		expectedErr string
+	}{
+		{
+			name: "target group per exported port",
+			ports: []anv1alpha1.ExportedPort{
+				{Port: 80},
+				{Port: 50051, ProtocolVersion: vpclattice.TargetGroupProtocolVersionGrpc},
+			},
+			expectedTgs: []expectedTg{
+				{80, vpclattice.TargetGroupProtocolHttp, vpclattice.TargetGroupProtocolVersionHttp1, 8080},
+				{50051, vpclattice.TargetGroupProtocolHttp, vpclattice.TargetGroupProtocolVersionGrpc, 9090},
+			},
+		},
+		{
+			name: "TCP exported port",
+			ports: []anv1alpha1.ExportedPort{
+				{Port: 80, Protocol: vpclattice.TargetGroupProtocolTcp},
+			},
+			expectedTgs: []expectedTg{
+				{80, vpclattice.TargetGroupProtocolTcp, "", 8080},
+			},
+		},
+		{
+			name: "protocolVersion with TCP exported port",
+			ports: []anv1alpha1.ExportedPort{
+				{Port: 80, Protocol: vpclattice.TargetGroupProtocolTcp, ProtocolVersion: vpclattice.TargetGroupProtocolVersionHttp2},
+			},
+			expectedErr: "protocolVersion is not supported for TCP port 80",
+		},
+		{
+			name: "exported port not in service",
+			ports: []anv1alpha1.ExportedPort{
+				{Port: 81},
+			},
+			expectedErr: "port 81 is not a port of service ns1/export1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.TODO()
+
+			k8sSchema := runtime.NewScheme()
+			clientgoscheme.AddToScheme(k8sSchema)
+			anv1alpha1.AddToScheme(k8sSchema)
+			k8sClient := testclient.NewClientBuilder().WithScheme(k8sSchema).
+				WithObjects(svc.DeepCopy(), epSlice.DeepCopy()).Build()
+
+			svcExport := &anv1alpha1.ServiceExport{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "export1",
+					Namespace: "ns1",
+				},
+				Spec: anv1alpha1.ServiceExportSpec{Ports: tt.ports},
+			}
+			builder := NewSvcExportTargetGroupBuilder(gwlog.FallbackLogger, k8sClient)
+
+			stack, err := builder.Build(ctx, svcExport)
+			if tt.expectedErr != "" {
+				assert.ErrorContains(t, err, tt.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+
+			var resTargetGroups []*model.TargetGroup
+			assert.NoError(t, stack.ListResources(&resTargetGroups))
+			assert.Len(t, resTargetGroups, len(tt.expectedTgs))
+			var resTargets []*model.Targets
+			assert.NoError(t, stack.ListResources(&resTargets))
+			assert.Len(t, resTargets, len(tt.expectedTgs))
+
+			for _, expected := range tt.expectedTgs {
+				var stackTg *model.TargetGroup
+				for _, tg := range resTargetGroups {
+					if tg.Spec.Port == expected.port {
+						stackTg = tg
+					}
+				}
+				assert.NotNil(t, stackTg)
+				assert.Equal(t, expected.port, stackTg.Spec.K8SServicePort)
+				assert.Equal(t, expected.protocol, stackTg.Spec.Protocol)
+				assert.Equal(t, expected.protocolVersion, stackTg.Spec.ProtocolVersion)
+				assert.Equal(t, expected.protocolVersion, stackTg.Spec.K8SProtocolVersion)
+
+				for _, targets := range resTargets {
+					if targets.Spec.StackTargetGroupId == stackTg.ID() {
+						assert.Equal(t, []model.Target{
+							{TargetIP: "10.0.0.1", Port: expected.targetPort, Ready: true},
+						}, targets.Spec.TargetList)
+					}
+				}
+			}
+		})
+	}
+}
+
 func Test_TGModelByHTTPRouteBuild(t *testing.T) {
 	config.VpcID = "vpc-id"
 	config.ClusterName = "cluster-name"
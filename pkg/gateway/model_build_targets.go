@@ -26,7 +26,7 @@ const (
 
 type LatticeTargetsBuilder interface {
 	Build(ctx context.Context, service *corev1.Service, backendRef core.BackendRef, stackTgId string) (core.Stack, error)
-	BuildForServiceExport(ctx context.Context, serviceExport *anv1alpha1.ServiceExport, stackTgId string) (core.Stack, error)
+	BuildForServiceExport(ctx context.Context, serviceExport *anv1alpha1.ServiceExport, port int32, stackTgId string) (core.Stack, error)
 }
 
 type LatticeTargetsModelBuilder struct {
@@ -49,17 +49,19 @@ func NewTargetsBuilder(
 
 func (b *LatticeTargetsModelBuilder) Build(ctx context.Context, service *corev1.Service,
 	backendRef core.BackendRef, stackTgId string) (core.Stack, error) {
-	return b.build(ctx, nil, service, backendRef, b.stack, stackTgId)
+	return b.build(ctx, nil, undefinedPort, service, backendRef, b.stack, stackTgId)
 }
 
+// BuildForServiceExport builds the targets of a single exported port, or of the ports of
+// the port annotation when port is undefined.
 func (b *LatticeTargetsModelBuilder) BuildForServiceExport(ctx context.Context,
-	serviceExport *anv1alpha1.ServiceExport, stackTgId string) (core.Stack, error) {
+	serviceExport *anv1alpha1.ServiceExport, port int32, stackTgId string) (core.Stack, error) {
 
-	return b.build(ctx, serviceExport, nil, nil, b.stack, stackTgId)
+	return b.build(ctx, serviceExport, port, nil, nil, b.stack, stackTgId)
 }
 
 func (b *LatticeTargetsModelBuilder) build(ctx context.Context,
-	serviceExport *anv1alpha1.ServiceExport,
+	serviceExport *anv1alpha1.ServiceExport, exportedPort int32,
 	service *corev1.Service, backendRef core.BackendRef,
 	stack core.Stack, stackTgId string,
 ) (core.Stack, error) {
@@ -102,6 +104,7 @@ func (b *LatticeTargetsModelBuilder) build(ctx context.Context,
 		log:           b.log,
 		client:        b.client,
 		serviceExport: serviceExport,
+		exportedPort:  exportedPort,
 		service:       service,
 		backendRef:    backendRef,
 		stack:         stack,
@@ -211,7 +214,9 @@ func (t *latticeTargetsModelBuildTask) getDefinedPorts() map[int32]struct{} {
 	definedPorts := make(map[int32]struct{})
 
 	isServiceExport := t.serviceExport != nil
-	if isServiceExport {
+	if isServiceExport && t.exportedPort != undefinedPort {
+		definedPorts[t.exportedPort] = struct{}{}
+	} else if isServiceExport {
 		portsAnnotations := strings.Split(t.serviceExport.ObjectMeta.Annotations[portAnnotationsKey], ",")
 
 		for _, portAnnotation := range portsAnnotations {
@@ -238,6 +243,7 @@ type latticeTargetsModelBuildTask struct {
 	log           gwlog.Logger
 	client        client.Client
 	serviceExport *anv1alpha1.ServiceExport
+	exportedPort  int32
 	service       *corev1.Service
 	backendRef    core.BackendRef
 	stack         core.Stack
@@ -40,6 +40,7 @@ func Test_Targets(t *testing.T) {
 		endpointSlice      []discoveryv1.EndpointSlice
 		svc                corev1.Service
 		serviceExport      anv1alpha1.ServiceExport
+		exportedPort       int32
 		refByServiceExport bool
 		refByService       bool
 		wantErrIsNil       bool
@@ -260,6 +261,76 @@ func Test_Targets(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Only add endpoints of the exported port to build spec",
+			endpointSlice: []discoveryv1.EndpointSlice{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "ns1",
+						Name:      "export1",
+						Labels:    map[string]string{discoveryv1.LabelServiceName: "export1"},
+					},
+					Ports: []discoveryv1.EndpointPort{
+						{
+							Name: aws.String("a"),
+							Port: aws.Int32(8675),
+						},
+						{
+							Name: aws.String("b"),
+							Port: aws.Int32(3090),
+						},
+					},
+					Endpoints: []discoveryv1.Endpoint{
+						{
+							Addresses: []string{"10.10.1.1"},
+							Conditions: discoveryv1.EndpointConditions{
+								Ready: aws.Bool(true),
+							},
+						},
+					},
+				},
+			},
+			svc: corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:         "ns1",
+					Name:              "export1",
+					DeletionTimestamp: nil,
+				},
+				Spec: corev1.ServiceSpec{
+					Ports: []corev1.ServicePort{
+						{
+							Name:       "a",
+							Port:       80,
+							TargetPort: intstr.FromInt(8675),
+						},
+						{
+							Name:       "b",
+							Port:       81,
+							TargetPort: intstr.FromInt(3090),
+						},
+					},
+				},
+			},
+			serviceExport: anv1alpha1.ServiceExport{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:         "ns1",
+					Name:              "export1",
+					DeletionTimestamp: nil,
+					// the annotation is ignored when building the targets of an exported port
+					Annotations: map[string]string{"application-networking.k8s.aws/port": "81"},
+				},
+			},
+			exportedPort:       80,
+			refByServiceExport: true,
+			wantErrIsNil:       true,
+			expectedTargetList: []model.Target{
+				{
+					TargetIP: "10.10.1.1",
+					Port:     8675,
+					Ready:    true,
+				},
+			},
+		},
 		{
 			name:               "Endpoints does NOT exists",
 			port:               0,
@@ -471,7 +542,7 @@ func Test_Targets(t *testing.T) {
 
 			var err error
 			if tt.refByServiceExport {
-				_, err = builder.BuildForServiceExport(ctx, &tt.serviceExport, "tg-id")
+				_, err = builder.BuildForServiceExport(ctx, &tt.serviceExport, tt.exportedPort, "tg-id")
 			} else {
 				_, err = builder.Build(ctx, &tt.svc, &corebr, "tg-id")
 			}
@@ -7,17 +7,24 @@ const (
 	FailedReconcileEvent = "FailedReconcile"
 
 	// Gateway events
-	GatewayEventReasonFailedAddFinalizer = "FailedAddFinalizer"
-	GatewayEventReasonFailedBuildModel   = "FailedBuildModel"
-	GatewayEventReasonFailedDeployModel  = "FailedDeployModel"
+	GatewayEventReasonFailedAddFinalizer         = "FailedAddFinalizer"
+	GatewayEventReasonFailedBuildModel           = "FailedBuildModel"
+	GatewayEventReasonFailedDeployModel          = "FailedDeployModel"
+	GatewayEventReasonInvalidCertificateRef      = "InvalidCertificateRef"
+	GatewayEventReasonServiceNetworkCreated      = "ServiceNetworkCreated"
+	GatewayEventReasonFailedDeleteServiceNetwork = "FailedDeleteServiceNetwork"
+	GatewayEventReasonServiceNetworkClaimed      = "ServiceNetworkClaimed"
 
 	// Route events
-	RouteEventReasonReconcile          = "Reconcile"
-	RouteEventReasonDeploySucceed      = "DeploySucceed"
-	RouteEventReasonFailedAddFinalizer = "FailedAddFinalizer"
-	RouteEventReasonFailedBuildModel   = "FailedBuildModel"
-	RouteEventReasonFailedDeployModel  = "FailedDeployModel"
-	RouteEventReasonRetryReconcile     = "Retry-Reconcile"
+	RouteEventReasonReconcile             = "Reconcile"
+	RouteEventReasonDeploySucceed         = "DeploySucceed"
+	RouteEventReasonFailedAddFinalizer    = "FailedAddFinalizer"
+	RouteEventReasonFailedBuildModel      = "FailedBuildModel"
+	RouteEventReasonFailedDeployModel     = "FailedDeployModel"
+	RouteEventReasonRetryReconcile        = "Retry-Reconcile"
+	RouteEventReasonNotAllowedByListeners = "NotAllowedByListeners"
+	RouteEventReasonPlanSucceed           = "PlanSucceed"
+	RouteEventReasonFailedPlanModel       = "FailedPlanModel"
 
 	// Service events
 	ServiceEventReasonFailedAddFinalizer = "FailedAddFinalizer"
@@ -28,9 +35,15 @@ const (
 	ServiceExportEventReasonFailedAddFinalizer = "FailedAddFinalizer"
 	ServiceExportEventReasonFailedBuildModel   = "FailedBuildModel"
 	ServiceExportEventReasonFailedDeployModel  = "FailedDeployModel"
+	ServiceExportEventReasonConflict           = "Conflict"
+	ServiceExportEventReasonMigrationBlocked   = "MigrationBlocked"
 
 	// ServiceImport events
 	ServiceImportEventReasonFailedAddFinalizer = "FailedAddFinalizer"
 	ServiceImportEventReasonFailedBuildModel   = "FailedBuildModel"
 	ServiceImportEventReasonFailedDeployModel  = "FailedDeployModel"
+
+	// Drift events, emitted on routes and ServiceExports
+	DriftEventReasonDriftDetected = "DriftDetected"
+	DriftEventReasonRepairDrift   = "RepairDrift"
 )
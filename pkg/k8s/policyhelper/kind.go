@@ -34,10 +34,15 @@ func ObjToGroupKind(obj client.Object) GroupKind {
 }
 
 func TargetRefGroupKind(tr *TargetRef) GroupKind {
-	return GroupKind{
+	gk := GroupKind{
 		Group: string(tr.Group),
 		Kind:  string(tr.Kind),
 	}
+	// ServiceExports of either group resolve to the ones of the configured group
+	if gk.Kind == "ServiceExport" && anv1alpha1.IsMulticlusterGroup(gk.Group) {
+		gk.Group = anv1alpha1.GroupName
+	}
+	return gk
 }
 
 func GroupKindToObj(gk GroupKind) (client.Object, bool) {
@@ -8,6 +8,8 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	gwv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
 	gwv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	anv1alpha1 "github.com/aws/aws-application-networking-k8s/pkg/apis/applicationnetworking/v1alpha1"
 )
 
 func TestGroupKind(t *testing.T) {
@@ -21,6 +23,7 @@ func TestGroupKind(t *testing.T) {
 		{&gwv1beta1.HTTPRoute{}, GroupKind{Group: gwv1beta1.GroupName, Kind: "HTTPRoute"}},
 		{&gwv1alpha2.GRPCRoute{}, GroupKind{Group: gwv1alpha2.GroupName, Kind: "GRPCRoute"}},
 		{&corev1.Service{}, GroupKind{Group: corev1.GroupName, Kind: "Service"}},
+		{&anv1alpha1.ServiceExport{}, GroupKind{Group: anv1alpha1.GroupName, Kind: "ServiceExport"}},
 	}
 
 	t.Run("obj to kind", func(t *testing.T) {
@@ -36,3 +39,13 @@ func TestGroupKind(t *testing.T) {
 		}
 	})
 }
+
+func TestTargetRefGroupKind(t *testing.T) {
+	// ServiceExports of both groups are targeted the same way
+	for _, group := range []string{anv1alpha1.GroupName, anv1alpha1.MulticlusterGroupName} {
+		tr := &TargetRef{Group: gwv1alpha2.Group(group), Kind: "ServiceExport", Name: "svc"}
+		assert.Equal(t, GroupKind{Group: anv1alpha1.GroupName, Kind: "ServiceExport"}, TargetRefGroupKind(tr))
+	}
+	tr := &TargetRef{Group: "example.com", Kind: "ServiceExport", Name: "svc"}
+	assert.Equal(t, GroupKind{Group: "example.com", Kind: "ServiceExport"}, TargetRefGroupKind(tr))
+}
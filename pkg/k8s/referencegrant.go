@@ -0,0 +1,67 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	gwv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+)
+
+// ObjectRef identifies one side of a cross namespace reference
+type ObjectRef struct {
+	Group     string
+	Kind      string
+	Namespace string
+	Name      string
+}
+
+// IsReferencePermitted checks a reference from an object to another one is allowed. References
+// within a namespace are always allowed, cross namespace references need a ReferenceGrant in the
+// namespace of the referenced object listing both sides.
+func IsReferencePermitted(ctx context.Context, c client.Client, from ObjectRef, to ObjectRef) (bool, error) {
+	if from.Namespace == to.Namespace {
+		return true, nil
+	}
+
+	grants := &gwv1beta1.ReferenceGrantList{}
+	if err := c.List(ctx, grants, client.InNamespace(to.Namespace)); err != nil {
+		if meta.IsNoMatchError(err) {
+			// ReferenceGrant CRD is not installed, nothing can grant the reference
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to list ReferenceGrants in namespace %s: %w", to.Namespace, err)
+	}
+
+	for _, grant := range grants.Items {
+		if referenceGrantAllows(grant, from, to) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func referenceGrantAllows(grant gwv1beta1.ReferenceGrant, from ObjectRef, to ObjectRef) bool {
+	fromAllowed := false
+	for _, f := range grant.Spec.From {
+		if string(f.Group) == from.Group && string(f.Kind) == from.Kind && string(f.Namespace) == from.Namespace {
+			fromAllowed = true
+			break
+		}
+	}
+	if !fromAllowed {
+		return false
+	}
+
+	for _, t := range grant.Spec.To {
+		if string(t.Group) != to.Group || string(t.Kind) != to.Kind {
+			continue
+		}
+		// a grant without name allows every object of the kind
+		if t.Name == nil || string(*t.Name) == "" || string(*t.Name) == to.Name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,72 @@
+package k8s
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gwv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+)
+
+func TestIsReferencePermitted(t *testing.T) {
+	ctx := context.TODO()
+	k8sScheme := runtime.NewScheme()
+	gwv1beta1.AddToScheme(k8sScheme)
+	k8sClient := testclient.NewClientBuilder().WithScheme(k8sScheme).Build()
+
+	svcName := gwv1beta1.ObjectName("allowed-svc")
+	grants := []gwv1beta1.ReferenceGrant{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "all-services", Namespace: "backends"},
+			Spec: gwv1beta1.ReferenceGrantSpec{
+				From: []gwv1beta1.ReferenceGrantFrom{{Group: gwv1beta1.GroupName, Kind: "HTTPRoute", Namespace: "frontend"}},
+				To:   []gwv1beta1.ReferenceGrantTo{{Group: "", Kind: "Service"}},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "named-service", Namespace: "restricted"},
+			Spec: gwv1beta1.ReferenceGrantSpec{
+				From: []gwv1beta1.ReferenceGrantFrom{{Group: gwv1beta1.GroupName, Kind: "HTTPRoute", Namespace: "frontend"}},
+				To:   []gwv1beta1.ReferenceGrantTo{{Group: "", Kind: "Service", Name: &svcName}},
+			},
+		},
+	}
+	for _, grant := range grants {
+		assert.NoError(t, k8sClient.Create(ctx, grant.DeepCopy()))
+	}
+
+	route := func(ns string) ObjectRef {
+		return ObjectRef{Group: gwv1beta1.GroupName, Kind: "HTTPRoute", Namespace: ns, Name: "route"}
+	}
+	svc := func(ns, name string) ObjectRef {
+		return ObjectRef{Group: "", Kind: "Service", Namespace: ns, Name: name}
+	}
+
+	tests := []struct {
+		name string
+		from ObjectRef
+		to   ObjectRef
+		want bool
+	}{
+		{"same namespace", route("frontend"), svc("frontend", "svc"), true},
+		{"granted namespace", route("frontend"), svc("backends", "svc"), true},
+		{"no grant in namespace", route("frontend"), svc("other", "svc"), false},
+		{"grant for another namespace", route("other"), svc("backends", "svc"), false},
+		{"grant for another kind", ObjectRef{Group: gwv1beta1.GroupName, Kind: "GRPCRoute", Namespace: "frontend"},
+			svc("backends", "svc"), false},
+		{"grant for another target kind", route("frontend"),
+			ObjectRef{Group: "application-networking.k8s.aws", Kind: "ServiceImport", Namespace: "backends", Name: "svc"}, false},
+		{"named grant matches", route("frontend"), svc("restricted", "allowed-svc"), true},
+		{"named grant does not match", route("frontend"), svc("restricted", "svc"), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			permitted, err := IsReferencePermitted(ctx, k8sClient, tt.from, tt.to)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, permitted)
+		})
+	}
+}
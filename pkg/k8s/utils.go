@@ -9,6 +9,8 @@ import (
 	"k8s.io/client-go/discovery"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/gateway-api/apis/v1beta1"
 )
 
@@ -64,3 +66,25 @@ func ObjExists(ctx context.Context, c client.Client, key types.NamespacedName, o
 	}
 	return true, nil
 }
+
+// AnnotationsChangedPredicate triggers on updates changing one of the given annotations. Unlike
+// predicate.AnnotationChangedPredicate, it ignores the annotations the controller writes itself.
+func AnnotationsChangedPredicate(annotations ...string) predicate.Predicate {
+	return predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			if e.ObjectOld == nil || e.ObjectNew == nil {
+				return false
+			}
+			oldAnnotations := e.ObjectOld.GetAnnotations()
+			newAnnotations := e.ObjectNew.GetAnnotations()
+			for _, annotation := range annotations {
+				oldValue, oldFound := oldAnnotations[annotation]
+				newValue, newFound := newAnnotations[annotation]
+				if oldFound != newFound || oldValue != newValue {
+					return true
+				}
+			}
+			return false
+		},
+	}
+}
@@ -0,0 +1,38 @@
+package k8s
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+)
+
+func TestAnnotationsChangedPredicate(t *testing.T) {
+	watched := AnnotationPrefix + "watched"
+	newObj := func(annotations map[string]string) *corev1.Service {
+		return &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "svc", Annotations: annotations}}
+	}
+
+	tests := []struct {
+		name     string
+		old      map[string]string
+		new      map[string]string
+		expected bool
+	}{
+		{name: "added", old: nil, new: map[string]string{watched: "a"}, expected: true},
+		{name: "changed", old: map[string]string{watched: "a"}, new: map[string]string{watched: "b"}, expected: true},
+		{name: "removed", old: map[string]string{watched: "a"}, new: map[string]string{}, expected: true},
+		{name: "emptied", old: map[string]string{watched: ""}, new: nil, expected: true},
+		{name: "unchanged", old: map[string]string{watched: "a"}, new: map[string]string{watched: "a", "other": "b"}},
+		{name: "other annotation", old: nil, new: map[string]string{"other": "b"}},
+	}
+	p := AnnotationsChangedPredicate(watched, AnnotationPrefix+"unused")
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, p.Update(event.UpdateEvent{ObjectOld: newObj(tt.old), ObjectNew: newObj(tt.new)}))
+		})
+	}
+	assert.True(t, p.Create(event.CreateEvent{Object: newObj(nil)}))
+}
@@ -179,12 +179,20 @@ func (r *GRPCRouteRule) Matches() []RouteMatch {
 	return routeMatches
 }
 
+func (r *GRPCRouteRule) Filters() []gwv1alpha2.GRPCRouteFilter {
+	return r.r.Filters
+}
+
 func (r *GRPCRouteRule) Equals(routeRule RouteRule) bool {
 	other, ok := routeRule.(*GRPCRouteRule)
 	if !ok {
 		return false
 	}
 
+	if !reflect.DeepEqual(r.Filters(), other.Filters()) {
+		return false
+	}
+
 	if len(r.BackendRefs()) != len(other.BackendRefs()) {
 		return false
 	}
@@ -236,6 +244,10 @@ func (r *GRPCBackendRef) Port() *gwv1beta1.PortNumber {
 	return r.r.Port
 }
 
+func (r *GRPCBackendRef) Filters() []gwv1alpha2.GRPCRouteFilter {
+	return r.r.Filters
+}
+
 func (r *GRPCBackendRef) Equals(backendRef BackendRef) bool {
 	other, ok := backendRef.(*GRPCBackendRef)
 	if !ok {
@@ -178,12 +178,20 @@ func (r *HTTPRouteRule) Matches() []RouteMatch {
 	return routeMatches
 }
 
+func (r *HTTPRouteRule) Filters() []gwv1beta1.HTTPRouteFilter {
+	return r.r.Filters
+}
+
 func (r *HTTPRouteRule) Equals(routeRule RouteRule) bool {
 	other, ok := routeRule.(*HTTPRouteRule)
 	if !ok {
 		return false
 	}
 
+	if !reflect.DeepEqual(r.Filters(), other.Filters()) {
+		return false
+	}
+
 	if len(r.BackendRefs()) != len(other.BackendRefs()) {
 		return false
 	}
@@ -239,6 +247,10 @@ func (r *HTTPBackendRef) Port() *gwv1beta1.PortNumber {
 	return r.r.Port
 }
 
+func (r *HTTPBackendRef) Filters() []gwv1beta1.HTTPRouteFilter {
+	return r.r.Filters
+}
+
 func (r *HTTPBackendRef) Equals(backendRef BackendRef) bool {
 	other, ok := backendRef.(*HTTPBackendRef)
 	if !ok {
@@ -3,7 +3,9 @@ package core
 import (
 	"context"
 	"fmt"
+	"reflect"
 
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -14,6 +16,10 @@ import (
 
 type RouteType string
 
+// RouteReasonIncompatibleListeners is the Accepted condition reason of a parentRef whose listener configuration
+// conflicts with the one of a preceding parentRef. The route lattice service is shared by all parents.
+const RouteReasonIncompatibleListeners gwv1.RouteConditionReason = "IncompatibleListeners"
+
 type Route interface {
 	Spec() RouteSpec
 	Status() RouteStatus
@@ -61,6 +67,38 @@ func ListAllRoutes(context context.Context, client client.Client) ([]Route, erro
 	return routes, nil
 }
 
+// Returns true when the route status records that the parent does not accept the route, because
+// none of its listeners allow the route or match the route hostnames, or its listener is incompatible
+// with the one of another parent. The route must not be deployed to such a parent.
+func IsRouteDetachedFromParent(route Route, parentRef gwv1beta1.ParentReference) bool {
+	for _, ps := range route.Status().Parents() {
+		if !reflect.DeepEqual(ps.ParentRef, parentRef) {
+			continue
+		}
+		cnd := meta.FindStatusCondition(ps.Conditions, string(gwv1.RouteConditionAccepted))
+		if cnd == nil || cnd.Status != metav1.ConditionFalse {
+			return false
+		}
+		return cnd.Reason == string(gwv1.RouteReasonNotAllowedByListeners) ||
+			cnd.Reason == string(gwv1.RouteReasonNoMatchingListenerHostname) ||
+			cnd.Reason == string(RouteReasonIncompatibleListeners)
+	}
+	return false
+}
+
+// Returns true when the route has parentRefs and every one of them is detached, see IsRouteDetachedFromParent
+func IsRouteDetachedFromAllParents(route Route) bool {
+	if len(route.Spec().ParentRefs()) == 0 {
+		return false
+	}
+	for _, parentRef := range route.Spec().ParentRefs() {
+		if !IsRouteDetachedFromParent(route, parentRef) {
+			return false
+		}
+	}
+	return true
+}
+
 type RouteSpec interface {
 	ParentRefs() []gwv1beta1.ParentReference
 	Hostnames() []gwv1beta1.Hostname
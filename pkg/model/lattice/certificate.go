@@ -0,0 +1,123 @@
+package lattice
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/aws/aws-application-networking-k8s/pkg/aws"
+	"github.com/aws/aws-application-networking-k8s/pkg/aws/services"
+)
+
+const (
+	K8SSecretNameKey             = aws.TagBase + "SecretName"
+	K8SSecretNamespaceKey        = aws.TagBase + "SecretNamespace"
+	K8SCertificateFingerprintKey = aws.TagBase + "CertificateFingerprint"
+)
+
+// Certificate is the certificate of a kubernetes.io/tls Secret referenced by a gateway listener,
+// it is imported into ACM for the lattice services of the routes attached to the listener
+type Certificate struct {
+	Spec CertificateSpec
+	// PEM encoded certificate, private key and intermediate certificates
+	Certificate      []byte
+	PrivateKey       []byte
+	CertificateChain []byte
+	// subject common name and DNS names of the certificate, which ACM lists it under. Empty when the
+	// certificate cannot be parsed, ACM then rejects it on import.
+	DomainNames []string
+}
+
+type CertificateSpec struct {
+	GatewayName      string
+	GatewayNamespace string
+	SecretName       string
+	SecretNamespace  string
+	// identifies the content of the secret, the certificate is re-imported when it changes
+	Fingerprint string
+}
+
+// NewCertificateFromSecret splits the tls.crt of the secret into the certificate and its chain,
+// the certificate comes first as in any kubernetes.io/tls Secret
+func NewCertificateFromSecret(gatewayName, gatewayNamespace string, secret *corev1.Secret) (*Certificate, error) {
+	crt := secret.Data[corev1.TLSCertKey]
+	key := secret.Data[corev1.TLSPrivateKeyKey]
+	if len(crt) == 0 || len(key) == 0 {
+		return nil, errors.New("secret must have tls.crt and tls.key")
+	}
+
+	var certs [][]byte
+	for rest := crt; ; {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			return nil, errors.New("tls.crt must only contain PEM encoded certificates")
+		}
+		certs = append(certs, pem.EncodeToMemory(block))
+	}
+	if len(certs) == 0 {
+		return nil, errors.New("tls.crt has no PEM encoded certificate")
+	}
+
+	// ACM rejects an empty chain
+	var chain []byte
+	if len(certs) > 1 {
+		chain = bytes.Join(certs[1:], nil)
+	}
+	// any change of the certificate, its chain or its key requires a re-import
+	fingerprint := sha256.Sum256(bytes.Join([][]byte{certs[0], chain, key}, []byte{0}))
+
+	var domainNames []string
+	if block, _ := pem.Decode(certs[0]); block != nil {
+		if parsed, err := x509.ParseCertificate(block.Bytes); err == nil {
+			if parsed.Subject.CommonName != "" {
+				domainNames = append(domainNames, strings.ToLower(parsed.Subject.CommonName))
+			}
+			for _, dnsName := range parsed.DNSNames {
+				domainNames = append(domainNames, strings.ToLower(dnsName))
+			}
+		}
+	}
+	return &Certificate{
+		Spec: CertificateSpec{
+			GatewayName:      gatewayName,
+			GatewayNamespace: gatewayNamespace,
+			SecretName:       secret.Name,
+			SecretNamespace:  secret.Namespace,
+			Fingerprint:      hex.EncodeToString(fingerprint[:]),
+		},
+		Certificate:      certs[0],
+		PrivateKey:       key,
+		CertificateChain: chain,
+		DomainNames:      domainNames,
+	}, nil
+}
+
+func CertificateSpecFromTags(tags services.Tags) CertificateSpec {
+	return CertificateSpec{
+		GatewayName:      getMapValue(tags, K8SGatewayNameKey),
+		GatewayNamespace: getMapValue(tags, K8SGatewayNamespaceKey),
+		SecretName:       getMapValue(tags, K8SSecretNameKey),
+		SecretNamespace:  getMapValue(tags, K8SSecretNamespaceKey),
+		Fingerprint:      getMapValue(tags, K8SCertificateFingerprintKey),
+	}
+}
+
+func (s *CertificateSpec) ToTags() services.Tags {
+	return services.Tags{
+		K8SGatewayNameKey:            &s.GatewayName,
+		K8SGatewayNamespaceKey:       &s.GatewayNamespace,
+		K8SSecretNameKey:             &s.SecretName,
+		K8SSecretNamespaceKey:        &s.SecretNamespace,
+		K8SCertificateFingerprintKey: &s.Fingerprint,
+	}
+}
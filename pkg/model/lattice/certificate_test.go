@@ -0,0 +1,53 @@
+package lattice
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_NewCertificateFromSecret(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "API.example.com"},
+		DNSNames:     []string{"www.example.com"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	assert.NoError(t, err)
+	crt := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	chain := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: []byte("intermediate")})
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "cert", Namespace: "default"},
+		Data:       map[string][]byte{corev1.TLSCertKey: append(crt, chain...), corev1.TLSPrivateKeyKey: []byte("key")},
+	}
+
+	cert, err := NewCertificateFromSecret("gw", "default", secret)
+	assert.NoError(t, err)
+	assert.Equal(t, crt, cert.Certificate)
+	assert.Equal(t, chain, cert.CertificateChain)
+	assert.Equal(t, []string{"api.example.com", "www.example.com"}, cert.DomainNames)
+
+	// the domain names are only used to look the certificate up, ACM validates it on import
+	secret.Data[corev1.TLSCertKey] = chain
+	cert, err = NewCertificateFromSecret("gw", "default", secret)
+	assert.NoError(t, err)
+	assert.Empty(t, cert.DomainNames)
+
+	delete(secret.Data, corev1.TLSPrivateKeyKey)
+	_, err = NewCertificateFromSecret("gw", "default", secret)
+	assert.Error(t, err)
+}
@@ -73,9 +73,6 @@ func (spec *ListenerSpec) Validate() error {
 	if isFixedResponse == isForward { // either both true or both false
 		return fmt.Errorf("invalid listener default action, must be either fixed response or forward")
 	}
-	if spec.Protocol != vpclattice.ListenerProtocolTlsPassthrough && !isFixedResponse {
-		return fmt.Errorf("non TLS_PASSTHROUGH listener default action must be fixed response")
-	}
 	if spec.Protocol == vpclattice.ListenerProtocolTlsPassthrough && !isForward {
 		return fmt.Errorf("TLS_PASSTHROUGH listener default action must be forward")
 	}
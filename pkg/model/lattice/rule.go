@@ -34,6 +34,8 @@ type RuleSpec struct {
 
 type RuleAction struct {
 	TargetGroups []*RuleTargetGroup `json:"ruletarget"`
+	// when set, the rule returns a fixed response with this status code instead of forwarding
+	FixedResponseStatusCode *int64 `json:"fixedresponsestatuscode"`
 }
 
 type RuleTargetGroup struct {
@@ -48,6 +50,18 @@ type SvcImportTargetGroup struct {
 	K8SServiceName      string `json:"k8sservicename"`
 	K8SServiceNamespace string `json:"k8sservicenamespace"`
 	VpcId               string `json:"vpcid"`
+	K8SServicePort      int32  `json:"k8sserviceport,omitempty"`
+}
+
+// Matches returns true when the target group of a ServiceExport is exported for the ServiceImport,
+// from the cluster and VPC it selects if any. Target groups exported without a port match any port.
+func (t *SvcImportTargetGroup) Matches(tg *TargetGroup) bool {
+	svcMatch := tg.Spec.IsSourceTypeServiceExport() && tg.Spec.K8SServiceName == t.K8SServiceName &&
+		tg.Spec.K8SServiceNamespace == t.K8SServiceNamespace
+	clusterMatch := t.K8SClusterName == "" || tg.Spec.K8SClusterName == t.K8SClusterName
+	vpcMatch := t.VpcId == "" || tg.Spec.VpcId == t.VpcId
+	portMatch := t.K8SServicePort == 0 || tg.Spec.K8SServicePort == 0 || tg.Spec.K8SServicePort == t.K8SServicePort
+	return svcMatch && clusterMatch && vpcMatch && portMatch
 }
 
 type RuleStatus struct {
@@ -1,6 +1,8 @@
 package lattice
 
 import (
+	"k8s.io/apimachinery/pkg/types"
+
 	"github.com/aws/aws-application-networking-k8s/pkg/aws/services"
 	"github.com/aws/aws-application-networking-k8s/pkg/model/core"
 	"github.com/aws/aws-application-networking-k8s/pkg/utils"
@@ -18,6 +20,10 @@ type ServiceSpec struct {
 	ServiceNetworkNames []string `json:"servicenetworkhnames"`
 	CustomerDomainName  string   `json:"customerdomainname"`
 	CustomerCertARN     string   `json:"customercertarn"`
+	// route configuring and owning the DNS records of a merged service, the one taking precedence
+	DnsRouteName      string         `json:"dnsroutename,omitempty"`
+	DnsRouteNamespace string         `json:"dnsroutenamespace,omitempty"`
+	DnsRouteType      core.RouteType `json:"dnsroutetype,omitempty"`
 }
 
 type ServiceStatus struct {
@@ -30,18 +36,32 @@ type ServiceTagFields struct {
 	RouteName      string
 	RouteNamespace string
 	RouteType      core.RouteType
+	// set instead of the route name, namespace and type when the service merges the routes of a gateway hostname
+	GatewayName      string
+	GatewayNamespace string
+	Hostname         string
 }
 
 func ServiceTagFieldsFromTags(tags map[string]*string) ServiceTagFields {
 	return ServiceTagFields{
-		RouteName:      getMapValue(tags, K8SRouteNameKey),
-		RouteNamespace: getMapValue(tags, K8SRouteNamespaceKey),
-		RouteType:      core.RouteType(getMapValue(tags, K8SRouteTypeKey)),
+		RouteName:        getMapValue(tags, K8SRouteNameKey),
+		RouteNamespace:   getMapValue(tags, K8SRouteNamespaceKey),
+		RouteType:        core.RouteType(getMapValue(tags, K8SRouteTypeKey)),
+		GatewayName:      getMapValue(tags, K8SGatewayNameKey),
+		GatewayNamespace: getMapValue(tags, K8SGatewayNamespaceKey),
+		Hostname:         getMapValue(tags, K8SHostnameKey),
 	}
 }
 
 func (t *ServiceTagFields) ToTags() services.Tags {
 	rt := string(t.RouteType)
+	if t.IsMerged() {
+		return services.Tags{
+			K8SGatewayNameKey:      &t.GatewayName,
+			K8SGatewayNamespaceKey: &t.GatewayNamespace,
+			K8SHostnameKey:         &t.Hostname,
+		}
+	}
 	return services.Tags{
 		K8SRouteNameKey:      &t.RouteName,
 		K8SRouteNamespaceKey: &t.RouteNamespace,
@@ -49,6 +69,19 @@ func (t *ServiceTagFields) ToTags() services.Tags {
 	}
 }
 
+// OwnerName identifies the route, or the gateway hostname of a merged service, the service is deployed for
+func (t *ServiceTagFields) OwnerName() string {
+	if t.IsMerged() {
+		return t.GatewayNamespace + "/" + t.GatewayName + "/" + t.Hostname
+	}
+	return t.RouteNamespace + "/" + t.RouteName
+}
+
+// IsMerged returns true when the service merges the routes of a gateway hostname instead of serving a single route
+func (t *ServiceTagFields) IsMerged() bool {
+	return t.Hostname != ""
+}
+
 func NewLatticeService(stack core.Stack, spec ServiceSpec) (*Service, error) {
 	id := spec.LatticeServiceName()
 
@@ -66,10 +99,21 @@ func NewLatticeService(stack core.Stack, spec ServiceSpec) (*Service, error) {
 	return service, nil
 }
 
+// DnsRoute returns the route configuring and owning the DNS records of the service, and its type
+func (s *ServiceSpec) DnsRoute() (types.NamespacedName, core.RouteType) {
+	if s.IsMerged() {
+		return types.NamespacedName{Namespace: s.DnsRouteNamespace, Name: s.DnsRouteName}, s.DnsRouteType
+	}
+	return types.NamespacedName{Namespace: s.RouteNamespace, Name: s.RouteName}, s.RouteType
+}
+
 func (s *Service) LatticeServiceName() string {
 	return s.Spec.LatticeServiceName()
 }
 
 func (s *ServiceSpec) LatticeServiceName() string {
+	if s.IsMerged() {
+		return utils.MergedLatticeServiceName(s.GatewayName, s.GatewayNamespace, s.Hostname)
+	}
 	return utils.LatticeServiceName(s.RouteName, s.RouteNamespace)
 }
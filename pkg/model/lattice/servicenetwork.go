@@ -23,6 +23,9 @@ type ServiceNetworkSpec struct {
 	SecurityGroupIds []*string `json:"securityGroupIds"`
 	AssociateToVPC   bool
 	IsDeleted        bool
+	// AuthType and Tags only apply when the service network is created
+	AuthType string            `json:"authtype"`
+	Tags     map[string]string `json:"tags"`
 }
 
 type ServiceNetworkStatus struct {
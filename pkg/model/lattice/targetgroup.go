@@ -3,6 +3,7 @@ package lattice
 import (
 	"errors"
 	"fmt"
+	"strconv"
 
 	"github.com/aws/aws-sdk-go/service/vpclattice"
 
@@ -19,9 +20,13 @@ const (
 	K8SRouteNamespaceKey   = aws.TagBase + "RouteNamespace"
 	K8SSourceTypeKey       = aws.TagBase + "SourceTypeKey"
 	K8SProtocolVersionKey  = aws.TagBase + "ProtocolVersion"
+	K8SServicePortKey      = aws.TagBase + "ServicePort"
 
 	// Service specific tags
-	K8SRouteTypeKey = aws.TagBase + "RouteType"
+	K8SRouteTypeKey        = aws.TagBase + "RouteType"
+	K8SGatewayNameKey      = aws.TagBase + "GatewayName"
+	K8SGatewayNamespaceKey = aws.TagBase + "GatewayNamespace"
+	K8SHostnameKey         = aws.TagBase + "Hostname"
 
 	MaxNamespaceLength = 55
 	MaxNameLength      = 55
@@ -53,6 +58,8 @@ type TargetGroupTagFields struct {
 	K8SRouteName        string        `json:"k8sroutename"`
 	K8SRouteNamespace   string        `json:"k8sroutenamespace"`
 	K8SProtocolVersion  string        `json:"k8sprotocolversion"`
+	// K8SServicePort is only set for the target groups of ports declared in the spec of a service export
+	K8SServicePort int32 `json:"k8sserviceport,omitempty"`
 }
 
 type TargetGroupStatus struct {
@@ -84,6 +91,7 @@ func TGTagFieldsFromTags(tags map[string]*string) TargetGroupTagFields {
 		K8SRouteName:        getMapValue(tags, K8SRouteNameKey),
 		K8SRouteNamespace:   getMapValue(tags, K8SRouteNamespaceKey),
 		K8SProtocolVersion:  getMapValue(tags, K8SProtocolVersionKey),
+		K8SServicePort:      getPortValue(tags, K8SServicePortKey),
 	}
 }
 
@@ -101,6 +109,10 @@ func TagsFromTGTagFields(tagFields TargetGroupTagFields) map[string]*string {
 		tags[K8SRouteNameKey] = &tagFields.K8SRouteName
 		tags[K8SRouteNamespaceKey] = &tagFields.K8SRouteNamespace
 	}
+	if tagFields.K8SServicePort != 0 {
+		port := strconv.Itoa(int(tagFields.K8SServicePort))
+		tags[K8SServicePortKey] = &port
+	}
 	return tags
 }
 
@@ -112,6 +124,14 @@ func getMapValue(m map[string]*string, key string) string {
 	return *v
 }
 
+func getPortValue(m map[string]*string, key string) int32 {
+	port, err := strconv.ParseInt(getMapValue(m, key), 10, 32)
+	if err != nil {
+		return 0
+	}
+	return int32(port)
+}
+
 func GetParentRefType(s string) K8SSourceType {
 	if s == "" {
 		return "" // empty is OK
@@ -1,6 +1,8 @@
 package utils
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"math/rand"
 	"strings"
@@ -64,6 +66,19 @@ func LatticeServiceName(k8sSourceRouteName string, k8sSourceRouteNamespace strin
 	return fmt.Sprintf("%s-%s", Truncate(k8sSourceRouteName, 20), Truncate(k8sSourceRouteNamespace, 18))
 }
 
+// MergedLatticeServiceName returns the name of the lattice service merging the routes of a gateway hostname.
+// The hostname is shortened to fit the 40 characters of a service name, the hash suffix keeps names unique.
+func MergedLatticeServiceName(gatewayName string, gatewayNamespace string, hostname string) string {
+	hostname = strings.ToLower(hostname)
+	hash := sha256.Sum256([]byte(fmt.Sprintf("%s/%s/%s", gatewayNamespace, gatewayName, hostname)))
+	name := strings.ReplaceAll(hostname, ".", "-")
+	// service names cannot have consecutive hyphens, e.g. of punycode labels
+	for strings.Contains(name, "--") {
+		name = strings.ReplaceAll(name, "--", "-")
+	}
+	return fmt.Sprintf("%s-%s", Truncate(name, 31), hex.EncodeToString(hash[:])[:8])
+}
+
 func TargetRefToLatticeResourceName(
 	targetRef *gwv1alpha2.PolicyTargetReference,
 	parentNamespace string,
@@ -61,3 +61,14 @@ func TestSet(t *testing.T) {
 	})
 
 }
+
+func TestMergedLatticeServiceName(t *testing.T) {
+	name := MergedLatticeServiceName("gw", "ns", "api.Example.com")
+	assert.Regexp(t, "^api-example-com-[0-9a-f]{8}$", name)
+	assert.Equal(t, name, MergedLatticeServiceName("gw", "ns", "api.example.com"))
+	assert.NotEqual(t, name, MergedLatticeServiceName("other-gw", "ns", "api.example.com"))
+
+	name = MergedLatticeServiceName("gw", "ns", "a-very-long-subdomain.xn--bcher-kva.example.com")
+	assert.Regexp(t, "^a-very-long-subdomain-xn-bcher-[0-9a-f]{8}$", name)
+	assert.LessOrEqual(t, len(name), 40)
+}